@@ -1,15 +1,20 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"catops/internal/commands"
 	"catops/internal/config"
+	"catops/internal/logger"
+	"catops/internal/metrics"
 	"catops/internal/ui"
+	"catops/pkg/utils"
 )
 
 // VERSION is set during build via ldflags
@@ -31,12 +36,46 @@ func getCurrentVersion() string {
 
 func main() {
 	// load configuration
-	_, err := config.LoadConfig()
-	if err != nil {
+	// A missing config file gives the defaults. An unreadable one stops here,
+	// a broken one is reported and left to the commands that need it, so help,
+	// version and uninstall still work.
+	cfg, err := config.LoadConfig()
+	switch {
+	case errors.Is(err, config.ErrConfigInvalid):
+		fmt.Fprintf(os.Stderr, "warning: %v\n%s\n", err, config.ErrorHint(err))
+		cfg = config.DefaultConfig()
+	case err != nil:
 		ui.PrintErrorWithSupport(fmt.Sprintf("Error loading config: %v", err))
+		if hint := config.ErrorHint(err); hint != "" {
+			ui.PrintStatus("info", hint)
+		}
 		os.Exit(1)
 	}
 
+	// The daemon log may be moved off /tmp (log_file)
+	if cfg.LogFile != "" {
+		if err := logger.SetPath(cfg.LogFile); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: can't write log_file %s, logging to %s: %v\n", cfg.LogFile, logger.Path(), err)
+		}
+	}
+
+	// Apply collection and network settings shared by all commands
+	metrics.SetSkippedFstypes(cfg.SkipFstypes)
+	metrics.SetNetworkSummary(cfg.NetworkSummary)
+	metrics.SetDiskMounts(cfg.DiskMounts)
+	metrics.SetHTTPSPorts(cfg.HTTPSPorts, cfg.HTTPSInbound)
+	metrics.SetProcessFilters(cfg.ProcessExcludes, cfg.ProcessIncludes)
+	metrics.SetProcessDetailLimit(cfg.ProcessDetailLimit)
+	metrics.SetSkipPrivileged(cfg.SkipPrivilegedMetrics)
+	metrics.SetTrackedUnits(cfg.SystemdUnits)
+	metrics.SetContainerLabelKeys(cfg.ContainerServiceLabel, cfg.ContainerEnvLabel)
+	metrics.SetLogDedupWindow(time.Duration(cfg.LogDedupWindow) * time.Second)
+	metrics.SetLogRateLimit(cfg.LogRateLimit)
+	metrics.SetDisabledLogSources(cfg.LogSourcesDisabled)
+	utils.SetHTTPTimeout(cfg.RequestTimeout())
+	utils.SetMinFreeDisk(uint64(max(cfg.MinFreeDisk, 0)) * 1024 * 1024)
+	utils.SetRequestHeaders(cfg.UserAgent, cfg.HTTPHeaders, cfg.HTTPHeadersHosts, cfg.HTTPHeadersInsecure)
+
 	// Set version function for commands package
 	commands.GetCurrentVersion = getCurrentVersion
 
@@ -116,6 +155,17 @@ Need help? Telegram: @mfhonley`,
 	// add version flag
 	rootCmd.Flags().BoolP("version", "v", false, "Show version information")
 
+	// global quiet flag for automation (plain status lines, no header/sections)
+	var quiet bool
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Plain output without header and sections (for scripts)")
+	// global no-color flag (NO_COLOR and a redirected stdout disable colors as well)
+	var noColor bool
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colors and terminal escape sequences")
+	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		ui.SetQuiet(quiet)
+		ui.SetNoColor(noColor)
+	}
+
 	// Create all commands using commands package
 	statusCmd := commands.NewStatusCmd()
 	processesCmd := commands.NewProcessesCmd()
@@ -131,6 +181,18 @@ Need help? Telegram: @mfhonley`,
 	authCmd := commands.NewAuthCmd()
 	askCmd := commands.NewAskCmd()
 	serviceCmd := commands.NewServiceCmd() // New: system service management
+	snapshotCmd := commands.NewSnapshotCmd()
+	historyCmd := commands.NewHistoryCmd()
+	servicesCmd := commands.NewServicesCmd()
+	maintenanceCmd := commands.NewMaintenanceCmd()
+	certsCmd := commands.NewCertsCmd()
+	unitsCmd := commands.NewUnitsCmd()
+	logsCmd := commands.NewLogsCmd()
+	logCmd := commands.NewLogCmd()
+	logPathCmd := commands.NewLogPathCmd()
+	benchmarkCmd := commands.NewBenchmarkCmd()
+	alertsCmd := commands.NewAlertsCmd()
+	connectionsCmd := commands.NewConnectionsCmd()
 
 	// add commands to root
 	rootCmd.AddCommand(statusCmd)
@@ -147,6 +209,18 @@ Need help? Telegram: @mfhonley`,
 	rootCmd.AddCommand(authCmd)
 	rootCmd.AddCommand(askCmd)
 	rootCmd.AddCommand(serviceCmd) // New: catops service install/start/stop/status
+	rootCmd.AddCommand(snapshotCmd)
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(servicesCmd)
+	rootCmd.AddCommand(maintenanceCmd)
+	rootCmd.AddCommand(certsCmd)
+	rootCmd.AddCommand(unitsCmd)
+	rootCmd.AddCommand(logsCmd)
+	rootCmd.AddCommand(logCmd)
+	rootCmd.AddCommand(logPathCmd)
+	rootCmd.AddCommand(benchmarkCmd)
+	rootCmd.AddCommand(alertsCmd)
+	rootCmd.AddCommand(connectionsCmd)
 
 	// execute
 	if err := rootCmd.Execute(); err != nil {
@@ -6,11 +6,14 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"catops/internal/k8s"
 	"catops/internal/logger"
+
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 const (
@@ -49,6 +52,18 @@ func main() {
 	logger.Info("   Node Name: %s", config.NodeName)
 	logger.Info("   Namespace: %s", config.Namespace)
 	logger.Info("   Collection Interval: %ds", config.CollectionInterval)
+	if len(config.PodNamespaces) > 0 {
+		logger.Info("   Pod Namespaces: %s", strings.Join(config.PodNamespaces, ", "))
+	}
+	if config.PodLabelSelector != "" {
+		logger.Info("   Pod Label Selector: %s", config.PodLabelSelector)
+	}
+	if config.HealthPort > 0 {
+		logger.Info("   Health Port: %d", config.HealthPort)
+	}
+	if config.Environment != "" || config.Region != "" {
+		logger.Info("   Environment: %s, Region: %s", config.Environment, config.Region)
+	}
 	fmt.Println()
 
 	// Создаем Kubernetes client
@@ -81,32 +96,74 @@ func main() {
 		cancel()
 	}()
 
+	// Health/readiness endpoints для probes DaemonSet (HEALTH_PORT=0 отключает)
+	interval := time.Duration(config.CollectionInterval) * time.Second
+	health := k8s.NewHealthServer(k8sClient, interval)
+	if config.HealthPort > 0 {
+		go func() {
+			if err := health.Serve(ctx, config.HealthPort); err != nil {
+				logger.Error("Failed to start health server: %v", err)
+			}
+		}()
+	}
+
 	logger.Info("🚀 Starting metrics collection...")
 	fmt.Println()
 
-	// Основной цикл сбора метрик
-	ticker := time.NewTicker(time.Duration(config.CollectionInterval) * time.Second)
-	defer ticker.Stop()
-
-	// Первый сбор сразу при старте
-	if err := collector.CollectAndSend(ctx); err != nil {
-		logger.Error("Failed to collect metrics: %v", err)
-	}
+	// Основной цикл сбора метрик: первый сбор сразу при старте, затем по
+	// расписанию; после ошибок следующая попытка откладывается с backoff
+	timer := time.NewTimer(0)
+	defer timer.Stop()
 
-	// Затем по расписанию
+	failures := 0
 	for {
 		select {
 		case <-ctx.Done():
 			logger.Info("👋 Shutdown complete")
 			return
-		case <-ticker.C:
+		case <-timer.C:
 			if err := collector.CollectAndSend(ctx); err != nil {
-				logger.Error("Failed to collect metrics: %v", err)
+				failures++
+				health.RecordFailure()
+				delay := retryDelay(interval, failures)
+				logger.Error("Failed to collect metrics (%d consecutive failures, retrying in %s): %v", failures, delay, err)
+				timer.Reset(delay)
+				continue
 			}
+			if failures > 0 {
+				logger.Info("✅ Collection recovered after %d consecutive failures", failures)
+			}
+			failures = 0
+			health.RecordSuccess()
+			timer.Reset(interval)
 		}
 	}
 }
 
+const (
+	// retryBase первая повторная попытка после ошибки (раньше обычного интервала)
+	retryBase = 10 * time.Second
+	// retryMax потолок backoff (если интервал не больше)
+	retryMax = 10 * time.Minute
+)
+
+// retryDelay возвращает паузу после failures ошибок подряд: retryBase,
+// удваиваясь с каждой ошибкой, до max(retryMax, interval)
+func retryDelay(interval time.Duration, failures int) time.Duration {
+	limit := retryMax
+	if interval > limit {
+		limit = interval
+	}
+	delay := retryBase
+	for i := 1; i < failures && delay < limit; i++ {
+		delay *= 2
+	}
+	if delay > limit {
+		delay = limit
+	}
+	return delay
+}
+
 // Config конфигурация приложения
 type Config struct {
 	// Backend API
@@ -119,10 +176,23 @@ type Config struct {
 	SecretName string // Secret name for permanent token updates
 
 	// Collection settings
-	CollectionInterval int // seconds
+	CollectionInterval int      // seconds
+	PodNamespaces      []string // пусто = все namespaces
+	PodLabelSelector   string   // пусто = все поды
+	PodLimitThreshold  float64  // % от limit, с которого под помечается near_limit (0 = выкл)
 
 	// Prometheus (optional)
-	PrometheusURL string
+	PrometheusURL            string
+	PrometheusMetricPrefixes []string // series imported as-is, e.g. node_load
+
+	// Health endpoints (0 = disabled)
+	HealthPort int
+
+	// Resource attributes добавляемые ко всем метрикам: deployment.environment,
+	// cloud.region и catops.label.<key> из CATOPS_TAGS
+	Environment string
+	Region      string
+	Tags        map[string]string
 }
 
 // Validate проверяет конфигурацию
@@ -139,6 +209,18 @@ func (c *Config) Validate() error {
 	if c.CollectionInterval < 10 {
 		return fmt.Errorf("collection interval must be at least 10 seconds")
 	}
+	if _, err := labels.Parse(c.PodLabelSelector); err != nil {
+		return fmt.Errorf("invalid POD_LABEL_SELECTOR: %w", err)
+	}
+	if c.PodLimitThreshold < 0 || c.PodLimitThreshold > 100 {
+		return fmt.Errorf("POD_LIMIT_THRESHOLD must be between 0 (disabled) and 100 percent")
+	}
+	if c.HealthPort < 0 || c.HealthPort > 65535 {
+		return fmt.Errorf("HEALTH_PORT must be between 0 (disabled) and 65535")
+	}
+	if c.Tags == nil {
+		return fmt.Errorf("CATOPS_TAGS must be key=value pairs separated by commas")
+	}
 	return nil
 }
 
@@ -149,6 +231,27 @@ func (c *Config) GetNodeName() string      { return c.NodeName }
 func (c *Config) GetNamespace() string     { return c.Namespace }
 func (c *Config) GetSecretName() string    { return c.SecretName }
 func (c *Config) GetPrometheusURL() string { return c.PrometheusURL }
+func (c *Config) GetPrometheusMetricPrefixes() []string {
+	return c.PrometheusMetricPrefixes
+}
+func (c *Config) GetPodNamespaces() []string    { return c.PodNamespaces }
+func (c *Config) GetPodLabelSelector() string   { return c.PodLabelSelector }
+func (c *Config) GetPodLimitThreshold() float64 { return c.PodLimitThreshold }
+
+// GetResourceAttributes возвращает attributes, которыми помечаются все метрики
+func (c *Config) GetResourceAttributes() map[string]string {
+	attrs := make(map[string]string, len(c.Tags)+2)
+	for k, v := range c.Tags {
+		attrs["catops.label."+k] = v
+	}
+	if c.Environment != "" {
+		attrs["deployment.environment"] = c.Environment
+	}
+	if c.Region != "" {
+		attrs["cloud.region"] = c.Region
+	}
+	return attrs
+}
 
 // loadConfig загружает конфигурацию из environment variables
 func loadConfig() (*Config, error) {
@@ -160,6 +263,17 @@ func loadConfig() (*Config, error) {
 		SecretName:         getEnv("SECRET_NAME", "catops"), // Default to "catops"
 		CollectionInterval: getEnvInt("COLLECTION_INTERVAL", 60),
 		PrometheusURL:      getEnv("PROMETHEUS_URL", ""), // Optional
+		HealthPort:         getEnvInt("HEALTH_PORT", 8080),
+		PodLabelSelector:   getEnv("POD_LABEL_SELECTOR", ""),
+		PodLimitThreshold:  float64(getEnvInt("POD_LIMIT_THRESHOLD", 90)),
+		// POD_NAMESPACES: через запятую, например "prod,staging"
+		PodNamespaces: getEnvList("POD_NAMESPACES"),
+		// PROMETHEUS_METRIC_PREFIXES: через запятую, например "node_load,node_filesystem_"
+		PrometheusMetricPrefixes: getEnvList("PROMETHEUS_METRIC_PREFIXES"),
+		Environment:              getEnv("CATOPS_ENVIRONMENT", ""),
+		Region:                   getEnv("CATOPS_REGION", ""),
+		// CATOPS_TAGS: "team=payments,cluster=eu-1"
+		Tags: getEnvMap("CATOPS_TAGS"),
 	}
 
 	return config, nil
@@ -173,6 +287,32 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getEnvList получает environment variable как список через запятую
+func getEnvList(key string) []string {
+	var list []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// getEnvMap получает environment variable как пары key=value через запятую;
+// nil, если пара без "="
+func getEnvMap(key string) map[string]string {
+	m := make(map[string]string)
+	for _, item := range getEnvList(key) {
+		k, v, ok := strings.Cut(item, "=")
+		k = strings.TrimSpace(k)
+		if !ok || k == "" {
+			return nil
+		}
+		m[k] = strings.TrimSpace(v)
+	}
+	return m
+}
+
 // getEnvInt получает environment variable как int с default значением
 func getEnvInt(key string, defaultValue int) int {
 	if value := os.Getenv(key); value != "" {
@@ -7,9 +7,9 @@ const (
 
 	// OpenTelemetry Protocol (OTLP) endpoints
 	// Metrics are now sent via OTLP instead of REST API
-	OTLP_ENDPOINT   = "api.catops.app"  // OTLP HTTP endpoint host (SDK adds /api/v1/metrics)
-	OTLP_PATH       = "/api/v1/metrics" // Custom path for CatOps OTLP receiver
-	OTLP_LOGS_PATH  = "/api/v1/logs"    // Custom path for CatOps OTLP logs receiver
+	OTLP_ENDPOINT  = "api.catops.app"  // OTLP HTTP endpoint host (SDK adds /api/v1/metrics)
+	OTLP_PATH      = "/api/v1/metrics" // Custom path for CatOps OTLP receiver
+	OTLP_LOGS_PATH = "/api/v1/logs"    // Custom path for CatOps OTLP logs receiver
 
 	// Server management endpoints
 	SERVERS_URL   = "https://api.catops.app/api/cli/servers/change-owner"
@@ -22,6 +22,7 @@ const (
 
 	// Download endpoints
 	GET_CATOPS_URL = "https://get.catops.app"
+	RELEASES_URL   = "https://github.com/mfhonley/catops/releases/download" // + /v{version}/{asset}
 )
 
 // HTTP headers required by new backend
@@ -46,12 +47,47 @@ const (
 
 // Default monitoring configuration
 const (
-	DEFAULT_COLLECTION_INTERVAL = 30 // seconds (optimized from 15 for better resource usage)
+	DEFAULT_COLLECTION_INTERVAL  = 30  // seconds (optimized from 15 for better resource usage)
+	DEFAULT_ALERT_CHECK_INTERVAL = 60  // seconds between local alert threshold evaluations
+	DEFAULT_EXPORT_STALE_AFTER   = 600 // seconds without a successful export before a local alert (at least 3 export periods)
+	MIN_COLLECTION_INTERVAL      = 10  // seconds, lower bound for interval (config and daemon --interval)
+
+	DEFAULT_MAINTENANCE_DURATION = 1800  // seconds (30 minutes)
+	MAX_MAINTENANCE_DURATION     = 86400 // seconds, a forgotten window expires on its own
+
+	DEFAULT_CERT_EXPIRY_WARNING_DAYS = 14 // alert when a monitored certificate expires within this many days
+
+	DEFAULT_HTTP_TIMEOUT   = 10 // seconds, backend API requests (registration, events, version checks)
+	DEFAULT_UPLOAD_TIMEOUT = 30 // seconds, bulk OTLP metric/log exports
+
+	DEFAULT_LOG_DEDUP_WINDOW = 600 // seconds a sent log line is remembered so it isn't resent
+
+	DEFAULT_MIN_FREE_DISK_MB = 100 // MB left free when catops writes its own buffers, history and snapshots
+
+	DEFAULT_ALERT_HOOK_TIMEOUT = 30 // seconds an alert_hook may run before it is killed
+
+	DEFAULT_SMTP_PORT = 587 // SMTP submission port for email alerts (STARTTLS)
+
+	DEFAULT_PROCESS_DETAIL_LIMIT = 20 // top processes enriched with exe/user/ppid/IO (matches the OTLP export)
+)
+
+// Container labels read as the service name and environment by default
+const (
+	DEFAULT_CONTAINER_SERVICE_LABEL = "com.catops.service"
+	DEFAULT_CONTAINER_ENV_LABEL     = "com.catops.env"
 )
 
+// Network/remote filesystems skipped in disk metrics by default
+// (an unreachable remote can hang statfs for seconds)
+var DEFAULT_SKIP_FSTYPES = []string{"nfs", "nfs4", "cifs", "smbfs", "fuse.*", "9p"}
+
+// Ports counted as HTTPS connections by default (https_ports)
+var DEFAULT_HTTPS_PORTS = []int{443}
+
 // File paths
 const (
-	CONFIG_DIR_NAME = "/.catops"
-	PID_FILE        = "/tmp/catops.pid"
-	LOG_FILE        = "/tmp/catops.log"
+	CONFIG_DIR_NAME   = "/.catops"
+	PID_FILE          = "/tmp/catops.pid"
+	LOG_FILE          = "/tmp/catops.log"
+	METRICS_DUMP_FILE = "/tmp/catops-metrics.json" // written by the daemon on SIGUSR1
 )
@@ -0,0 +1,830 @@
+// Package alerts evaluates local alert thresholds in the daemon.
+// CPU, memory and disk usage alerts are processed on the backend from OTLP metrics;
+// this package covers checks configured on the host itself.
+package alerts
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"catops/internal/certs"
+	"catops/internal/config"
+	"catops/internal/logger"
+	"catops/internal/metrics"
+	"catops/internal/ui"
+	"catops/pkg/utils"
+)
+
+// Alert types
+const (
+	TypeIOPS        = "iops"
+	TypeThroughput  = "throughput"
+	TypeMaintenance = "maintenance"
+	TypeFD          = "fd"
+	TypeProcessIO   = "process_io"
+	TypeCertExpiry  = "cert_expiry"
+	TypeUnit        = "unit"
+	TypeNetRate     = "net_rate"
+	TypeRuntime     = "container_runtime"
+	TypeService     = "required_service"
+	TypeMemory      = "mem_available"
+	TypeExportStale = "export_stale"
+	TypeTemperature = "temperature"
+	TypeThrottle    = "thermal_throttle"
+)
+
+// Alert represents a threshold violation detected by the daemon
+type Alert struct {
+	Type      string    // alert type (iops, throughput, ...)
+	Severity  string    // warning, critical
+	Subject   string    // device, mount or service the alert is about
+	Value     float64   // observed value
+	Threshold float64   // configured threshold
+	Message   string    // human readable description
+	Timestamp time.Time // when the alert fired
+
+	PID     int               // process the alert is about (process-level alerts only)
+	Details map[string]string // process forensics captured when the alert fired (capture_process_detail_on_alert)
+}
+
+// Severities
+const (
+	SeverityInfo     = "info" // notices that need no action (maintenance, recovery)
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// Tier is a warning/critical threshold pair (0 disables a level)
+type Tier struct {
+	Warning  float64
+	Critical float64
+}
+
+// Enabled reports whether either level is set
+func (t Tier) Enabled() bool {
+	return t.Warning > 0 || t.Critical > 0
+}
+
+// Check returns the severity of value and the threshold it crossed
+func (t Tier) Check(value float64) (string, float64, bool) {
+	if t.Critical > 0 && value >= t.Critical {
+		return SeverityCritical, t.Critical, true
+	}
+	if t.Warning > 0 && value >= t.Warning {
+		return SeverityWarning, t.Warning, true
+	}
+	return "", 0, false
+}
+
+// Thresholds holds locally evaluated alert thresholds
+type Thresholds struct {
+	IOPS       Tier // read+write operations per second, per device
+	Throughput Tier // read+write bytes per second, per device
+	FDPercent  Tier // open file descriptors as % of the process nofile limit
+	ProcessIO  Tier // read+write bytes per second, per process
+
+	NetRate        Tier // bytes per second in either direction, per interface
+	NetRatePercent Tier // % of the link speed, only for interfaces reporting one
+
+	// MemAvailableMin is the available memory floor in bytes (0 = disabled)
+	MemAvailableMin uint64
+
+	// Temperature is the warning level in °C for any sensor, critical is the
+	// sensor's own critical mark; enabling it also alerts on CPU throttling
+	Temperature Tier
+
+	// RequiredServices must always be detected (by name or type), a missing one is critical
+	RequiredServices []string
+
+	// Sustained is how long a violation must persist before it fires (0 = immediately)
+	Sustained time.Duration
+
+	// WarmUp is how long after start violations are not reported, while CPU and
+	// rate metrics still lack a previous sample to compute deltas from
+	WarmUp time.Duration
+
+	// Trend appends a sparkline of recent values and their direction to alert messages
+	Trend bool
+
+	// ProcessDetail captures the full command line, cwd and owner of the process a
+	// process-level alert is about, once when it fires; ProcessEnv adds its environment
+	ProcessDetail bool
+	ProcessEnv    bool
+}
+
+// defaultFDCritical is used when only the fd warning level is configured
+const defaultFDCritical = 95
+
+// ThresholdsFromConfig builds thresholds from the user configuration
+func ThresholdsFromConfig(cfg *config.Config) Thresholds {
+	fdCritical := cfg.FDCritical
+	if fdCritical == 0 && cfg.FDThreshold > 0 && cfg.FDThreshold < defaultFDCritical {
+		fdCritical = defaultFDCritical
+	}
+
+	const mb = 1024 * 1024 // MB/s -> bytes/s
+	return Thresholds{
+		IOPS:       Tier{Warning: float64(cfg.IOPSThreshold), Critical: float64(cfg.IOPSCritical)},
+		Throughput: Tier{Warning: cfg.ThroughputThreshold * mb, Critical: cfg.ThroughputCritical * mb},
+		FDPercent:  Tier{Warning: cfg.FDThreshold, Critical: fdCritical},
+		ProcessIO:  Tier{Warning: cfg.ProcessIOThreshold * mb},
+		Sustained:  time.Duration(cfg.SustainedDuration) * time.Second,
+		WarmUp:     cfg.AlertWarmUpPeriod(),
+		Trend:      cfg.AlertTrend,
+
+		ProcessDetail: cfg.CaptureProcessDetailOnAlert,
+		ProcessEnv:    cfg.CaptureProcessDetailOnAlert && cfg.CaptureProcessEnv,
+
+		NetRate:        Tier{Warning: cfg.NetRateThreshold * mb},
+		NetRatePercent: Tier{Warning: cfg.NetRatePercent},
+
+		MemAvailableMin: uint64(cfg.MemAvailableMin * mb),
+		Temperature:     Tier{Warning: cfg.TemperatureThreshold},
+
+		RequiredServices: cfg.RequiredServices,
+	}
+}
+
+// Manager evaluates thresholds against collected metrics and tracks active alerts
+// so each violation is reported once until it resolves
+type Manager struct {
+	thresholds Thresholds
+	notify     func(Alert)
+	resolved   func(Alert) // resolutions that are not notified (OnResolve)
+	active     map[string]Alert
+	breaching  map[string]time.Time // first check a not yet fired violation was seen
+	runtimes   map[string]bool      // container runtimes seen responsive at least once
+	samples    map[string][]float64 // recent values per alert key, for trends
+	sampled    map[string]bool      // keys sampled in the current evaluation
+	cycles     int
+	warmUntil  time.Time // violations are not reported before, see Thresholds.WarmUp
+	warming    bool      // warm-up was logged and hasn't ended yet
+	maintUntil time.Time // zero when no maintenance window is active
+	mu         sync.Mutex
+}
+
+// NewManager creates an alert manager; notify is called for every newly fired alert
+func NewManager(thresholds Thresholds, notify func(Alert)) *Manager {
+	return &Manager{
+		thresholds: thresholds,
+		notify:     notify,
+		warmUntil:  time.Now().Add(thresholds.WarmUp),
+		active:     make(map[string]Alert),
+		breaching:  make(map[string]time.Time),
+		runtimes:   make(map[string]bool),
+		samples:    make(map[string][]float64),
+		sampled:    make(map[string]bool),
+	}
+}
+
+// OnResolve sets a function called for every resolved alert that isn't
+// notified (required services notify their recovery instead)
+func (m *Manager) OnResolve(fn func(Alert)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.resolved = fn
+}
+
+// Enabled reports whether any local threshold is configured
+func (m *Manager) Enabled() bool {
+	return m.thresholds.IOPS.Enabled() || m.thresholds.Throughput.Enabled() || m.thresholds.FDPercent.Enabled() ||
+		m.thresholds.ProcessIO.Enabled() || m.thresholds.NetRate.Enabled() || m.thresholds.NetRatePercent.Enabled() ||
+		m.thresholds.MemAvailableMin > 0 || m.thresholds.Temperature.Enabled() || len(m.thresholds.RequiredServices) > 0
+}
+
+// SetMaintenance updates the maintenance window; until in the past ends it.
+// A single notification is sent when entering or leaving maintenance.
+func (m *Manager) SetMaintenance(subject string, until time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	wasActive := !m.maintUntil.IsZero()
+	active := until.After(now)
+
+	var alert *Alert
+	switch {
+	case active && !wasActive:
+		alert = &Alert{
+			Type:      TypeMaintenance,
+			Severity:  SeverityInfo,
+			Subject:   subject,
+			Message:   fmt.Sprintf("Entering maintenance on %s until %s, alerts suppressed", subject, until.Format("15:04 MST")),
+			Timestamp: now,
+		}
+	case !active && wasActive:
+		alert = &Alert{
+			Type:      TypeMaintenance,
+			Severity:  SeverityInfo,
+			Subject:   subject,
+			Message:   fmt.Sprintf("Leaving maintenance on %s, alerts resumed", subject),
+			Timestamp: now,
+		}
+	}
+
+	if active {
+		m.maintUntil = until
+	} else {
+		m.maintUntil = time.Time{}
+	}
+
+	if alert != nil {
+		logger.Info("[ALERT] %s", alert.Message)
+		if m.notify != nil {
+			m.notify(*alert)
+		}
+	}
+}
+
+// InMaintenance reports whether notifications are currently suppressed
+func (m *Manager) InMaintenance() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return !m.maintUntil.IsZero() && time.Now().Before(m.maintUntil)
+}
+
+// Evaluate checks metrics against thresholds and returns newly fired alerts
+func (m *Manager) Evaluate(all *metrics.AllMetrics) []Alert {
+	if all == nil {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.cycles++
+
+	violations := make(map[string]Alert)
+
+	// Disk and network rates are computed from deltas, the first cycle has no baseline
+	if m.cycles > 1 {
+		m.checkDisks(all.Disks, violations)
+		m.checkNetworks(all.Networks, violations)
+		m.checkProcessIO(all.Processes, violations)
+	}
+	m.checkProcesses(all.Processes, violations)
+	m.checkMemory(all.Summary, violations)
+	m.checkThermal(all.Temperatures, all.Throttle, violations)
+	m.checkUnits(all.Units, violations)
+	m.checkServices(all, violations)
+	m.checkRuntimes(all.Runtimes, violations)
+	m.pruneSamples()
+
+	// Checks still run during warm-up to build baselines and trends, but what
+	// they find is dropped so sustained streaks start afterwards
+	if time.Now().Before(m.warmUntil) {
+		if !m.warming {
+			logger.Info("[ALERT] Warming up, alerts suppressed until %s", m.warmUntil.Format("15:04:05"))
+			m.warming = true
+		}
+		for _, alert := range violations {
+			logger.Debug("[ALERT] Suppressed (warm-up): %s", alert.Message)
+		}
+		return nil
+	}
+	if m.warming {
+		logger.Info("[ALERT] Warm-up over, alerts enabled")
+		m.warming = false
+	}
+
+	m.holdUntilSustained(violations)
+
+	return m.apply(violations, TypeIOPS, TypeThroughput, TypeFD, TypeProcessIO, TypeUnit, TypeNetRate, TypeRuntime, TypeService, TypeMemory, TypeTemperature, TypeThrottle)
+}
+
+// checkRuntimes raises a critical alert when a container runtime that answered
+// before stops responding. Installed but never running runtimes are ignored.
+func (m *Manager) checkRuntimes(runtimes []metrics.ContainerRuntimeInfo, violations map[string]Alert) {
+	now := time.Now()
+	for _, r := range runtimes {
+		if r.Responsive {
+			m.runtimes[r.Name] = true
+			continue
+		}
+		if !m.runtimes[r.Name] {
+			continue
+		}
+
+		violations[TypeRuntime+":"+r.Name] = Alert{
+			Type:      TypeRuntime,
+			Severity:  SeverityCritical,
+			Subject:   r.Name,
+			Message:   fmt.Sprintf("%s daemon is not responding: %s", r.Name, r.Error),
+			Timestamp: now,
+		}
+	}
+}
+
+// holdUntilSustained drops new violations that have not persisted for the
+// sustained duration yet, so a single spiky sample doesn't fire an alert.
+// Already active alerts are kept (and may still escalate immediately).
+func (m *Manager) holdUntilSustained(violations map[string]Alert) {
+	for key := range m.breaching {
+		if _, ok := violations[key]; !ok {
+			delete(m.breaching, key) // streak broken, start over next time
+		}
+	}
+	if m.thresholds.Sustained <= 0 {
+		return
+	}
+
+	now := time.Now()
+	for key := range violations {
+		if _, ok := m.active[key]; ok {
+			continue
+		}
+		since, ok := m.breaching[key]
+		if !ok {
+			m.breaching[key] = now
+			since = now
+		}
+		if now.Sub(since) < m.thresholds.Sustained {
+			delete(violations, key)
+		}
+	}
+}
+
+// EvaluateCerts checks certificates against the expiry warning window and returns newly fired alerts
+func (m *Manager) EvaluateCerts(results []certs.Cert, warningDays int) []Alert {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	violations := make(map[string]Alert)
+	now := time.Now()
+
+	for _, c := range results {
+		if c.Err != nil {
+			logger.Warning("[CERTS] Failed to check %s: %v", c.Source, c.Err)
+			continue
+		}
+
+		days := c.DaysLeft()
+		if days > warningDays {
+			continue
+		}
+
+		severity := SeverityWarning
+		message := fmt.Sprintf("Certificate %s (%s) expires in %s on %s",
+			c.Subject, c.Source, c.Remaining(), c.NotAfter.Format("2006-01-02"))
+		if c.Expired() {
+			severity = SeverityCritical
+			message = fmt.Sprintf("Certificate %s (%s) expired on %s", c.Subject, c.Source, c.NotAfter.Format("2006-01-02"))
+		}
+
+		violations[TypeCertExpiry+":"+c.Source] = Alert{
+			Type:      TypeCertExpiry,
+			Severity:  severity,
+			Subject:   c.Source,
+			Value:     float64(days),
+			Threshold: float64(warningDays),
+			Message:   message,
+			Timestamp: now,
+		}
+	}
+
+	return m.apply(violations, TypeCertExpiry)
+}
+
+// EvaluateExport raises a critical alert when no metrics export succeeded
+// within staleAfter of lastSuccess (the daemon start before the first one).
+// Delivered by hook and email, which may still work when OTLP is blocked.
+func (m *Manager) EvaluateExport(lastSuccess time.Time, staleAfter time.Duration) []Alert {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	violations := make(map[string]Alert)
+	if age := time.Since(lastSuccess); age > staleAfter {
+		violations[TypeExportStale] = Alert{
+			Type:      TypeExportStale,
+			Severity:  SeverityCritical,
+			Subject:   "otlp",
+			Value:     age.Seconds(),
+			Threshold: staleAfter.Seconds(),
+			Message: fmt.Sprintf("No metrics exported for %v (since %s), the dashboard is stale",
+				age.Round(time.Second), lastSuccess.Format("15:04:05")),
+			Timestamp: time.Now(),
+		}
+	}
+
+	return m.apply(violations, TypeExportStale)
+}
+
+// apply fires new violations and resolves active alerts of the given types
+// that are no longer violated. Caller must hold m.mu.
+func (m *Manager) apply(violations map[string]Alert, types ...string) []Alert {
+	// During maintenance new violations are not tracked, so anything still
+	// firing when the window ends is reported then
+	if !m.maintUntil.IsZero() && time.Now().Before(m.maintUntil) {
+		for key, alert := range violations {
+			if _, ok := m.active[key]; !ok {
+				logger.Debug("[ALERT] Suppressed (maintenance): %s", alert.Message)
+			}
+		}
+		return nil
+	}
+
+	var fired []Alert
+	for key, alert := range violations {
+		if prev, ok := m.active[key]; ok {
+			if prev.Severity == alert.Severity {
+				continue
+			}
+			// Escalation to critical bypasses deduplication, a drop back to
+			// warning is only recorded so a later escalation is reported again
+			alert.Details = prev.Details // captured once per incident
+			m.active[key] = alert
+			if alert.Severity != SeverityCritical {
+				continue
+			}
+		}
+		if _, ok := m.active[key]; !ok && alert.PID > 0 && m.thresholds.ProcessDetail {
+			alert.Details = metrics.ProcessForensics(alert.PID, m.thresholds.ProcessEnv)
+		}
+		alert.Message += m.trend(key)
+		m.active[key] = alert
+		fired = append(fired, alert)
+	}
+
+	// A required service coming back is notified, other resolutions are only logged
+	var recovered []Alert
+	for key, alert := range m.active {
+		if _, ok := violations[key]; ok || !slices.Contains(types, alert.Type) {
+			continue
+		}
+		delete(m.active, key)
+		logger.Info("[ALERT] Resolved: %s on %s", alert.Type, alert.Subject)
+		if alert.Type == TypeService {
+			recovered = append(recovered, Alert{
+				Type:      TypeService,
+				Severity:  SeverityInfo,
+				Subject:   alert.Subject,
+				Message:   fmt.Sprintf("Required service %s is running again", alert.Subject),
+				Timestamp: time.Now(),
+			})
+		} else if m.resolved != nil {
+			m.resolved(Alert{
+				Type:      alert.Type,
+				Severity:  "resolved",
+				Subject:   alert.Subject,
+				Threshold: alert.Threshold,
+				Message:   fmt.Sprintf("Resolved: %s on %s", alert.Type, alert.Subject),
+				Timestamp: time.Now(),
+			})
+		}
+	}
+
+	for _, alert := range fired {
+		logger.Warning("[ALERT] %s", alert.Message)
+		if cmdline := alert.Details["process_cmdline"]; cmdline != "" {
+			logger.Info("[ALERT] Process %d: %s (cwd %s, user %s)", alert.PID, cmdline, alert.Details["process_cwd"], alert.Details["process_user"])
+		}
+		if m.notify != nil {
+			m.notify(alert)
+		}
+	}
+	for _, alert := range recovered {
+		logger.Info("[ALERT] %s", alert.Message)
+		if m.notify != nil {
+			m.notify(alert)
+		}
+	}
+
+	return fired
+}
+
+// checkDisks evaluates per-device IOPS and throughput thresholds
+func (m *Manager) checkDisks(disks []metrics.DiskMetrics, violations map[string]Alert) {
+	now := time.Now()
+
+	for _, d := range disks {
+		iops := float64(d.IOPSRead) + float64(d.IOPSWrite)
+		m.record(TypeIOPS+":"+d.Device, iops)
+		if severity, threshold, ok := m.thresholds.IOPS.Check(iops); ok {
+			violations[TypeIOPS+":"+d.Device] = Alert{
+				Type:      TypeIOPS,
+				Severity:  severity,
+				Subject:   d.Device,
+				Value:     iops,
+				Threshold: threshold,
+				Message: fmt.Sprintf("Disk %s (%s) IOPS saturated: %.0f ops/s (%s threshold %.0f)",
+					d.Device, d.MountPoint, iops, severity, threshold),
+				Timestamp: now,
+			}
+		}
+
+		throughput := float64(d.ThroughputRead) + float64(d.ThroughputWrite)
+		m.record(TypeThroughput+":"+d.Device, throughput)
+		if severity, threshold, ok := m.thresholds.Throughput.Check(throughput); ok {
+			violations[TypeThroughput+":"+d.Device] = Alert{
+				Type:      TypeThroughput,
+				Severity:  severity,
+				Subject:   d.Device,
+				Value:     throughput,
+				Threshold: threshold,
+				Message: fmt.Sprintf("Disk %s (%s) throughput saturated: %s/s (%s threshold %s/s)",
+					d.Device, d.MountPoint, utils.FormatBytes(int64(throughput)), severity, utils.FormatBytes(int64(threshold))),
+				Timestamp: now,
+			}
+		}
+	}
+}
+
+// checkNetworks evaluates per-interface receive/send rates. The busier direction
+// counts, links are full duplex. A percentage threshold needs a known link speed.
+func (m *Manager) checkNetworks(networks []metrics.NetworkInterfaceMetrics, violations map[string]Alert) {
+	now := time.Now()
+
+	for _, n := range networks {
+		direction, rate := "receive", float64(n.BytesRecvRate)
+		if float64(n.BytesSentRate) > rate {
+			direction, rate = "send", float64(n.BytesSentRate)
+		}
+		m.record(TypeNetRate+":"+n.Interface, rate) // the % of link speed has the same shape
+
+		if n.SpeedMbps > 0 {
+			percent := rate * 8 / (float64(n.SpeedMbps) * 1e6) * 100
+			if severity, threshold, ok := m.thresholds.NetRatePercent.Check(percent); ok {
+				violations[TypeNetRate+":"+n.Interface] = Alert{
+					Type:      TypeNetRate,
+					Severity:  severity,
+					Subject:   n.Interface,
+					Value:     percent,
+					Threshold: threshold,
+					Message: fmt.Sprintf("Interface %s %s rate at %.0f%% of its %d Mbps link: %s/s (%s threshold %.0f%%)",
+						n.Interface, direction, percent, n.SpeedMbps, utils.FormatBytes(int64(rate)), severity, threshold),
+					Timestamp: now,
+				}
+				continue
+			}
+		}
+
+		if severity, threshold, ok := m.thresholds.NetRate.Check(rate); ok {
+			violations[TypeNetRate+":"+n.Interface] = Alert{
+				Type:      TypeNetRate,
+				Severity:  severity,
+				Subject:   n.Interface,
+				Value:     rate,
+				Threshold: threshold,
+				Message: fmt.Sprintf("Interface %s %s rate saturated: %s/s (%s threshold %s/s)",
+					n.Interface, direction, utils.FormatBytes(int64(rate)), severity, utils.FormatBytes(int64(threshold))),
+				Timestamp: now,
+			}
+		}
+	}
+}
+
+// checkProcesses evaluates open file descriptors against each process's nofile limit
+func (m *Manager) checkProcesses(processes []metrics.ProcessInfo, violations map[string]Alert) {
+	if !m.thresholds.FDPercent.Enabled() {
+		return
+	}
+
+	now := time.Now()
+	for _, p := range processes {
+		if p.FDLimit == 0 || p.NumFDs == 0 {
+			continue // not readable without privileges
+		}
+
+		usage := float64(p.NumFDs) / float64(p.FDLimit) * 100
+		m.record(fmt.Sprintf("%s:%d", TypeFD, p.PID), usage)
+		severity, threshold, ok := m.thresholds.FDPercent.Check(usage)
+		if !ok {
+			continue
+		}
+
+		subject := fmt.Sprintf("%s (pid %d)", p.Name, p.PID)
+		violations[fmt.Sprintf("%s:%d", TypeFD, p.PID)] = Alert{
+			Type:      TypeFD,
+			Severity:  severity,
+			Subject:   subject,
+			Value:     usage,
+			Threshold: threshold,
+			Message: fmt.Sprintf("Process %s is running out of file descriptors: %d of %d open (%.0f%%, %s threshold %.0f%%)",
+				subject, p.NumFDs, p.FDLimit, usage, severity, threshold),
+			Timestamp: now,
+			PID:       p.PID,
+		}
+	}
+}
+
+// checkProcessIO evaluates the disk read+write rate of each collected process
+func (m *Manager) checkProcessIO(processes []metrics.ProcessInfo, violations map[string]Alert) {
+	if !m.thresholds.ProcessIO.Enabled() {
+		return
+	}
+
+	now := time.Now()
+	for _, p := range processes {
+		rate := float64(p.IOReadRate) + float64(p.IOWriteRate)
+		m.record(fmt.Sprintf("%s:%d", TypeProcessIO, p.PID), rate)
+		severity, threshold, ok := m.thresholds.ProcessIO.Check(rate)
+		if !ok {
+			continue
+		}
+
+		subject := fmt.Sprintf("%s (pid %d)", p.Name, p.PID)
+		violations[fmt.Sprintf("%s:%d", TypeProcessIO, p.PID)] = Alert{
+			Type:      TypeProcessIO,
+			Severity:  severity,
+			Subject:   subject,
+			Value:     rate,
+			Threshold: threshold,
+			Message: fmt.Sprintf("Process %s disk IO saturated: %s/s read, %s/s write (%s threshold %s/s)",
+				subject, utils.FormatBytes(int64(p.IOReadRate)), utils.FormatBytes(int64(p.IOWriteRate)), severity, utils.FormatBytes(int64(threshold))),
+			Timestamp: now,
+			PID:       p.PID,
+		}
+	}
+}
+
+// checkMemory raises a warning when available memory drops below the absolute
+// floor, for hosts where a percentage threshold leaves too much or too little
+func (m *Manager) checkMemory(s *metrics.SystemSummary, violations map[string]Alert) {
+	if m.thresholds.MemAvailableMin == 0 || s == nil || s.MemoryTotal == 0 {
+		return
+	}
+	m.record(TypeMemory, float64(s.MemoryAvailable))
+	if s.MemoryAvailable >= m.thresholds.MemAvailableMin {
+		return
+	}
+
+	violations[TypeMemory] = Alert{
+		Type:      TypeMemory,
+		Severity:  SeverityWarning,
+		Subject:   "memory",
+		Value:     float64(s.MemoryAvailable),
+		Threshold: float64(m.thresholds.MemAvailableMin),
+		Message: fmt.Sprintf("Available memory below floor: %s available of %s, below mem_available_min %s",
+			utils.FormatBytes(int64(s.MemoryAvailable)), utils.FormatBytes(int64(s.MemoryTotal)), utils.FormatBytes(int64(m.thresholds.MemAvailableMin))),
+		Timestamp: time.Now(),
+	}
+}
+
+// checkThermal evaluates each temperature sensor, critical once it reaches the
+// critical mark the sensor reports, and warns while the CPU is throttled
+func (m *Manager) checkThermal(temps []metrics.TemperatureInfo, throttle *metrics.ThermalThrottleInfo, violations map[string]Alert) {
+	if !m.thresholds.Temperature.Enabled() {
+		return
+	}
+
+	now := time.Now()
+	for _, t := range temps {
+		m.record(TypeTemperature+":"+t.Sensor, t.Celsius)
+		tier := m.thresholds.Temperature
+		if t.Critical > tier.Warning {
+			tier.Critical = t.Critical
+		}
+		severity, threshold, ok := tier.Check(t.Celsius)
+		if !ok {
+			continue
+		}
+		violations[TypeTemperature+":"+t.Sensor] = Alert{
+			Type:      TypeTemperature,
+			Severity:  severity,
+			Subject:   t.Sensor,
+			Value:     t.Celsius,
+			Threshold: threshold,
+			Message: fmt.Sprintf("Sensor %s temperature high: %.1f °C (%s threshold %g °C)",
+				t.Sensor, t.Celsius, severity, threshold),
+			Timestamp: now,
+		}
+	}
+
+	if throttle != nil && throttle.Active {
+		message := "CPU is thermally throttled, performance is reduced to cool it down"
+		if throttle.Events > 0 {
+			message = fmt.Sprintf("CPU thermally throttled %d times since the last check, performance is reduced to cool it down", throttle.Events)
+		}
+		violations[TypeThrottle] = Alert{
+			Type:      TypeThrottle,
+			Severity:  SeverityWarning,
+			Subject:   "cpu",
+			Value:     float64(throttle.Events),
+			Message:   message,
+			Timestamp: now,
+		}
+	}
+}
+
+// checkServices raises a critical alert for every required service that is not
+// detected. When the process list couldn't be read every service would look
+// missing, so the alerts already firing are kept as they are.
+func (m *Manager) checkServices(all *metrics.AllMetrics, violations map[string]Alert) {
+	if len(m.thresholds.RequiredServices) == 0 {
+		return
+	}
+	// Services are nil when listing them failed, keep the alerts as they are
+	// rather than raising or resolving them on missing data
+	if all.Services == nil {
+		for key, alert := range m.active {
+			if alert.Type == TypeService {
+				violations[key] = alert
+			}
+		}
+		return
+	}
+
+	now := time.Now()
+	for _, name := range metrics.MissingServices(all.Services, m.thresholds.RequiredServices) {
+		violations[TypeService+":"+name] = Alert{
+			Type:      TypeService,
+			Severity:  SeverityCritical,
+			Subject:   name,
+			Message:   fmt.Sprintf("Required service %s is not running", name),
+			Timestamp: now,
+		}
+	}
+}
+
+// checkUnits alerts on failed systemd units and on tracked units that are not running
+func (m *Manager) checkUnits(units []metrics.UnitInfo, violations map[string]Alert) {
+	now := time.Now()
+	for _, u := range units {
+		severity := ""
+		switch {
+		case u.ActiveState == "failed":
+			severity = SeverityCritical
+		case u.Tracked && u.ActiveState == "inactive":
+			severity = SeverityWarning
+		default:
+			continue
+		}
+
+		message := fmt.Sprintf("systemd unit %s is %s (%s)", u.Name, u.ActiveState, u.SubState)
+		if u.LoadState == "not-found" {
+			message = fmt.Sprintf("systemd unit %s is not installed", u.Name)
+		}
+		violations[TypeUnit+":"+u.Name] = Alert{
+			Type:      TypeUnit,
+			Severity:  severity,
+			Subject:   u.Name,
+			Message:   message,
+			Timestamp: now,
+		}
+	}
+}
+
+const (
+	trendSamples    = 10 // values kept per alert key for the trend sparkline
+	minTrendSamples = 3  // fewer than this and the trend is omitted
+)
+
+// record remembers a value checked for key, for the trend of a later alert.
+// Caller must hold m.mu.
+func (m *Manager) record(key string, value float64) {
+	if !m.thresholds.Trend {
+		return
+	}
+	values := append(m.samples[key], value)
+	if len(values) > trendSamples {
+		values = values[len(values)-trendSamples:]
+	}
+	m.samples[key] = values
+	m.sampled[key] = true
+}
+
+// pruneSamples forgets devices, interfaces and processes that were not checked
+// in this evaluation (gone, or the check is disabled). Caller must hold m.mu.
+func (m *Manager) pruneSamples() {
+	for key := range m.samples {
+		if !m.sampled[key] {
+			delete(m.samples, key)
+		}
+	}
+	clear(m.sampled)
+}
+
+// trend returns " [trend ▁▃▅▇ rising]" for the recent values of key, or ""
+// when trends are disabled or there is not enough history yet
+func (m *Manager) trend(key string) string {
+	values := m.samples[key]
+	if !m.thresholds.Trend || len(values) < minTrendSamples {
+		return ""
+	}
+
+	// Least-squares slope, projected over the whole window
+	n := float64(len(values))
+	var sumX, sumY, sumXY, sumXX float64
+	for i, v := range values {
+		x := float64(i)
+		sumX += x
+		sumY += v
+		sumXY += x * v
+		sumXX += x * x
+	}
+	slope := (n*sumXY - sumX*sumY) / (n*sumXX - sumX*sumX)
+	change := slope * (n - 1)
+
+	direction := "steady"
+	if mean := sumY / n; mean != 0 && math.Abs(change) >= 0.05*math.Abs(mean) {
+		direction = "rising"
+		if change < 0 {
+			direction = "falling"
+		}
+	}
+
+	return fmt.Sprintf(" [trend %s %s]", ui.RenderSparkline(values, 0), direction)
+}
@@ -0,0 +1,151 @@
+package alerts
+
+import (
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"catops/internal/logger"
+)
+
+// emailTimeout bounds a whole SMTP delivery (connect, TLS, auth and data)
+const emailTimeout = 30 * time.Second
+
+// Email sends every fired alert as a plain-text mail through an SMTP relay.
+// STARTTLS is used when the server offers it; port 465 uses implicit TLS.
+type Email struct {
+	Host     string
+	Port     int
+	User     string // empty = no authentication
+	Pass     string
+	From     string
+	To       []string
+	Hostname string
+}
+
+// Send delivers an alert in the background; failures are logged
+func (e *Email) Send(a Alert) {
+	if e == nil || e.Host == "" || len(e.To) == 0 {
+		return
+	}
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error("PANIC in alert email: %v", r)
+			}
+		}()
+
+		if err := e.send(a); err != nil {
+			logger.Warning("[ALERT] Email to %s failed for %s on %s: %v", strings.Join(e.To, ", "), a.Type, a.Subject, err)
+			return
+		}
+		logger.Info("[ALERT] Email sent to %s for %s on %s", strings.Join(e.To, ", "), a.Type, a.Subject)
+	}()
+}
+
+// send runs one SMTP session
+func (e *Email) send(a Alert) error {
+	addr := net.JoinHostPort(e.Host, strconv.Itoa(e.Port))
+	tlsConfig := &tls.Config{ServerName: e.Host}
+	dialer := &net.Dialer{Timeout: emailTimeout}
+
+	var conn net.Conn
+	var err error
+	if e.Port == 465 {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return err
+	}
+	conn.SetDeadline(time.Now().Add(emailTimeout))
+
+	c, err := smtp.NewClient(conn, e.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("STARTTLS: %w", err)
+		}
+	}
+
+	// PlainAuth refuses to send credentials over an unencrypted remote connection
+	if e.User != "" {
+		if err := c.Auth(smtp.PlainAuth("", e.User, e.Pass, e.Host)); err != nil {
+			return fmt.Errorf("authentication: %w", err)
+		}
+	}
+
+	if err := c.Mail(e.From); err != nil {
+		return err
+	}
+	for _, to := range e.To {
+		if err := c.Rcpt(to); err != nil {
+			return fmt.Errorf("recipient %s: %w", to, err)
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(e.message(a)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// message renders the alert as an RFC 5322 plain-text mail
+func (e *Email) message(a Alert) []byte {
+	subject := fmt.Sprintf("[CatOps] %s %s on %s: %s", strings.ToUpper(a.Severity), a.Type, e.Hostname, a.Subject)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", e.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(e.To, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", a.Timestamp.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+
+	fmt.Fprintf(&b, "%s\r\n\r\n", a.Message)
+	fmt.Fprintf(&b, "Server:    %s\r\n", e.Hostname)
+	fmt.Fprintf(&b, "Type:      %s\r\n", a.Type)
+	fmt.Fprintf(&b, "Severity:  %s\r\n", a.Severity)
+	fmt.Fprintf(&b, "Subject:   %s\r\n", a.Subject)
+	if a.Threshold != 0 {
+		fmt.Fprintf(&b, "Value:     %g\r\n", a.Value)
+		fmt.Fprintf(&b, "Threshold: %g\r\n", a.Threshold)
+	}
+	fmt.Fprintf(&b, "Time:      %s\r\n", a.Timestamp.Format(time.RFC3339))
+
+	if len(a.Details) > 0 {
+		fmt.Fprintf(&b, "\r\nProcess %d:\r\n", a.PID)
+		keys := make([]string, 0, len(a.Details))
+		for k := range a.Details {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			value := strings.ReplaceAll(a.Details[k], "\n", "\r\n    ")
+			fmt.Fprintf(&b, "  %s: %s\r\n", strings.TrimPrefix(k, "process_"), value)
+		}
+	}
+	return []byte(b.String())
+}
@@ -0,0 +1,131 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"catops/internal/logger"
+)
+
+// maxHookOutput caps how much hook output is copied into the log
+const maxHookOutput = 4096
+
+// maxRunningHooks bounds the hooks running at once, an alert storm queues the
+// rest for up to the hook timeout before they are dropped
+const maxRunningHooks = 4
+
+// hookSlots holds a token per running hook
+var hookSlots = make(chan struct{}, maxRunningHooks)
+
+// Hook runs a user executable for every fired alert (alert_hook). The alert is
+// passed as JSON on stdin and as CATOPS_ALERT_* environment variables.
+type Hook struct {
+	Path     string
+	Timeout  time.Duration
+	Hostname string
+}
+
+// hookPayload is the JSON document written to the hook's stdin
+type hookPayload struct {
+	Type      string    `json:"type"`
+	Severity  string    `json:"severity"`
+	Subject   string    `json:"subject"`
+	Value     float64   `json:"value"`
+	Threshold float64   `json:"threshold"`
+	Message   string    `json:"message"`
+	Hostname  string    `json:"hostname"`
+	Timestamp time.Time `json:"timestamp"`
+
+	PID     int               `json:"pid,omitempty"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// Run starts the hook for an alert in the background, at most maxRunningHooks
+// at a time. The hook and its children are killed after Timeout, its combined
+// output is written to the daemon log.
+func (h *Hook) Run(a Alert) {
+	if h == nil || h.Path == "" {
+		return
+	}
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error("PANIC in alert hook: %v", r)
+			}
+		}()
+
+		select {
+		case hookSlots <- struct{}{}:
+			defer func() { <-hookSlots }()
+		case <-time.After(h.Timeout):
+			logger.Warning("[ALERT] Hook %s skipped for %s on %s: %d hooks still running", h.Path, a.Type, a.Subject, maxRunningHooks)
+			return
+		}
+
+		if err := h.run(a); err != nil {
+			logger.Warning("[ALERT] Hook %s failed for %s on %s: %v", h.Path, a.Type, a.Subject, err)
+		}
+	}()
+}
+
+// run executes the hook and waits for it to finish
+func (h *Hook) run(a Alert) error {
+	payload, err := json.Marshal(hookPayload{
+		Type:      a.Type,
+		Severity:  a.Severity,
+		Subject:   a.Subject,
+		Value:     a.Value,
+		Threshold: a.Threshold,
+		Message:   a.Message,
+		Hostname:  h.Hostname,
+		Timestamp: a.Timestamp,
+		PID:       a.PID,
+		Details:   a.Details,
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), h.Timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, h.Path)
+	killProcessGroup(cmd)
+	cmd.WaitDelay = time.Second // don't wait on pipes held open by children of a killed hook
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Env = append(os.Environ(),
+		"CATOPS_ALERT_TYPE="+a.Type,
+		"CATOPS_ALERT_SEVERITY="+a.Severity,
+		"CATOPS_ALERT_SUBJECT="+a.Subject,
+		fmt.Sprintf("CATOPS_ALERT_VALUE=%g", a.Value),
+		fmt.Sprintf("CATOPS_ALERT_THRESHOLD=%g", a.Threshold),
+		"CATOPS_ALERT_MESSAGE="+a.Message,
+		"CATOPS_HOSTNAME="+h.Hostname,
+	)
+
+	start := time.Now()
+	output, err := cmd.CombinedOutput()
+	if out := strings.TrimSpace(string(output)); out != "" {
+		if len(out) > maxHookOutput {
+			out = out[:maxHookOutput] + "... (truncated)"
+		}
+		logger.Info("[ALERT] Hook output for %s on %s:\n%s", a.Type, a.Subject, out)
+	}
+
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("killed after %v", h.Timeout)
+	}
+	if err != nil {
+		return err
+	}
+
+	logger.Info("[ALERT] Hook %s finished for %s on %s in %v", h.Path, a.Type, a.Subject, time.Since(start).Round(time.Millisecond))
+	return nil
+}
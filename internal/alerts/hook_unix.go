@@ -0,0 +1,18 @@
+//go:build !windows
+// +build !windows
+
+package alerts
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// killProcessGroup starts the hook in its own process group and makes the
+// timeout kill the whole group, so children of a shell script don't outlive it
+func killProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
@@ -0,0 +1,9 @@
+//go:build windows
+// +build windows
+
+package alerts
+
+import "os/exec"
+
+// killProcessGroup is a no-op on Windows, where only the hook itself is killed
+func killProcessGroup(cmd *exec.Cmd) {}
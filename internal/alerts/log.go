@@ -0,0 +1,157 @@
+package alerts
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"catops/internal/config"
+	"catops/pkg/utils"
+)
+
+const (
+	logFileName   = "alerts.jsonl"
+	maxLogEntries = 10000 // the file is trimmed to the most recent entries
+)
+
+// LogEntry is one fired, escalated or resolved alert in the local alert log
+type LogEntry struct {
+	Timestamp time.Time `json:"ts"`
+	Type      string    `json:"type"`
+	Severity  string    `json:"severity"` // warning, critical, info (recovery notice) or resolved
+	Subject   string    `json:"subject"`
+	Value     float64   `json:"value,omitempty"`
+	Threshold float64   `json:"threshold,omitempty"`
+	Message   string    `json:"message"`
+	Channels  []string  `json:"channels,omitempty"` // where it was sent: backend, hook, email
+}
+
+// Log appends alerts to ~/.catops/alerts.jsonl, an audit trail that doesn't
+// depend on the backend being reachable
+type Log struct {
+	path    string
+	entries int
+	mu      sync.Mutex
+}
+
+// LogPath returns the location of the alert log
+func LogPath() string {
+	return filepath.Join(config.ConfigDir(), logFileName)
+}
+
+// NewLog opens the alert log, trimming it if it grew past the cap
+func NewLog() *Log {
+	l := &Log{path: LogPath()}
+	l.mu.Lock()
+	l.compactLocked()
+	l.mu.Unlock()
+	return l
+}
+
+// Append records an alert and the channels it was sent to
+func (l *Log) Append(a Alert, channels []string) error {
+	if l == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(LogEntry{
+		Timestamp: a.Timestamp.UTC(),
+		Type:      a.Type,
+		Severity:  a.Severity,
+		Subject:   a.Subject,
+		Value:     a.Value,
+		Threshold: a.Threshold,
+		Message:   a.Message,
+		Channels:  channels,
+	})
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return err
+	}
+	if err := utils.EnsureFreeSpace(l.path, int64(len(data))+1); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(data, '\n'))
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	// Rewrite the file once it grows 10% past the cap
+	l.entries++
+	if l.entries > maxLogEntries+maxLogEntries/10 {
+		l.compactLocked()
+	}
+	return nil
+}
+
+func (l *Log) compactLocked() {
+	entries, err := LoadLog(time.Time{})
+	if err != nil {
+		return
+	}
+	l.entries = len(entries)
+	if len(entries) <= maxLogEntries {
+		return
+	}
+	entries = entries[len(entries)-maxLogEntries:]
+
+	tmpFile := l.path + ".tmp"
+	f, err := os.OpenFile(tmpFile, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return
+	}
+	bw := bufio.NewWriter(f)
+	for _, e := range entries {
+		if data, err := json.Marshal(e); err == nil {
+			bw.Write(append(data, '\n'))
+		}
+	}
+	bw.Flush()
+	f.Close()
+
+	if err := os.Rename(tmpFile, l.path); err == nil {
+		l.entries = len(entries)
+	}
+}
+
+// LoadLog reads alert log entries recorded at or after since, oldest first
+func LoadLog(since time.Time) ([]LogEntry, error) {
+	f, err := os.Open(LogPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []LogEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e LogEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue // skip partially written lines
+		}
+		if e.Timestamp.Before(since) {
+			continue
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, scanner.Err()
+}
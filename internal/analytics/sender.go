@@ -4,7 +4,6 @@
 package analytics
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -17,14 +16,11 @@ import (
 	"catops/pkg/utils"
 )
 
-// Shared HTTP client
-var sharedHTTPClient = &http.Client{
-	Timeout: 5 * time.Second,
-	Transport: &http.Transport{
-		MaxIdleConns:        10,
-		MaxIdleConnsPerHost: 5,
-		IdleConnTimeout:     30 * time.Second,
-	},
+// Shared HTTP transport (keeps connections alive across senders)
+var sharedTransport = &http.Transport{
+	MaxIdleConns:        10,
+	MaxIdleConnsPerHost: 5,
+	IdleConnTimeout:     30 * time.Second,
 }
 
 // Sender handles sending events to the backend
@@ -39,13 +35,13 @@ func NewSender(cfg *config.Config, version string) *Sender {
 	return &Sender{
 		cfg:        cfg,
 		version:    version,
-		httpClient: sharedHTTPClient,
+		httpClient: &http.Client{Timeout: cfg.RequestTimeout(), Transport: sharedTransport},
 	}
 }
 
 // SendEvent sends a service event asynchronously (non-blocking)
 func (s *Sender) SendEvent(eventType string) {
-	if s.cfg.AuthToken == "" || s.cfg.ServerID == "" {
+	if s.cfg.AuthToken == "" || s.cfg.ServerID == "" || !s.cfg.AnalyticsEnabled {
 		return
 	}
 
@@ -62,16 +58,39 @@ func (s *Sender) SendEvent(eventType string) {
 
 // SendEventSync sends a service event synchronously (blocking)
 func (s *Sender) SendEventSync(eventType string) {
-	if s.cfg.AuthToken == "" || s.cfg.ServerID == "" {
+	if s.cfg.AuthToken == "" || s.cfg.ServerID == "" || !s.cfg.AnalyticsEnabled {
 		return
 	}
 
 	s.sendEvent(eventType)
 }
 
+// SendAlert sends a locally detected alert asynchronously (non-blocking)
+func (s *Sender) SendAlert(severity, message string, tags map[string]string) {
+	if s.cfg.AuthToken == "" || s.cfg.ServerID == "" || !s.cfg.AnalyticsEnabled {
+		return
+	}
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error("PANIC in SendAlert: %v", r)
+			}
+		}()
+
+		eventData := s.buildEventData("alert")
+		s.applyEventOverrides(eventData, severity, message, tags)
+		s.post("alert", eventData)
+	}()
+}
+
 // sendEvent sends event to backend
 func (s *Sender) sendEvent(eventType string) {
-	eventData := s.buildEventData(eventType)
+	s.post(eventType, s.buildEventData(eventType))
+}
+
+// post sends an event payload to the backend
+func (s *Sender) post(eventType string, eventData map[string]interface{}) {
 	jsonData, err := json.Marshal(eventData)
 	if err != nil {
 		logger.Error("Failed to marshal event data: %v", err)
@@ -80,13 +99,7 @@ func (s *Sender) sendEvent(eventType string) {
 
 	logger.Info("Sending event: %s", eventType)
 
-	req, err := utils.CreateCLIRequest("POST", constants.EVENTS_URL, bytes.NewBuffer(jsonData), s.version)
-	if err != nil {
-		logger.Error("Failed to create event request: %v", err)
-		return
-	}
-
-	resp, err := s.httpClient.Do(req)
+	resp, err := utils.PostJSON(s.httpClient, constants.EVENTS_URL, jsonData, s.version)
 	if err != nil {
 		logger.Error("Failed to send event: %v", err)
 		return
@@ -100,19 +113,33 @@ func (s *Sender) sendEvent(eventType string) {
 	}
 }
 
+// applyEventOverrides sets severity, message and extra tags on a built event payload
+func (s *Sender) applyEventOverrides(eventData map[string]interface{}, severity, message string, tags map[string]string) {
+	events, ok := eventData["events"].([]map[string]interface{})
+	if !ok || len(events) == 0 {
+		return
+	}
+
+	event := events[0]
+	event["severity"] = severity
+	event["message"] = message
+	if eventTags, ok := event["tags"].(map[string]string); ok {
+		for k, v := range tags {
+			eventTags[k] = v
+		}
+	}
+}
+
 // buildEventData creates event payload
 func (s *Sender) buildEventData(eventType string) map[string]interface{} {
-	hostname, _ := os.Hostname()
-	if hostname == "" {
-		hostname = "unknown"
-	}
+	hostname := s.cfg.DisplayHostname()
 
 	pid := os.Getpid()
 
 	// Map event types
 	backendEventType := eventType
 	switch eventType {
-	case "service_start", "service_stop", "service_restart", "update_installed", "config_change":
+	case "service_start", "service_stop", "service_restart", "update_installed", "config_change", "alert":
 		// Valid types
 	default:
 		backendEventType = "service_start"
@@ -131,12 +158,27 @@ func (s *Sender) buildEventData(eventType string) map[string]interface{} {
 		"service_restart":  "CatOps restarted",
 		"update_installed": "CatOps updated",
 		"config_change":    "CatOps config changed",
+		"alert":            "CatOps alert",
 	}
 	message := messages[eventType]
 	if message == "" {
 		message = fmt.Sprintf("CatOps event: %s", eventType)
 	}
 
+	tags := map[string]string{
+		"hostname":       hostname,
+		"catops_version": s.version,
+	}
+	for k, v := range s.cfg.ServerLabels {
+		tags["label."+k] = v
+	}
+	if s.cfg.Environment != "" {
+		tags["environment"] = s.cfg.Environment
+	}
+	if s.cfg.Region != "" {
+		tags["region"] = s.cfg.Region
+	}
+
 	eventModel := map[string]interface{}{
 		"timestamp":     time.Now().UTC().Format("2006-01-02T15:04:05Z"),
 		"server_id":     s.cfg.ServerID,
@@ -147,10 +189,7 @@ func (s *Sender) buildEventData(eventType string) map[string]interface{} {
 		"message":       message,
 		"severity":      severity,
 		"error_message": nil,
-		"tags": map[string]string{
-			"hostname":       hostname,
-			"catops_version": s.version,
-		},
+		"tags":          tags,
 	}
 
 	return map[string]interface{}{
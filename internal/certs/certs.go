@@ -0,0 +1,132 @@
+// Package certs reads TLS certificates from PEM files and live endpoints
+// so their expiry can be monitored.
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+// dialTimeout bounds each endpoint check
+const dialTimeout = 10 * time.Second
+
+// Cert is the result of checking a single certificate source
+type Cert struct {
+	Source   string    // PEM file path or host:port
+	Subject  string    // subject common name (or full subject if CN is empty)
+	Issuer   string    // issuer common name
+	NotAfter time.Time // expiry
+	Err      error     // set when the source could not be read
+}
+
+// DaysLeft returns whole days until expiry, 0 on the last day (negative once
+// expired, see Expired)
+func (c Cert) DaysLeft() int {
+	return int(time.Until(c.NotAfter).Hours() / 24)
+}
+
+// Expired reports whether the certificate is past its expiry
+func (c Cert) Expired() bool {
+	return time.Until(c.NotAfter) < 0
+}
+
+// Remaining describes the time left until expiry: "N days", or "less than a
+// day" on the last day
+func (c Cert) Remaining() string {
+	if days := c.DaysLeft(); days > 0 {
+		return fmt.Sprintf("%d days", days)
+	}
+	return "less than a day"
+}
+
+// Check reads the leaf certificate of every source. Sources containing a path
+// separator or ending in .pem/.crt are read from disk, everything else is
+// treated as a host:port endpoint speaking TLS from the start (protocols that
+// upgrade with STARTTLS, like PostgreSQL or SMTP on 587, are not supported).
+func Check(sources []string) []Cert {
+	results := make([]Cert, 0, len(sources))
+	for _, source := range sources {
+		source = strings.TrimSpace(source)
+		if source == "" {
+			continue
+		}
+
+		var cert *x509.Certificate
+		var err error
+		if isFile(source) {
+			cert, err = readPEM(source)
+		} else {
+			cert, err = fetchRemote(source)
+		}
+
+		result := Cert{Source: source, Err: err}
+		if err == nil {
+			result.Subject = cert.Subject.CommonName
+			if result.Subject == "" {
+				result.Subject = cert.Subject.String()
+			}
+			result.Issuer = cert.Issuer.CommonName
+			result.NotAfter = cert.NotAfter
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// isFile reports whether source refers to a PEM file rather than an endpoint
+func isFile(source string) bool {
+	if strings.ContainsRune(source, os.PathSeparator) {
+		return true
+	}
+	lower := strings.ToLower(source)
+	return strings.HasSuffix(lower, ".pem") || strings.HasSuffix(lower, ".crt")
+}
+
+// readPEM returns the first certificate in a PEM file
+func readPEM(path string) (*x509.Certificate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("no certificate found in %s", path)
+		}
+		if block.Type == "CERTIFICATE" {
+			return x509.ParseCertificate(block.Bytes)
+		}
+	}
+}
+
+// fetchRemote connects to a TLS endpoint and returns the presented leaf certificate.
+// Verification is skipped on purpose: expired or self-signed certs must still be reported.
+func fetchRemote(endpoint string) (*x509.Certificate, error) {
+	host, _, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint %s (expected host:port)", endpoint)
+	}
+
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: dialTimeout}, "tcp", endpoint, &tls.Config{
+		ServerName:         host,
+		InsecureSkipVerify: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	peers := conn.ConnectionState().PeerCertificates
+	if len(peers) == 0 {
+		return nil, fmt.Errorf("%s presented no certificate", endpoint)
+	}
+	return peers[0], nil
+}
@@ -0,0 +1,116 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"catops/internal/alerts"
+	"catops/internal/ui"
+)
+
+// NewAlertsCmd creates the alerts command
+func NewAlertsCmd() *cobra.Command {
+	var last, alertType, severity string
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "alerts",
+		Short: "Show alerts fired by the daemon",
+		Long: `List alerts recorded locally by the monitoring daemon, newest last.
+Every fired, escalated and resolved alert is appended to ~/.catops/alerts.jsonl
+with its value, threshold, severity and the channels it was sent to (backend,
+hook, email), so there is an audit trail even without the backend.
+
+Examples:
+  catops alerts                       # Alerts of the last 24 hours
+  catops alerts --last 7d             # Last week
+  catops alerts --type iops           # Only disk IOPS alerts
+  catops alerts --severity critical   # Only critical alerts`,
+		Run: func(cmd *cobra.Command, args []string) {
+			ui.PrintHeader()
+			ui.PrintSection(fmt.Sprintf("Alerts (last %s)", last))
+
+			window, err := parseAlertWindow(last)
+			if err != nil {
+				ui.PrintStatus("error", fmt.Sprintf("Invalid duration: %s (use e.g. 30m, 24h, 7d)", last))
+				ui.PrintSectionEnd()
+				os.Exit(1)
+			}
+
+			entries, err := alerts.LoadLog(time.Now().Add(-window))
+			if err != nil {
+				ui.PrintStatus("error", fmt.Sprintf("Failed to read %s: %v", alerts.LogPath(), err))
+				ui.PrintSectionEnd()
+				os.Exit(1)
+			}
+
+			var shown []alerts.LogEntry
+			for _, e := range entries {
+				if alertType != "" && !strings.EqualFold(e.Type, alertType) {
+					continue
+				}
+				if severity != "" && !strings.EqualFold(e.Severity, severity) {
+					continue
+				}
+				shown = append(shown, e)
+			}
+			if len(shown) == 0 {
+				ui.PrintStatus("success", "No alerts recorded for this period")
+				ui.PrintSectionEnd()
+				return
+			}
+			if limit > 0 && len(shown) > limit {
+				ui.PrintStatus("info", fmt.Sprintf("Showing the latest %d of %d alerts (--limit)", limit, len(shown)))
+				shown = shown[len(shown)-limit:]
+			}
+
+			for _, e := range shown {
+				status := "info"
+				switch e.Severity {
+				case alerts.SeverityCritical:
+					status = "error"
+				case alerts.SeverityWarning:
+					status = "warning"
+				case "resolved":
+					status = "success"
+				}
+
+				line := fmt.Sprintf("%s  %s", e.Timestamp.Local().Format("Jan 02 15:04:05"), e.Message)
+				if len(e.Channels) > 0 {
+					line += fmt.Sprintf(" (sent to %s)", strings.Join(e.Channels, ", "))
+				}
+				ui.PrintStatus(status, line)
+			}
+			ui.PrintSectionEnd()
+		},
+	}
+
+	cmd.Flags().StringVar(&last, "last", "24h", "Time window to show (e.g. 30m, 24h, 7d)")
+	cmd.Flags().StringVar(&alertType, "type", "", "Only alerts of this type (iops, throughput, fd, process_io, ...)")
+	cmd.Flags().StringVar(&severity, "severity", "", "Only alerts of this severity (warning, critical, info, resolved)")
+	cmd.Flags().IntVar(&limit, "limit", 100, "Show at most this many alerts, newest kept (0 = all)")
+
+	return cmd
+}
+
+// parseAlertWindow parses a Go duration, plus whole days as "7d"
+func parseAlertWindow(s string) (time.Duration, error) {
+	var d time.Duration
+	var err error
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		var n int
+		if _, err = fmt.Sscanf(days, "%d", &n); err == nil {
+			d = time.Duration(n) * 24 * time.Hour
+		}
+	} else {
+		d, err = time.ParseDuration(s)
+	}
+	if err == nil && d <= 0 {
+		err = fmt.Errorf("duration must be positive")
+	}
+	return d, err
+}
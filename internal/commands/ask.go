@@ -14,8 +14,10 @@ import (
 	constants "catops/config"
 	"catops/internal/config"
 	"catops/internal/encoding"
+	"catops/internal/logger"
 	"catops/internal/metrics"
 	"catops/internal/ui"
+	"catops/pkg/utils"
 )
 
 // NewAskCmd creates the AI assistant command
@@ -131,13 +133,14 @@ func runAsk(question string) {
 	}
 
 	req.Header.Set("Content-Type", "application/cbor")
-	req.Header.Set("User-Agent", constants.HEADER_USER_AGENT)
+	utils.ApplyRequestHeaders(req)
 
 	// Show loading indicator
 	fmt.Print("  ")
 	ui.PrintStatus("info", "Thinking...")
 
-	client := &http.Client{Timeout: 30 * time.Second}
+	// AI answers take longer than regular API calls, but respect a higher http_timeout
+	client := &http.Client{Timeout: max(30*time.Second, utils.HTTPTimeout())}
 	resp, err := client.Do(req)
 	if err != nil {
 		fmt.Println()
@@ -210,11 +213,11 @@ func showOfflineHelp(question string) {
 		fmt.Println("  • df -h                - Show disk space details")
 	} else if strings.Contains(questionLower, "alert") {
 		fmt.Println("  • catops status        - Check daemon status")
-		fmt.Println("  • cat /tmp/catops.log  - View daemon logs")
+		fmt.Printf("  • cat %s  - View daemon logs\n", logger.Path())
 	} else {
 		fmt.Println("  • catops status        - Check system overview")
 		fmt.Println("  • catops processes     - View running processes")
-		fmt.Println("  • cat /tmp/catops.log  - Check daemon logs")
+		fmt.Printf("  • cat %s  - Check daemon logs\n", logger.Path())
 	}
 
 	fmt.Println()
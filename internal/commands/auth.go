@@ -8,6 +8,7 @@ import (
 	"catops/internal/config"
 	"catops/internal/server"
 	"catops/internal/ui"
+	"catops/pkg/utils"
 )
 
 // NewAuthCmd creates the auth command with all subcommands
@@ -20,7 +21,8 @@ func NewAuthCmd() *cobra.Command {
 Commands:
   login    Login with authentication token
   logout   Logout and clear authentication
-  status   Show authentication status`,
+  info     Show authentication status
+  token    Show current authentication token`,
 	}
 
 	// Add subcommands
@@ -52,7 +54,7 @@ Examples:
 			// Load current config
 			cfg, err := config.LoadConfig()
 			if err != nil {
-				ui.PrintStatus("error", "Failed to load config")
+				printConfigError("Failed to load config", err)
 				return
 			}
 
@@ -85,7 +87,7 @@ Examples:
 			// update auth_token (server_id is already saved in registerServer)
 			cfg.AuthToken = newToken
 			if err := config.SaveConfig(cfg); err != nil {
-				ui.PrintStatus("error", "Failed to save authentication token")
+				printConfigError("Failed to save authentication token", err)
 				return
 			}
 
@@ -108,7 +110,7 @@ func newLogoutCmd() *cobra.Command {
 			// load current config
 			cfg, err := config.LoadConfig()
 			if err != nil {
-				ui.PrintStatus("error", "Failed to load config")
+				printConfigError("Failed to load config", err)
 				return
 			}
 
@@ -117,7 +119,7 @@ func newLogoutCmd() *cobra.Command {
 
 			// save config
 			if err := config.SaveConfig(cfg); err != nil {
-				ui.PrintStatus("error", "Failed to clear authentication token")
+				printConfigError("Failed to clear authentication token", err)
 				return
 			}
 
@@ -141,7 +143,7 @@ func newStatusAuthCmd() *cobra.Command {
 			// load current config
 			cfg, err := config.LoadConfig()
 			if err != nil {
-				ui.PrintStatus("error", "Failed to load config")
+				printConfigError("Failed to load config", err)
 				return
 			}
 
@@ -149,11 +151,7 @@ func newStatusAuthCmd() *cobra.Command {
 				ui.PrintStatus("success", "Authenticated")
 
 				// Show shortened token instead of full JWT
-				token := cfg.AuthToken
-				if len(token) > 30 {
-					token = token[:15] + "..." + token[len(token)-15:]
-				}
-				ui.PrintStatus("info", "Token: "+token)
+				ui.PrintStatus("info", "Token: "+utils.MaskSecret(cfg.AuthToken, 15))
 
 				ui.PrintStatus("info", "Server registered: "+func() string {
 					if cfg.ServerID != "" {
@@ -186,7 +184,7 @@ This command shows the full token that is currently stored in the configuration.
 			// load current config
 			cfg, err := config.LoadConfig()
 			if err != nil {
-				ui.PrintStatus("error", "Failed to load config")
+				printConfigError("Failed to load config", err)
 				return
 			}
 
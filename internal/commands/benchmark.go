@@ -0,0 +1,171 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/process"
+	"github.com/spf13/cobra"
+
+	"catops/internal/metrics"
+	"catops/internal/ui"
+	"catops/pkg/utils"
+)
+
+// NewBenchmarkCmd creates the (hidden) benchmark command
+func NewBenchmarkCmd() *cobra.Command {
+	var iterations int
+	var pause time.Duration
+
+	cmd := &cobra.Command{
+		Use:    "benchmark",
+		Short:  "Measure the overhead of a metrics collection",
+		Hidden: true,
+		Long: `Run a full metrics collection several times and report how long it takes,
+how much it allocates, which collectors dominate, and the CPU and memory used
+by catops itself during the run. Useful for capacity planning before a
+fleet-wide rollout.
+
+The first collection has no baseline for rates and process CPU, so it is run
+once as a warm-up and not counted.
+
+Examples:
+  catops benchmark             # 10 measured collections
+  catops benchmark -n 50       # 50 collections
+  catops benchmark --pause 1s  # Wait between collections, closer to the daemon`,
+		Run: func(cmd *cobra.Command, args []string) {
+			ui.PrintHeader()
+			ui.PrintSection("Collection Benchmark")
+
+			if iterations < 1 || iterations > 1000 {
+				ui.PrintStatus("error", "Iterations must be between 1 and 1000")
+				ui.PrintSectionEnd()
+				return
+			}
+
+			self, err := process.NewProcess(int32(os.Getpid()))
+			if err != nil {
+				ui.PrintStatus("error", fmt.Sprintf("Failed to inspect own process: %v", err))
+				ui.PrintSectionEnd()
+				return
+			}
+
+			ui.PrintStatus("info", fmt.Sprintf("Running %d collections (plus one warm-up)...", iterations))
+			if _, err := metrics.CollectAllMetrics(); err != nil {
+				ui.PrintStatus("warning", fmt.Sprintf("Warm-up collection reported: %v", err))
+			}
+
+			var before, after runtime.MemStats
+			runtime.ReadMemStats(&before)
+			cpuBefore, _ := self.Times()
+			start := time.Now()
+
+			durations := make([]time.Duration, 0, iterations)
+			collectors := make(map[string][]time.Duration)
+			failures := 0
+			var busy time.Duration
+
+			for i := 0; i < iterations; i++ {
+				if i > 0 && pause > 0 {
+					time.Sleep(pause)
+				}
+
+				t := time.Now()
+				_, timings, err := metrics.CollectAllMetricsWithTimings()
+				d := time.Since(t)
+
+				busy += d
+				durations = append(durations, d)
+				if err != nil {
+					failures++
+				}
+				for _, ct := range timings {
+					collectors[ct.Name] = append(collectors[ct.Name], ct.Duration)
+				}
+			}
+
+			wall := time.Since(start)
+			cpuAfter, _ := self.Times()
+			runtime.ReadMemStats(&after)
+
+			avg, p95, worst := durationStats(durations)
+			fmt.Print(ui.CreateBeautifulList(map[string]string{
+				"Collections": fmt.Sprintf("%d (%d with errors)", iterations, failures),
+				"Average":     avg.Round(time.Microsecond).String(),
+				"P95":         p95.Round(time.Microsecond).String(),
+				"Max":         worst.Round(time.Microsecond).String(),
+				"Allocations": fmt.Sprintf("%d objects, %s per collection",
+					(after.Mallocs-before.Mallocs)/uint64(iterations),
+					utils.FormatBytes(int64((after.TotalAlloc-before.TotalAlloc)/uint64(iterations)))),
+			}))
+			ui.PrintSectionEnd()
+
+			ui.PrintSection("Collectors (average, slowest first)")
+			type collectorStat struct {
+				name     string
+				avg, p95 time.Duration
+			}
+			var stats []collectorStat
+			for name, ds := range collectors {
+				a, p, _ := durationStats(ds)
+				stats = append(stats, collectorStat{name, a, p})
+			}
+			sort.Slice(stats, func(i, j int) bool { return stats[i].avg > stats[j].avg })
+			for _, s := range stats {
+				share := 0.0
+				if avg > 0 {
+					share = float64(s.avg) / float64(avg) * 100
+				}
+				ui.PrintStatus("info", fmt.Sprintf("%-12s avg %8s  p95 %8s  (%.0f%% of a collection)",
+					s.name, s.avg.Round(time.Microsecond), s.p95.Round(time.Microsecond), share))
+			}
+			ui.PrintStatus("info", "Collectors run in parallel, so shares add up to more than 100%")
+			ui.PrintSectionEnd()
+
+			ui.PrintSection("CatOps Process")
+			selfStats := map[string]string{
+				"Heap In Use": utils.FormatBytes(int64(after.HeapInuse)),
+				"GC Cycles":   fmt.Sprintf("%d", after.NumGC-before.NumGC),
+				"Goroutines":  fmt.Sprintf("%d", runtime.NumGoroutine()),
+			}
+			if cpuBefore != nil && cpuAfter != nil {
+				cpuTime := time.Duration((cpuAfter.User + cpuAfter.System - cpuBefore.User - cpuBefore.System) * float64(time.Second))
+				selfStats["CPU Time"] = fmt.Sprintf("%s total, %s per collection",
+					cpuTime.Round(time.Millisecond), (cpuTime / time.Duration(iterations)).Round(time.Microsecond))
+				selfStats["CPU While Busy"] = fmt.Sprintf("%.1f%% of one core", cpuTime.Seconds()/busy.Seconds()*100)
+				selfStats["CPU Over Run"] = fmt.Sprintf("%.1f%% of one core", cpuTime.Seconds()/wall.Seconds()*100)
+			}
+			if mem, err := self.MemoryInfo(); err == nil && mem != nil {
+				selfStats["Memory RSS"] = utils.FormatBytes(int64(mem.RSS))
+			}
+			fmt.Print(ui.CreateBeautifulList(selfStats))
+			ui.PrintSectionEnd()
+		},
+	}
+
+	cmd.Flags().IntVarP(&iterations, "iterations", "n", 10, "Number of measured collections")
+	cmd.Flags().DurationVar(&pause, "pause", 0, "Wait between collections (e.g. 1s)")
+
+	return cmd
+}
+
+// durationStats returns the average, 95th percentile and maximum of durations
+func durationStats(durations []time.Duration) (avg, p95, worst time.Duration) {
+	if len(durations) == 0 {
+		return 0, 0, 0
+	}
+
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+
+	idx := (len(sorted)*95+99)/100 - 1 // nearest-rank
+	return total / time.Duration(len(sorted)), sorted[idx], sorted[len(sorted)-1]
+}
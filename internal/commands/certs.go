@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"catops/internal/certs"
+	"catops/internal/config"
+	"catops/internal/ui"
+)
+
+// NewCertsCmd creates the certs command
+func NewCertsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "certs",
+		Short: "List monitored TLS certificates and days until expiry",
+		Long: `Check the TLS certificates listed in cert_paths (PEM files and/or host:port
+endpoints) and show how many days each has left. The daemon checks them hourly
+and alerts when one is within cert_expiry_warning_days of expiry.
+
+Configure in ~/.catops/config.yaml:
+  cert_paths: ["/etc/nginx/ssl/site.crt", "ldap.internal:636"]
+
+Endpoints must speak TLS from the first byte (HTTPS, LDAPS, IMAPS...);
+protocols that upgrade with STARTTLS, such as PostgreSQL, are not supported.
+  cert_expiry_warning_days: 14
+
+Examples:
+  catops certs             # Show all monitored certificates`,
+		Run: func(cmd *cobra.Command, args []string) {
+			ui.PrintHeader()
+			ui.PrintSection("TLS Certificates")
+
+			cfg, err := config.LoadConfig()
+			if err != nil {
+				printConfigError("Failed to load configuration", err)
+				cfg = config.DefaultConfig()
+			}
+
+			if len(cfg.CertPaths) == 0 {
+				ui.PrintStatus("info", "No certificates configured")
+				ui.PrintStatus("info", "Add cert_paths to ~/.catops/config.yaml to monitor certificate expiry")
+				ui.PrintSectionEnd()
+				return
+			}
+
+			for _, c := range certs.Check(cfg.CertPaths) {
+				if c.Err != nil {
+					ui.PrintStatus("error", fmt.Sprintf("%s: %v", c.Source, c.Err))
+					continue
+				}
+
+				status := "success"
+				if c.Expired() {
+					status = "error"
+				} else if c.DaysLeft() <= cfg.CertExpiryWarningDays {
+					status = "warning"
+				}
+				left := c.Remaining() + " left"
+				if c.Expired() {
+					left = "expired"
+				}
+				ui.PrintStatus(status, fmt.Sprintf("%s: %s, %s (expires %s, issuer %s)",
+					c.Source, c.Subject, left, c.NotAfter.Format("2006-01-02"), c.Issuer))
+			}
+
+			ui.PrintStatus("info", fmt.Sprintf("Alert window: %d days", cfg.CertExpiryWarningDays))
+			ui.PrintSectionEnd()
+		},
+	}
+}
@@ -2,23 +2,37 @@ package commands
 
 import (
 	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"catops/internal/alerts"
 	"catops/internal/config"
+	"catops/internal/metrics"
 	"catops/internal/ui"
+	"catops/pkg/utils"
 )
 
 // NewConfigCmd creates the config command
 func NewConfigCmd() *cobra.Command {
-	return &cobra.Command{
+	var showSecrets bool
+
+	cmd := &cobra.Command{
 		Use:   "config",
 		Short: "Show current configuration",
 		Long: `Show current CatOps configuration including cloud mode status.
 
 Use 'catops config show' to see current settings.
+Use 'catops config dump' to print every resolved setting, including defaults.
+Use 'catops config export' / 'catops config import' to copy settings across servers.
+Use 'catops config wizard' for guided first-time setup.
 Use 'catops set' to change monitoring settings.
-Use 'catops auth' to manage cloud mode authentication.`,
+Use 'catops auth' to manage cloud mode authentication.
+Tokens are masked unless --show-secrets is given.`,
 		Run: func(cmd *cobra.Command, args []string) {
 			ui.PrintHeader()
 			ui.PrintSection("Configuration")
@@ -26,17 +40,31 @@ Use 'catops auth' to manage cloud mode authentication.`,
 			// Load configuration
 			cfg, err := config.LoadConfig()
 			if err != nil {
-				ui.PrintStatus("error", "Failed to load configuration")
+				printConfigError("Failed to load configuration", err)
 				ui.PrintStatus("info", "Using default values")
-				cfg = &config.Config{}
+				cfg = config.DefaultConfig()
 			}
 
 			// Show current configuration
 			ui.PrintSection("Cloud Mode Status")
+			for _, err := range cfg.SecretErrors() {
+				ui.PrintStatus("error", fmt.Sprintf("Secret not readable, %v", err))
+			}
 			if cfg.AuthToken != "" {
-				ui.PrintStatus("success", fmt.Sprintf("Auth Token: %s...%s", cfg.AuthToken[:10], cfg.AuthToken[len(cfg.AuthToken)-10:]))
+				token := utils.MaskSecret(cfg.AuthToken, 10)
+				if showSecrets {
+					token = cfg.AuthToken
+				}
+				if source := cfg.SecretSource("auth_token"); source != "" {
+					token += " (from " + source + ")"
+				}
+				ui.PrintStatus("success", fmt.Sprintf("Auth Token: %s", token))
 				ui.PrintStatus("success", "Cloud Mode: Enabled")
 				ui.PrintStatus("info", "Metrics sent to backend with notifications")
+			} else if cfg.SecretDeferred("auth_token") {
+				ui.PrintStatus("success", fmt.Sprintf("Auth Token: from %s, read by the catops service", cfg.SecretSource("auth_token")))
+				ui.PrintStatus("success", "Cloud Mode: Enabled")
+				ui.PrintStatus("info", "The CLI can read it as root while the service is running")
 			} else {
 				ui.PrintStatus("warning", "Cloud Mode: Disabled")
 				ui.PrintStatus("info", "Running in local mode (no notifications)")
@@ -44,10 +72,338 @@ Use 'catops auth' to manage cloud mode authentication.`,
 			}
 			ui.PrintSectionEnd()
 
+			ui.PrintSection("Server Identity")
+			ui.PrintStatus("info", fmt.Sprintf("Display Name: %s", cfg.DisplayHostname()))
+			if cfg.Environment != "" {
+				ui.PrintStatus("info", fmt.Sprintf("Environment: %s", cfg.Environment))
+			}
+			if cfg.Region != "" {
+				ui.PrintStatus("info", fmt.Sprintf("Region: %s", cfg.Region))
+			}
+			if len(cfg.ServerLabels) > 0 {
+				labels := make([]string, 0, len(cfg.ServerLabels))
+				for k, v := range cfg.ServerLabels {
+					labels = append(labels, k+"="+v)
+				}
+				sort.Strings(labels)
+				ui.PrintStatus("info", fmt.Sprintf("Labels: %s", strings.Join(labels, ", ")))
+			} else {
+				ui.PrintStatus("info", "Labels: none")
+			}
+			ui.PrintStatus("info", "Use 'catops set display_name=web-01 labels=env=prod' to adjust")
+			ui.PrintSectionEnd()
+
 			ui.PrintSection("Monitoring Configuration")
 			ui.PrintStatus("info", fmt.Sprintf("Collection Interval: %d seconds", cfg.CollectionInterval))
+			ui.PrintStatus("info", fmt.Sprintf("OTLP Export Interval: %v", cfg.ExportPeriod()))
+			ui.PrintStatus("info", fmt.Sprintf("OTLP Endpoints: %s", strings.Join(cfg.OTLPEndpointList(), ", ")))
+			for _, endpoint := range cfg.OTLPEndpointList() {
+				if _, err := metrics.ParseOTLPEndpoint(endpoint); err != nil {
+					ui.PrintStatus("error", err.Error())
+				}
+			}
+			if len(cfg.EnabledMetricGroups) > 0 {
+				ui.PrintStatus("info", fmt.Sprintf("OTLP Metric Groups: %s", strings.Join(cfg.EnabledMetricGroups, ", ")))
+			} else {
+				ui.PrintStatus("info", "OTLP Metric Groups: all")
+			}
+			ui.PrintStatus("info", fmt.Sprintf("Alert Check Interval: %v", cfg.AlertPeriod()))
+			ui.PrintStatus("info", fmt.Sprintf("Timeouts: backend requests %v, OTLP exports %v", cfg.RequestTimeout(), cfg.ExportTimeout()))
+			if cfg.UserAgent != "" {
+				ui.PrintStatus("info", fmt.Sprintf("User-Agent: %s", cfg.UserAgent))
+			}
+			if len(cfg.HTTPHeaders) > 0 {
+				headers := make([]string, 0, len(cfg.HTTPHeaders))
+				for k, v := range cfg.HTTPHeaders {
+					headers = append(headers, http.CanonicalHeaderKey(k)+"="+utils.MaskSecret(v, 4))
+				}
+				sort.Strings(headers)
+				ui.PrintStatus("info", fmt.Sprintf("Extra request headers: %s", strings.Join(headers, ", ")))
+				hosts := "the CatOps backend"
+				if len(cfg.HTTPHeadersHosts) > 0 {
+					hosts = strings.Join(cfg.HTTPHeadersHosts, ", ")
+				}
+				if cfg.HTTPHeadersInsecure {
+					hosts += " (also over plain http)"
+				}
+				ui.PrintStatus("info", fmt.Sprintf("Extra headers sent to: %s", hosts))
+			}
 			ui.PrintStatus("info", "Use 'catops set interval=30' to adjust")
 			ui.PrintSectionEnd()
+
+			ui.PrintSection("Local Alerts")
+			printTier := func(name string, warning, critical float64, unit string) {
+				if warning <= 0 && critical <= 0 {
+					ui.PrintStatus("info", fmt.Sprintf("%s: disabled", name))
+					return
+				}
+				tiers := []string{}
+				if warning > 0 {
+					tiers = append(tiers, fmt.Sprintf("warning %g%s", warning, unit))
+				}
+				if critical > 0 {
+					tiers = append(tiers, fmt.Sprintf("critical %g%s", critical, unit))
+				}
+				ui.PrintStatus("info", fmt.Sprintf("%s: %s", name, strings.Join(tiers, ", ")))
+			}
+			printTier("Disk IOPS", float64(cfg.IOPSThreshold), float64(cfg.IOPSCritical), " ops/s per device")
+			printTier("Disk Throughput", cfg.ThroughputThreshold, cfg.ThroughputCritical, " MB/s per device")
+			fd := alerts.ThresholdsFromConfig(cfg).FDPercent // includes the default critical tier
+			printTier("Process File Descriptors", fd.Warning, fd.Critical, "% of limit")
+			printTier("Process Disk IO", cfg.ProcessIOThreshold, 0, " MB/s per process")
+			if cfg.MemAvailableMin > 0 {
+				ui.PrintStatus("info", fmt.Sprintf("Available Memory: warning below %g MB", cfg.MemAvailableMin))
+			}
+			printTier("Network Rate", cfg.NetRateThreshold, 0, " MB/s per interface")
+			if cfg.NetRatePercent > 0 {
+				ui.PrintStatus("info", fmt.Sprintf("Network Rate: %g%% of link speed (interfaces reporting a speed)", cfg.NetRatePercent))
+			}
+			if cfg.SustainedDuration > 0 {
+				ui.PrintStatus("info", fmt.Sprintf("Sustained Duration: %v before alerting", time.Duration(cfg.SustainedDuration)*time.Second))
+			}
+			if cfg.AlertTrend {
+				ui.PrintStatus("info", "Trend: sparkline of recent values added to alerts")
+			}
+			if cfg.CaptureProcessDetailOnAlert {
+				detail := "command line, cwd and owner"
+				if cfg.CaptureProcessEnv {
+					detail += ", environment"
+				}
+				ui.PrintStatus("info", fmt.Sprintf("Process Detail: %s captured on process alerts", detail))
+			}
+			if cfg.AlertHook != "" {
+				ui.PrintStatus("info", fmt.Sprintf("Alert Hook: %s (timeout %v)", cfg.AlertHook, cfg.AlertHookPeriod()))
+			}
+			if cfg.SMTPHost != "" && len(cfg.AlertEmailTo) > 0 {
+				ui.PrintStatus("info", fmt.Sprintf("Email: %s via %s:%d", strings.Join(cfg.AlertEmailTo, ", "), cfg.SMTPHost, cfg.SMTPPort))
+				if source := cfg.SecretSource("smtp_pass"); source != "" {
+					ui.PrintStatus("info", fmt.Sprintf("SMTP Password: from %s", source))
+				}
+			}
+			ui.PrintStatus("info", "Use 'catops set iops.warn=5000 iops.crit=8000 fd=80' to adjust")
+			ui.PrintSectionEnd()
+
+			ui.PrintSection("Features")
+			printToggle := func(name string, enabled bool) {
+				if enabled {
+					ui.PrintStatus("success", fmt.Sprintf("%s: Enabled", name))
+				} else {
+					ui.PrintStatus("warning", fmt.Sprintf("%s: Disabled", name))
+				}
+			}
+			printToggle("Analytics", cfg.AnalyticsEnabled)
+			printToggle("OTLP Metrics Export", cfg.OTLPEnabled)
+			ui.PrintStatus("info", "Use 'catops set analytics=off' (or otlp) to adjust")
+			ui.PrintSectionEnd()
+		},
+	}
+
+	cmd.Flags().BoolVar(&showSecrets, "show-secrets", false, "Show full tokens instead of masking them")
+
+	cmd.AddCommand(newConfigDumpCmd())
+	cmd.AddCommand(newConfigExportCmd())
+	cmd.AddCommand(newConfigImportCmd())
+	cmd.AddCommand(newConfigWizardCmd())
+
+	return cmd
+}
+
+// newConfigDumpCmd creates the config dump subcommand
+func newConfigDumpCmd() *cobra.Command {
+	var showSecrets bool
+
+	cmd := &cobra.Command{
+		Use:   "dump",
+		Short: "Print the effective configuration as YAML",
+		Long: `Print the fully resolved configuration as YAML: values from
+~/.catops/config.yaml merged with defaults, including settings that are not in
+the file. Tokens are masked unless --show-secrets is given.
+
+Examples:
+  catops config dump                  # Effective configuration
+  catops config dump > effective.yaml # Save it for comparison`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, err := config.LoadConfig()
+			if err != nil {
+				printConfigError("Failed to load configuration", err)
+				return
+			}
+
+			fmt.Print(cfg.Dump(showSecrets))
 		},
 	}
+
+	cmd.Flags().BoolVar(&showSecrets, "show-secrets", false, "Show full tokens instead of masking them")
+
+	return cmd
+}
+
+// newConfigExportCmd creates the config export subcommand
+func newConfigExportCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "export",
+		Short: "Print the portable configuration as a bundle",
+		Long: `Print the configuration as a bundle that can be applied to other servers
+with 'catops config import'. Thresholds, intervals, endpoints, filters and
+alert channels are included; credentials (auth token, SMTP password), the
+server ID, display name and maintenance window are per host and left out.
+
+Examples:
+  catops config export > catops.conf`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, err := config.LoadConfig()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+				if hint := config.ErrorHint(err); hint != "" {
+					fmt.Fprintln(os.Stderr, hint)
+				}
+				os.Exit(1)
+			}
+
+			fmt.Print(cfg.ExportBundle())
+		},
+	}
+}
+
+// newConfigImportCmd creates the config import subcommand
+func newConfigImportCmd() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Apply a configuration bundle",
+		Long: `Apply a bundle written by 'catops config export'. The bundle replaces
+every portable setting (settings it doesn't list fall back to defaults); the
+auth token, server ID, display name, SMTP password and maintenance window of
+this server are kept. The bundle is validated before anything is saved.
+
+Examples:
+  catops config import catops.conf          # Asks before overwriting
+  catops config import catops.conf --force  # For provisioning scripts`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ui.PrintHeader()
+			ui.PrintSection("Import Configuration")
+
+			current, err := config.LoadConfig()
+			if err != nil {
+				printConfigError("Failed to load configuration", err)
+				ui.PrintSectionEnd()
+				os.Exit(1)
+			}
+
+			imported, ignored, err := config.ImportBundle(args[0], current)
+			if err != nil {
+				printConfigError("Bundle not applied", err)
+				ui.PrintSectionEnd()
+				os.Exit(1)
+			}
+			for _, key := range ignored {
+				ui.PrintStatus("warning", fmt.Sprintf("Ignoring %s from the bundle (kept per host)", key))
+			}
+
+			if !checkConfig(imported) {
+				ui.PrintStatus("error", "Bundle not applied, fix the settings above")
+				ui.PrintSectionEnd()
+				os.Exit(1)
+			}
+
+			changes := configChanges(current, imported)
+			if len(changes) == 0 {
+				ui.PrintStatus("success", "Configuration already matches the bundle")
+				ui.PrintSectionEnd()
+				return
+			}
+			for _, change := range changes {
+				ui.PrintStatus("info", change)
+			}
+
+			if _, err := os.Stat(config.ConfigDir() + "/config.yaml"); err == nil && !force {
+				fmt.Print("\nOverwrite the current configuration? (y/N): ")
+				var response string
+				fmt.Scanln(&response)
+				if response != "y" && response != "Y" {
+					ui.PrintStatus("info", "Import cancelled")
+					ui.PrintSectionEnd()
+					return
+				}
+			}
+
+			if err := config.SaveConfig(imported); err != nil {
+				printConfigError("Failed to save configuration", err)
+				ui.PrintSectionEnd()
+				os.Exit(1)
+			}
+			ui.PrintStatus("success", fmt.Sprintf("Applied %d setting change(s) from %s", len(changes), args[0]))
+			ui.PrintStatus("info", "Run 'catops restart' so the daemon picks them up")
+			ui.PrintSectionEnd()
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite the existing configuration without asking")
+
+	return cmd
+}
+
+// configChanges lists "key: old -> new" for every setting that differs
+func configChanges(before, after *config.Config) []string {
+	old := make(map[string]string)
+	for _, kv := range dumpValues(before) {
+		old[kv[0]] = kv[1]
+	}
+
+	var changes []string
+	for _, kv := range dumpValues(after) {
+		if prev := old[kv[0]]; prev != kv[1] {
+			changes = append(changes, fmt.Sprintf("%s: %s -> %s", kv[0], prev, kv[1]))
+		}
+	}
+	return changes
+}
+
+// dumpValues splits a config dump into key/value pairs in dump order (map
+// entries are joined into one value)
+func dumpValues(cfg *config.Config) [][2]string {
+	var values [][2]string
+	for _, line := range strings.Split(cfg.Dump(false), "\n") {
+		if strings.HasPrefix(line, "  ") && len(values) > 0 {
+			last := &values[len(values)-1]
+			last[1] = strings.TrimSpace(last[1] + " " + strings.TrimSpace(line))
+			continue
+		}
+		if k, v, ok := strings.Cut(line, ": "); ok {
+			values = append(values, [2]string{k, v})
+		} else if k, ok := strings.CutSuffix(line, ":"); ok {
+			values = append(values, [2]string{k, ""})
+		}
+	}
+	return values
+}
+
+// printConfigError reports a failed config load or save, with a hint on how to
+// fix it for the errors config can classify
+func printConfigError(action string, err error) {
+	ui.PrintStatus("error", fmt.Sprintf("%s: %v", action, err))
+	if hint := config.ErrorHint(err); hint != "" {
+		ui.PrintStatus("info", hint)
+	}
+}
+
+// checkConfig prints every invalid setting of cfg (value ranges and OTLP
+// endpoints) and reports whether it is valid
+func checkConfig(cfg *config.Config) bool {
+	valid := true
+	if err := cfg.Validate(); err != nil {
+		for _, line := range strings.Split(err.Error(), "\n") {
+			ui.PrintStatus("error", line)
+		}
+		valid = false
+	}
+	for _, endpoint := range cfg.OTLPEndpointList() {
+		if _, err := metrics.ParseOTLPEndpoint(endpoint); err != nil {
+			ui.PrintStatus("error", err.Error())
+			valid = false
+		}
+	}
+	return valid
 }
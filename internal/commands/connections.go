@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"catops/internal/metrics"
+	"catops/internal/ui"
+)
+
+// NewConnectionsCmd creates the connections command
+func NewConnectionsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "connections",
+		Short: "Show the remote hosts with the most TCP connections",
+		Long: `Group the open TCP connections by remote address, most connections first,
+with how many are in each state. Useful to spot a flood from a few hosts or a
+client that leaks connections. Listening sockets are not counted.
+
+--processes adds the local processes owning the connections. Other users'
+sockets are only mapped to a process when catops runs as root.
+
+Examples:
+  catops connections                       # Top 20 remote hosts
+  catops connections --state ESTABLISHED   # Only established connections
+  catops connections --state time_wait -n 50
+  catops connections -p                    # With the owning local processes`,
+		Run: func(cmd *cobra.Command, args []string) {
+			state, _ := cmd.Flags().GetString("state")
+			limit, _ := cmd.Flags().GetInt("limit")
+			withProcesses, _ := cmd.Flags().GetBool("processes")
+
+			state = strings.ToUpper(state)
+			if state != "" && !slices.Contains(metrics.TCPStates, state) {
+				ui.PrintStatus("error", fmt.Sprintf("Unknown state %q (use %s)", state, strings.Join(metrics.TCPStates, ", ")))
+				os.Exit(1)
+			}
+
+			groups, err := metrics.GetRemoteConnections(state, withProcesses)
+			if err != nil {
+				ui.PrintStatus("error", fmt.Sprintf("Failed to read connections: %v", err))
+				os.Exit(1)
+			}
+
+			total := 0
+			for _, g := range groups {
+				total += g.Count
+			}
+
+			ui.PrintHeader()
+			title := "Connections by Remote Host"
+			if state != "" {
+				title += " (" + state + ")"
+			}
+			ui.PrintSection(title)
+			ui.PrintStatus("info", fmt.Sprintf("%d connections from %d remote hosts", total, len(groups)))
+			if limit > 0 && len(groups) > limit {
+				groups = groups[:limit]
+			}
+			fmt.Print(ui.CreateConnectionTable(groups, withProcesses))
+			ui.PrintTableSectionEnd()
+
+			if withProcesses && len(metrics.MissingPrivileges()) > 0 {
+				ui.PrintStatus("info", "Connections of other users' processes show as unknown, run as root to map them")
+			}
+		},
+	}
+
+	cmd.Flags().String("state", "", "Only count connections in this TCP state (e.g. ESTABLISHED, TIME_WAIT)")
+	cmd.Flags().IntP("limit", "n", 20, "Number of remote hosts to show")
+	cmd.Flags().BoolP("processes", "p", false, "Show the local processes owning the connections")
+
+	return cmd
+}
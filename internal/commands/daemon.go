@@ -2,20 +2,26 @@ package commands
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
-	"net/http"
+	"hash/fnv"
+	"math/rand"
 	"os"
 	"os/signal"
 	"runtime"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	constants "catops/config"
+	"catops/internal/alerts"
 	"catops/internal/analytics"
+	"catops/internal/certs"
 	"catops/internal/config"
+	"catops/internal/history"
 	"catops/internal/logger"
 	"catops/internal/metrics"
 	"catops/internal/server"
@@ -30,16 +36,24 @@ import (
 // 3. Checks for updates
 // All alerting and metric analysis is done on the backend
 func NewDaemonCmd() *cobra.Command {
-	return &cobra.Command{
+	var interval int
+
+	cmd := &cobra.Command{
 		Use:    "daemon",
 		Hidden: true,
 		Run: func(cmd *cobra.Command, args []string) {
-			runDaemon()
+			runDaemon(interval)
 		},
 	}
+
+	cmd.Flags().IntVar(&interval, "interval", 0, "Collection and alert check interval in seconds for this run (not saved)")
+
+	return cmd
 }
 
-func runDaemon() {
+// runDaemon runs the monitoring loop; a positive interval overrides the
+// configured collection and alert check intervals for this run only
+func runDaemon(interval int) {
 	// Log all exits
 	defer func() {
 		logger.Info("=== DAEMON EXITING - PID: %d ===", os.Getpid())
@@ -62,6 +76,13 @@ func runDaemon() {
 	logger.Info("=== DAEMON STARTING - PID: %d ===", os.Getpid())
 	logger.Info("========================================")
 
+	// Single instance, a PID file left by an unclean shutdown is cleaned up
+	if err := acquirePIDFile(); err != nil {
+		logger.Error("Not starting: %v", err)
+		return
+	}
+	defer releasePIDFile()
+
 	// Migrate service file if needed (fix for duplicate path bug in older versions)
 	service.MigrateServiceFile()
 
@@ -69,20 +90,47 @@ func runDaemon() {
 	cfg, err := config.LoadConfig()
 	if err != nil {
 		logger.Error("Error loading config: %v", err)
+		if hint := config.ErrorHint(err); hint != "" {
+			logger.Info("%s", hint)
+		}
 		os.Exit(1)
 	}
 
-	hostname, _ := os.Hostname()
+	// A secret reference that can't be read leaves the setting empty
+	for _, err := range cfg.SecretErrors() {
+		logger.Warning("Secret not readable, %v", err)
+	}
+
+	// --interval: faster iteration in the foreground without editing config.yaml
+	if interval > 0 {
+		if interval < constants.MIN_COLLECTION_INTERVAL {
+			logger.Warning("--interval %ds is below the minimum, using %ds", interval, constants.MIN_COLLECTION_INTERVAL)
+			interval = constants.MIN_COLLECTION_INTERVAL
+		}
+		cfg.CollectionInterval = interval
+		cfg.AlertCheckInterval = interval
+		logger.Info("Interval overridden to %ds for this run", interval)
+	}
+
+	hostname := cfg.DisplayHostname()
+
+	// Per-host jitter so a fleet installed together doesn't hit the backend in lockstep
+	jitter := newDaemonJitter()
 
-	// Send service start event
-	if cfg.IsCloudMode() {
-		analytics.NewSender(cfg, GetCurrentVersion()).SendEvent("service_start")
-		server.UpdateServerVersion(cfg.AuthToken, GetCurrentVersion(), cfg)
+	// Send service start event and server info (both are analytics)
+	if cfg.IsCloudMode() && cfg.AnalyticsEnabled {
+		go func() {
+			time.Sleep(jitter.delay(maxRequestJitter))
+			analytics.NewSender(cfg, GetCurrentVersion()).SendEvent("service_start")
+			server.UpdateServerVersion(cfg.AuthToken, GetCurrentVersion(), cfg)
+		}()
 	}
 
 	// Start metrics collection (sends catops.* metrics directly to backend)
 	var metricsStarted bool
-	if cfg.IsCloudMode() && cfg.AuthToken != "" && cfg.ServerID != "" {
+	exportWanted := cfg.IsCloudMode() && cfg.AuthToken != "" && cfg.ServerID != "" && cfg.OTLPEnabled
+	exportSince := time.Now()
+	if exportWanted {
 		metricsStarted = startMetricsCollection(cfg, hostname)
 	}
 	defer func() {
@@ -95,15 +143,134 @@ func runDaemon() {
 	}()
 
 
+	// Optional user executable run for every fired alert
+	var alertHook *alerts.Hook
+	if cfg.AlertHook != "" {
+		alertHook = &alerts.Hook{Path: cfg.AlertHook, Timeout: cfg.AlertHookPeriod(), Hostname: hostname}
+	}
+
+	// Optional email delivery through an SMTP relay
+	var alertEmail *alerts.Email
+	if cfg.SMTPHost != "" && len(cfg.AlertEmailTo) > 0 {
+		alertEmail = &alerts.Email{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			User:     cfg.SMTPUser,
+			Pass:     cfg.SMTPPass,
+			From:     cfg.AlertEmailFrom(),
+			To:       cfg.AlertEmailTo,
+			Hostname: hostname,
+		}
+	}
+
+	// Every alert and resolution is also kept in ~/.catops/alerts.jsonl ('catops alerts')
+	alertLog := alerts.NewLog()
+	alertChannels := cfg.AlertChannels()
+
+	// Local alert thresholds, evaluated after each collection
+	alertManager := alerts.NewManager(alerts.ThresholdsFromConfig(cfg), func(a alerts.Alert) {
+		tags := map[string]string{
+			"alert_type": a.Type,
+			"subject":    a.Subject,
+		}
+		for k, v := range a.Details {
+			tags[k] = v
+		}
+		analytics.NewSender(cfg, GetCurrentVersion()).SendAlert(a.Severity, a.Message, tags)
+		alertHook.Run(a)
+		alertEmail.Send(a)
+		if err := alertLog.Append(a, alertChannels); err != nil {
+			logger.Warning("[ALERT] Failed to write alert log: %v", err)
+		}
+	})
+	alertManager.OnResolve(func(a alerts.Alert) {
+		if err := alertLog.Append(a, nil); err != nil {
+			logger.Warning("[ALERT] Failed to write alert log: %v", err)
+		}
+	})
+
+	// Local metrics history for 'catops history'
+	historyWriter := history.NewWriter()
+
 	logger.Info("Daemon initialized:")
 	logger.Info("  Mode: %s", cfg.Mode)
-	logger.Info("  Collection interval: %ds", cfg.CollectionInterval)
+	logger.Info("  Log file: %s", logger.Path())
+	logger.Info("  Collection interval: %v", cfg.CollectionPeriod())
+	logger.Info("  OTLP export interval: %v", cfg.ExportPeriod())
+	logger.Info("  Alert check interval: %v", cfg.AlertPeriod())
+	if exportWanted {
+		logger.Info("  Export stale alert after: %v", cfg.ExportStalePeriod())
+	}
 	if metricsStarted {
-		logger.Info("  Metrics: sending via OTLP to %s", constants.OTLP_ENDPOINT)
+		logger.Info("  Metrics: sending via OTLP to %s", strings.Join(cfg.OTLPEndpointList(), ", "))
 		logger.Info("  Alerts: processed on backend")
+	} else if !cfg.OTLPEnabled {
+		logger.Info("  Metrics: export disabled (otlp_enabled: false)")
 	} else {
 		logger.Info("  Metrics: not started (local mode or missing credentials)")
 	}
+	if !cfg.AnalyticsEnabled {
+		logger.Info("  Analytics: disabled (analytics_enabled: false)")
+	}
+	if cfg.InMaintenance() {
+		logger.Info("  Maintenance: active until %s", time.Unix(cfg.MaintenanceUntil, 0).Format(time.RFC3339))
+	}
+	if len(cfg.CertPaths) > 0 {
+		logger.Info("  Certificates: %d monitored, warning at %d days", len(cfg.CertPaths), cfg.CertExpiryWarningDays)
+	}
+	if metrics.IsSystemd() {
+		logger.Info("  systemd units: failed units reported, %d tracked", len(cfg.SystemdUnits))
+	}
+	if len(cfg.RequiredServices) > 0 {
+		logger.Info("  Required services: %s", strings.Join(cfg.RequiredServices, ", "))
+	}
+	if len(cfg.DiskMounts) > 0 {
+		logger.Info("  Disk mounts: only %s", strings.Join(cfg.DiskMounts, ", "))
+	}
+	if cfg.NetworkSummary == "primary" {
+		logger.Info("  Network totals: primary interface only")
+	}
+	if missing := metrics.MissingPrivileges(); len(missing) > 0 {
+		logger.Warning("  Running as non-root (uid %d): %s unavailable for other users' processes", os.Geteuid(), strings.Join(missing, ", "))
+		if cfg.SkipPrivilegedMetrics {
+			logger.Info("  Privileged reads: skipped for other users' processes (skip_privileged_metrics)")
+		} else {
+			logger.Info("  Set skip_privileged_metrics: true to stop attempting them, or run the daemon as root")
+		}
+	}
+	if alertManager.Enabled() {
+		logger.Info("  Local alerts (warn/crit): IOPS %d/%d ops/s, throughput %g/%g MB/s, fd %g/%g%% (0 = off)",
+			cfg.IOPSThreshold, cfg.IOPSCritical, cfg.ThroughputThreshold, cfg.ThroughputCritical, cfg.FDThreshold, cfg.FDCritical)
+	}
+	if cfg.ProcessIOThreshold > 0 {
+		logger.Info("  Process disk IO alerts: %g MB/s read+write per process", cfg.ProcessIOThreshold)
+	}
+	if cfg.MemAvailableMin > 0 {
+		logger.Info("  Available memory alert: below %g MB", cfg.MemAvailableMin)
+	}
+	if cfg.TemperatureThreshold > 0 {
+		logger.Info("  Temperature alert: above %g °C on any sensor, and on CPU thermal throttling", cfg.TemperatureThreshold)
+	}
+	if cfg.NetRateThreshold > 0 || cfg.NetRatePercent > 0 {
+		logger.Info("  Network rate alerts: %g MB/s or %g%% of link speed per interface (0 = off)", cfg.NetRateThreshold, cfg.NetRatePercent)
+	}
+	if alertHook != nil {
+		logger.Info("  Alert hook: %s (timeout %v)", alertHook.Path, alertHook.Timeout)
+		if info, err := os.Stat(alertHook.Path); err != nil || info.IsDir() || (runtime.GOOS != "windows" && info.Mode()&0111 == 0) {
+			logger.Warning("  Alert hook %s is not an executable file, it will fail on every alert", alertHook.Path)
+		}
+	}
+	if alertEmail != nil {
+		logger.Info("  Alert email: %s via %s:%d", strings.Join(alertEmail.To, ", "), alertEmail.Host, alertEmail.Port)
+	} else if cfg.SMTPHost != "" || len(cfg.AlertEmailTo) > 0 {
+		logger.Warning("  Alert email disabled: both smtp_host and alert_email_to are required")
+	}
+	if cfg.SustainedDuration > 0 {
+		logger.Info("  Sustained duration: %ds before a local alert fires", cfg.SustainedDuration)
+	}
+	if alertManager.Enabled() {
+		logger.Info("  Alert warm-up: %v after start", cfg.AlertWarmUpPeriod())
+	}
 
 	// Notify systemd that we're ready (for Type=notify services)
 	service.NotifyReady()
@@ -113,32 +280,65 @@ func runDaemon() {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGTERM, syscall.SIGINT)
 
+	// On-demand metrics dump (SIGUSR1, not available on Windows)
+	dumpChan := make(chan os.Signal, 1)
+	if len(dumpSignals) > 0 {
+		signal.Notify(dumpChan, dumpSignals...)
+	}
+
 	// Update check ticker (once per day)
-	updateTicker := time.NewTicker(24 * time.Hour)
+	updateTicker := jitter.ticker(24 * time.Hour)
 	defer updateTicker.Stop()
 
 	// Health check ticker (every 5 minutes)
-	healthTicker := time.NewTicker(5 * time.Minute)
+	healthTicker := jitter.ticker(5 * time.Minute)
 	defer healthTicker.Stop()
 
+	// Certificate expiry check ticker (every hour, only when cert_paths is set)
+	certTicker := jitter.ticker(time.Hour)
+	defer certTicker.Stop()
+	checkCerts := func() {
+		if len(cfg.CertPaths) == 0 {
+			return
+		}
+		// Endpoint dials can be slow, keep them off the main loop
+		go func() {
+			alertManager.EvaluateCerts(certs.Check(cfg.CertPaths), cfg.CertExpiryWarningDays)
+		}()
+	}
+	checkCerts()
+
 	// OTel failure tracking for recovery logic
 	var consecutiveOTelFailures int
 	const maxOTelFailuresBeforeRestart = 3
 
-	// Metrics collection ticker - must run BEFORE OTel SDK reads the cache
-	// OTel SDK calls callbacks at CollectionInterval, we collect slightly faster
-	metricsInterval := time.Duration(cfg.CollectionInterval) * time.Second
-	if metricsInterval == 0 {
-		metricsInterval = 30 * time.Second
-	}
-	metricsTicker := time.NewTicker(metricsInterval)
+	// Three independent schedules:
+	//   - collection_interval: collect metrics into the cache (and local history)
+	//   - otlp_export_interval: OTel periodic reader exports whatever is cached
+	//   - alert_check_interval: evaluate local thresholds against the latest collection
+	// Export and alert periods are never shorter than the collection period.
+	// Each schedule's first tick is offset by a per-host random jitter.
+	// Note that delta tracking (checkAndUpdateDelta) only replaces the cache when
+	// CPU/mem/disk moved by >1% or every 60s, so exports in between resend the
+	// last significant snapshot.
+	metricsTicker := jitter.ticker(cfg.CollectionPeriod())
 	defer metricsTicker.Stop()
 
+	alertTicker := jitter.ticker(cfg.AlertPeriod())
+	defer alertTicker.Stop()
+	maintenance := newMaintenanceWindow(cfg)
+
+	// Latest collection, evaluated by the alert ticker
+	var lastCollected *metrics.AllMetrics
+
 	// Initial metrics collection (so first OTel export has data)
 	if metricsStarted {
-		if _, err := metrics.CollectAllMetrics(); err != nil {
+		if m, err := metrics.CollectAllMetrics(); m == nil || m.Summary == nil {
 			logger.Warning("Initial metrics collection failed: %v", err)
 		} else {
+			if err != nil {
+				logger.Warning("Initial metrics collected with errors: %v", err)
+			}
 			logger.Debug("Initial metrics collected successfully")
 			// Force immediate export so dashboard shows data right away
 			if err := metrics.ForceFlush(); err != nil {
@@ -153,32 +353,73 @@ func runDaemon() {
 	for {
 		select {
 		case <-metricsTicker.C:
-			// Collect metrics and update cache for OTel callbacks
-			if metricsStarted {
-				if m, err := metrics.CollectAllMetrics(); err != nil {
-					logger.Warning("Metrics collection error: %v", err)
-				} else if m != nil && m.Summary != nil {
-					// Count total logs across containers and services
-					totalLogs := 0
-					for _, c := range m.Containers {
-						totalLogs += len(c.RecentLogs)
-					}
-					for _, s := range m.Services {
-						totalLogs += len(s.RecentLogs)
+			metricsTicker.align()
+			// Collect metrics and update cache for OTel callbacks, local history and alerts
+			m, err := metrics.CollectAllMetrics()
+			if err != nil {
+				logger.Warning("Metrics collection error: %v", err)
+			}
+			if m != nil && m.Summary != nil {
+				// Count total logs across containers and services
+				totalLogs := 0
+				for _, c := range m.Containers {
+					totalLogs += len(c.RecentLogs)
+				}
+				for _, s := range m.Services {
+					totalLogs += len(s.RecentLogs)
+				}
+				containerInfo := ""
+				for _, c := range m.Containers {
+					if len(c.RecentLogs) > 0 {
+						containerInfo += fmt.Sprintf(" [%s:%dlogs]", c.ContainerName, len(c.RecentLogs))
 					}
-					containerInfo := ""
-					for _, c := range m.Containers {
-						if len(c.RecentLogs) > 0 {
-							containerInfo += fmt.Sprintf(" [%s:%dlogs]", c.ContainerName, len(c.RecentLogs))
-						}
+				}
+				logger.Info("[COLLECT] CPU: %.1f%%, Mem: %.1f%%, Disk: %.1f%%, Procs: %d, Containers: %d, Logs: %d%s",
+					m.Summary.CPUUsage, m.Summary.MemoryUsage, m.Summary.DiskUsage,
+					len(m.Processes), len(m.Containers), totalLogs, containerInfo)
+			}
+			if m != nil && m.Summary != nil {
+				if err := historyWriter.Append(m.Summary); err != nil {
+					if errors.Is(err, utils.ErrLowDiskSpace) {
+						logger.Warning("History entry dropped: %v", err)
+					} else {
+						logger.Debug("Failed to write history: %v", err)
 					}
-					logger.Info("[COLLECT] CPU: %.1f%%, Mem: %.1f%%, Disk: %.1f%%, Procs: %d, Containers: %d, Logs: %d%s",
-						m.Summary.CPUUsage, m.Summary.MemoryUsage, m.Summary.DiskUsage,
-						len(m.Processes), len(m.Containers), totalLogs, containerInfo)
 				}
 			}
+			saveLogStats()
+			if exportWanted {
+				saveExportStatus(exportStatus{
+					Since:       exportSince,
+					LastSuccess: metrics.LastExportSuccess(),
+					StaleAfter:  cfg.ExportStalePeriod().Seconds(),
+				})
+			}
+			if m != nil {
+				lastCollected = m
+			}
+
+		case <-alertTicker.C:
+			alertTicker.align()
+			// Maintenance is toggled by 'catops maintenance', pick up the current window
+			alertManager.SetMaintenance(hostname, maintenance.until())
+			alertManager.Evaluate(lastCollected)
+			// Dead man's switch: the dashboard goes quiet when exports fail,
+			// so say so through the local channels
+			if exportWanted {
+				lastExport := metrics.LastExportSuccess()
+				if lastExport.IsZero() {
+					lastExport = exportSince
+				}
+				alertManager.EvaluateExport(lastExport, cfg.ExportStalePeriod())
+			}
+
+		case <-certTicker.C:
+			certTicker.align()
+			checkCerts()
 
 		case <-healthTicker.C:
+			healthTicker.align()
 			// Log health status and notify systemd watchdog
 			var memStats runtime.MemStats
 			runtime.ReadMemStats(&memStats)
@@ -210,7 +451,7 @@ func runDaemon() {
 						if metricsStarted {
 							logger.Info("OTel collector restarted successfully")
 							// Collect and send initial metrics after restart
-							if _, err := metrics.CollectAllMetrics(); err == nil {
+							if m, _ := metrics.CollectAllMetrics(); m != nil && m.Summary != nil {
 								metrics.ForceFlush()
 							}
 						} else {
@@ -233,7 +474,16 @@ func runDaemon() {
 			service.NotifyWatchdog()
 
 		case <-updateTicker.C:
-			checkForUpdates()
+			updateTicker.align()
+			if cfg.AnalyticsEnabled {
+				go func() {
+					time.Sleep(jitter.delay(maxRequestJitter))
+					checkForUpdates()
+				}()
+			}
+
+		case <-dumpChan:
+			go dumpMetrics()
 
 		case sig := <-sigChan:
 			logger.Info("========================================")
@@ -254,19 +504,124 @@ func runDaemon() {
 	}
 }
 
+// dumpMetrics collects a full snapshot outside the regular schedule and writes
+// it as JSON to METRICS_DUMP_FILE. Nothing is flushed, exports keep their period.
+func dumpMetrics() {
+	logger.Info("Metrics dump requested")
+	m, err := metrics.CollectAllMetrics()
+	if err != nil {
+		logger.Warning("Metrics dump collected with errors: %v", err)
+	}
+	if m == nil {
+		return
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		logger.Error("Failed to encode metrics dump: %v", err)
+		return
+	}
+	if err := os.WriteFile(constants.METRICS_DUMP_FILE, data, 0600); err != nil {
+		logger.Error("Failed to write metrics dump: %v", err)
+		return
+	}
+	logger.Info("Metrics dump written to %s", constants.METRICS_DUMP_FILE)
+}
+
+// maxRequestJitter bounds the random delay before one-off backend requests
+const maxRequestJitter = 10 * time.Second
+
+// daemonJitter spreads daemon schedules across a fleet. It is seeded from
+// hostname+pid, so offsets are stable per host but differ between hosts.
+type daemonJitter struct {
+	rand *rand.Rand
+	mu   sync.Mutex
+}
+
+func newDaemonJitter() *daemonJitter {
+	hostname, _ := os.Hostname()
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%s:%d", hostname, os.Getpid())
+	return &daemonJitter{rand: rand.New(rand.NewSource(int64(h.Sum64())))}
+}
+
+// delay returns a random duration in [0, max)
+func (j *daemonJitter) delay(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return time.Duration(j.rand.Int63n(int64(max)))
+}
+
+// jitteredTicker fires first after a random offset within its period, then every period
+type jitteredTicker struct {
+	*time.Ticker
+	period  time.Duration
+	aligned bool
+}
+
+// ticker creates a jittered ticker; call align after each tick
+func (j *daemonJitter) ticker(period time.Duration) *jitteredTicker {
+	offset := j.delay(period)
+	if offset <= 0 {
+		offset = time.Millisecond
+	}
+	return &jitteredTicker{Ticker: time.NewTicker(offset), period: period}
+}
+
+// align switches to the regular period after the first (jittered) tick
+func (t *jitteredTicker) align() {
+	if !t.aligned {
+		t.Reset(t.period)
+		t.aligned = true
+	}
+}
+
+// maintenanceWindow follows the maintenance window set by 'catops maintenance'
+// in config.yaml. The config is only re-read when the file changed, not on
+// every alert check.
+type maintenanceWindow struct {
+	version string // config.FileVersion at the last read
+	end     int64  // MaintenanceUntil, unix seconds
+}
+
+// newMaintenanceWindow starts from the window loaded at startup
+func newMaintenanceWindow(cfg *config.Config) *maintenanceWindow {
+	return &maintenanceWindow{end: cfg.MaintenanceUntil}
+}
+
+// until returns the end of the current window (zero when none), keeping the
+// last known value when the changed file can't be read
+func (w *maintenanceWindow) until() time.Time {
+	if version := config.FileVersion(); version != w.version {
+		if current, err := config.LoadConfig(); err == nil {
+			w.version = version
+			w.end = current.MaintenanceUntil
+		}
+	}
+	if w.end <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(w.end, 0)
+}
+
 // startMetricsCollection initializes and starts the built-in metrics collection
 func startMetricsCollection(cfg *config.Config, hostname string) bool {
-	interval := time.Duration(cfg.CollectionInterval) * time.Second
-	if interval == 0 {
-		interval = 30 * time.Second
-	}
+	interval := cfg.ExportPeriod()
 
 	otelCfg := &metrics.OTelConfig{
-		Endpoint:           constants.OTLP_ENDPOINT,
+		Endpoints:          cfg.OTLPEndpointList(),
 		AuthToken:          cfg.AuthToken,
 		ServerID:           cfg.ServerID,
 		Hostname:           hostname,
 		CollectionInterval: interval,
+		Labels:             cfg.ServerLabels,
+		Environment:        cfg.Environment,
+		Region:             cfg.Region,
+		ExportTimeout:      cfg.ExportTimeout(),
+		MetricGroups:       cfg.EnabledMetricGroups,
 	}
 
 	if err := metrics.StartOTelCollector(otelCfg); err != nil {
@@ -289,7 +644,7 @@ func checkForUpdates() {
 		return
 	}
 
-	client := &http.Client{Timeout: 10 * time.Second}
+	client := utils.NewHTTPClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		logger.Error("Failed to check for updates: %v", err)
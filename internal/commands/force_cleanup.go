@@ -1,7 +1,7 @@
 package commands
 
 import (
-	"os/exec"
+	"fmt"
 
 	"github.com/spf13/cobra"
 
@@ -32,9 +32,10 @@ Examples:
 				svc.Stop()
 			}
 
-			// Also kill any remaining processes via pkill
-			killCmd := exec.Command("pkill", "-9", "-f", "catops daemon")
-			killCmd.Run() // Ignore errors
+			// Also stop any remaining daemons started outside the service manager
+			if stopped := stopDaemonProcesses(); stopped > 0 {
+				ui.PrintStatus("info", fmt.Sprintf("Stopped %d remaining daemon process(es)", stopped))
+			}
 
 			ui.PrintStatus("success", "Force cleanup completed.")
 			ui.PrintStatus("info", "Run 'catops service start' to start fresh.")
@@ -0,0 +1,139 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"catops/internal/history"
+	"catops/internal/ui"
+	"catops/pkg/utils"
+)
+
+// historyMetrics maps metric names to value extractors and formatters
+var historyMetrics = map[string]struct {
+	value  func(e history.Entry) float64
+	format func(v float64) string
+}{
+	"cpu":    {func(e history.Entry) float64 { return e.CPUUsage }, utils.FormatPercentage},
+	"memory": {func(e history.Entry) float64 { return e.MemoryUsage }, utils.FormatPercentage},
+	"swap":   {func(e history.Entry) float64 { return e.SwapUsage }, utils.FormatPercentage},
+	"disk":   {func(e history.Entry) float64 { return e.DiskUsage }, utils.FormatPercentage},
+	"load":   {func(e history.Entry) float64 { return e.Load1m }, func(v float64) string { return fmt.Sprintf("%.2f", v) }},
+	"iops":   {func(e history.Entry) float64 { return float64(e.DiskIOPS) }, func(v float64) string { return fmt.Sprintf("%.0f ops/s", v) }},
+}
+
+// NewHistoryCmd creates the history command
+func NewHistoryCmd() *cobra.Command {
+	var last string
+
+	cmd := &cobra.Command{
+		Use:   "history <metric>",
+		Short: "Show recent metric trends from local history",
+		Long: `Show a trend for a metric recorded locally by the monitoring daemon.
+History is kept in ~/.catops/history.jsonl for up to 7 days, no backend required.
+
+Metrics:
+  cpu, memory, swap, disk, load, iops, net_in, net_out
+
+Examples:
+  catops history cpu                # Last hour of CPU usage
+  catops history memory --last 24h  # Last day of memory usage`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			metric := strings.ToLower(args[0])
+
+			ui.PrintHeader()
+			ui.PrintSection(fmt.Sprintf("History: %s (last %s)", metric, last))
+
+			window, err := time.ParseDuration(last)
+			if err != nil || window <= 0 {
+				ui.PrintStatus("error", fmt.Sprintf("Invalid duration: %s (use e.g. 30m, 1h, 24h)", last))
+				ui.PrintSectionEnd()
+				return
+			}
+
+			entries, err := history.Load(time.Now().Add(-window))
+			if err != nil {
+				ui.PrintStatus("error", fmt.Sprintf("Failed to read history: %v", err))
+				ui.PrintSectionEnd()
+				return
+			}
+
+			values, format, ok := historyValues(metric, entries)
+			if !ok {
+				ui.PrintStatus("error", fmt.Sprintf("Unknown metric: %s", metric))
+				ui.PrintStatus("info", "Supported: cpu, memory, swap, disk, load, iops, net_in, net_out")
+				ui.PrintSectionEnd()
+				return
+			}
+			if len(values) == 0 {
+				ui.PrintStatus("warning", "No history recorded for this period")
+				ui.PrintStatus("info", "History is written by the monitoring daemon, run 'catops start'")
+				ui.PrintSectionEnd()
+				return
+			}
+
+			min, max, sum := values[0], values[0], 0.0
+			for _, v := range values {
+				if v < min {
+					min = v
+				}
+				if v > max {
+					max = v
+				}
+				sum += v
+			}
+
+			fmt.Printf("  %s\n\n", ui.RenderSparkline(values, 56))
+			fmt.Print(ui.CreateBeautifulList(map[string]string{
+				"Min":     format(min),
+				"Max":     format(max),
+				"Avg":     format(sum / float64(len(values))),
+				"Latest":  format(values[len(values)-1]),
+				"Samples": utils.FormatNumber(int64(len(values))),
+			}))
+			ui.PrintSectionEnd()
+		},
+	}
+
+	cmd.Flags().StringVar(&last, "last", "1h", "Time window to show (e.g. 30m, 1h, 24h)")
+
+	return cmd
+}
+
+// historyValues extracts a metric series from history entries
+func historyValues(metric string, entries []history.Entry) ([]float64, func(float64) string, bool) {
+	if m, ok := historyMetrics[metric]; ok {
+		values := make([]float64, 0, len(entries))
+		for _, e := range entries {
+			values = append(values, m.value(e))
+		}
+		return values, m.format, true
+	}
+
+	// Network counters are cumulative, convert to per-second rates
+	var counter func(e history.Entry) uint64
+	switch metric {
+	case "net_in":
+		counter = func(e history.Entry) uint64 { return e.NetBytesRecv }
+	case "net_out":
+		counter = func(e history.Entry) uint64 { return e.NetBytesSent }
+	default:
+		return nil, nil, false
+	}
+
+	var values []float64
+	for i := 1; i < len(entries); i++ {
+		prev, cur := entries[i-1], entries[i]
+		elapsed := cur.Timestamp.Sub(prev.Timestamp).Seconds()
+		if elapsed <= 0 || counter(cur) < counter(prev) {
+			continue // counter reset (reboot) or clock skew
+		}
+		values = append(values, float64(counter(cur)-counter(prev))/elapsed)
+	}
+	format := func(v float64) string { return utils.FormatBytes(int64(v)) + "/s" }
+	return values, format, true
+}
@@ -0,0 +1,178 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"catops/internal/logger"
+	"catops/internal/ui"
+)
+
+// logFollowInterval is how often 'catops log tail --follow' checks for new lines
+const logFollowInterval = 500 * time.Millisecond
+
+// NewLogPathCmd creates the log-path command
+func NewLogPathCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "log-path",
+		Short: "Print where the daemon writes its own log",
+		Long: `Print the path of the daemon log, for use in scripts and support requests.
+A warning on stderr notes when the location does not survive a reboot; move
+the log with log_file in ~/.catops/config.yaml.
+
+This is the CatOps daemon's log. Service and container logs collected by the
+daemon are inspected with 'catops logs'.
+
+Examples:
+  catops log-path
+  less $(catops log-path)`,
+		Run: func(cmd *cobra.Command, args []string) {
+			path := logger.Path()
+			fmt.Println(path)
+			if ephemeralPath(path) {
+				fmt.Fprintf(os.Stderr, "warning: %s is cleared on reboot, set log_file in ~/.catops/config.yaml to keep it\n", path)
+			}
+		},
+	}
+}
+
+// NewLogCmd creates the log command
+func NewLogCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "log",
+		Short: "Read the daemon's own log",
+		Long: `Read the CatOps daemon log (see 'catops log-path').
+
+Service and container logs collected by the daemon are inspected with
+'catops logs' instead.
+
+Examples:
+  catops log tail           # Last 50 lines
+  catops log tail -n 200 -f # Last 200 lines, then follow new ones`,
+	}
+	cmd.AddCommand(newLogTailCmd())
+	return cmd
+}
+
+// newLogTailCmd creates the log tail subcommand
+func newLogTailCmd() *cobra.Command {
+	var lines int
+	var follow bool
+
+	cmd := &cobra.Command{
+		Use:   "tail",
+		Short: "Print the last lines of the daemon log",
+		Run: func(cmd *cobra.Command, args []string) {
+			path := logger.Path()
+			if ephemeralPath(path) {
+				ui.PrintStatus("warning", fmt.Sprintf("%s is cleared on reboot, set log_file in ~/.catops/config.yaml to keep it", path))
+			}
+
+			offset, err := printLogTail(path, lines)
+			if err != nil {
+				ui.PrintStatus("error", fmt.Sprintf("Failed to read the daemon log: %v", err))
+				ui.PrintStatus("info", "The daemon creates it on start, see 'catops status'")
+				os.Exit(1)
+			}
+			if follow {
+				followLog(path, offset)
+			}
+		},
+	}
+
+	cmd.Flags().IntVarP(&lines, "lines", "n", 50, "Number of lines to show")
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Keep printing lines as the daemon writes them")
+	return cmd
+}
+
+// printLogTail prints the last n lines of path and returns the offset it read up to
+func printLogTail(path string, n int) (int64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+
+	content := strings.TrimRight(string(data), "\n")
+	if content != "" && n > 0 {
+		lines := strings.Split(content, "\n")
+		if len(lines) > n {
+			lines = lines[len(lines)-n:]
+		}
+		fmt.Println(strings.Join(lines, "\n"))
+	}
+	return int64(len(data)), nil
+}
+
+// followLog prints what is appended to path after offset until interrupted,
+// starting over when the file is truncated or replaced
+func followLog(path string, offset int64) {
+	var current os.FileInfo
+	for {
+		time.Sleep(logFollowInterval)
+
+		info, err := os.Stat(path)
+		if err != nil {
+			continue // removed, wait for the daemon to recreate it
+		}
+		if (current != nil && !os.SameFile(current, info)) || info.Size() < offset {
+			offset = 0
+		}
+		current = info
+		if info.Size() == offset {
+			continue
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		if _, err := f.Seek(offset, io.SeekStart); err == nil {
+			reader := bufio.NewReader(f)
+			for {
+				line, err := reader.ReadString('\n')
+				if err != nil {
+					break // a partial line is printed once it is complete
+				}
+				fmt.Print(line)
+				offset += int64(len(line))
+			}
+		}
+		f.Close()
+	}
+}
+
+// ephemeralPath reports whether path is on a tmpfs or under /tmp, which most
+// systems clear on reboot
+func ephemeralPath(path string) bool {
+	path = filepath.Clean(path)
+	if path == "/tmp" || strings.HasPrefix(path, "/tmp/") {
+		return true
+	}
+
+	mounts, err := os.ReadFile("/proc/self/mounts")
+	if err != nil {
+		return false
+	}
+	var mountPoint, fsType string
+	for _, line := range strings.Split(string(mounts), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		point := fields[1]
+		if point != "/" && path != point && !strings.HasPrefix(path, point+"/") {
+			continue
+		}
+		if len(point) >= len(mountPoint) {
+			mountPoint, fsType = point, fields[2]
+		}
+	}
+	return fsType == "tmpfs" || fsType == "ramfs"
+}
@@ -0,0 +1,365 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"catops/internal/config"
+	"catops/internal/logger"
+	"catops/internal/metrics"
+	"catops/internal/ui"
+)
+
+// logStatsFileName holds the daemon's log deduplication counters
+const logStatsFileName = "log_stats.json"
+
+// logStatsPath returns where the daemon writes log deduplication counters
+func logStatsPath() string {
+	return filepath.Join(config.ConfigDir(), logStatsFileName)
+}
+
+// lastLogStats are the counters saveLogStats last wrote, UpdatedAt cleared
+var lastLogStats *metrics.LogStats
+
+// saveLogStats writes the current log deduplication counters for 'catops logs
+// stats', only when they changed since the last write
+func saveLogStats() {
+	stats := metrics.GetLogStats()
+	compare := stats
+	compare.UpdatedAt = time.Time{}
+	compare.Sources = slices.Clone(stats.Sources)
+	for i := range compare.Sources {
+		compare.Sources[i].LastSeen = time.Time{}
+	}
+	if lastLogStats != nil && reflect.DeepEqual(*lastLogStats, compare) {
+		return
+	}
+
+	data, err := json.Marshal(stats)
+	if err != nil {
+		return
+	}
+	if err := os.WriteFile(logStatsPath(), data, 0600); err != nil {
+		logger.Debug("Failed to write log stats: %v", err)
+		return
+	}
+	lastLogStats = &compare
+}
+
+// loadLogStats reads the counters written by the daemon, reporting why they
+// are unavailable
+func loadLogStats() (metrics.LogStats, bool) {
+	var stats metrics.LogStats
+	data, err := os.ReadFile(logStatsPath())
+	if err != nil {
+		ui.PrintStatus("warning", "No stats yet, the daemon writes them when its counters change")
+		return stats, false
+	}
+	if err := json.Unmarshal(data, &stats); err != nil {
+		ui.PrintStatus("error", fmt.Sprintf("Failed to read %s: %v", logStatsPath(), err))
+		return stats, false
+	}
+	return stats, true
+}
+
+// NewLogsCmd creates the logs command
+func NewLogsCmd() *cobra.Command {
+	var since, until string
+	var all bool
+	var limit int
+
+	logsCmd := &cobra.Command{
+		Use:   "logs",
+		Short: "Inspect service and container log collection",
+		Long: `Inspect how the daemon collects error and warning lines from service and
+container logs.
+
+With --since, the container and pm2 logs are re-read for a past time window,
+for looking into an incident after the fact. Only error and warning lines are
+shown unless --all is given. Times are a duration ago (2h, 1d) or a local
+time (2024-05-01 14:30, RFC 3339 also works). pm2 lines need timestamps
+(pm2 start --time); rotated files (.1, .1.gz) are read too.
+
+Commands:
+  stats    Show log deduplication and rate limit counters
+  sources  List log sources, disable or re-enable one
+
+Examples:
+  catops logs --since 2h                  # Errors and warnings of the last 2 hours
+  catops logs --since "2024-05-01 14:00" --until "2024-05-01 15:00" --all
+  catops logs stats                  # Lines sent vs dropped as duplicates
+  catops logs sources                # Containers and services being read
+  catops logs sources disable nginx  # Stop collecting a source's logs`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if since == "" {
+				cmd.Help()
+				return
+			}
+
+			ui.PrintHeader()
+			from, err := parseLogTime(since)
+			if err != nil {
+				ui.PrintStatus("error", fmt.Sprintf("Invalid --since: %v", err))
+				os.Exit(1)
+			}
+			var to time.Time
+			if until != "" {
+				if to, err = parseLogTime(until); err != nil {
+					ui.PrintStatus("error", fmt.Sprintf("Invalid --until: %v", err))
+					os.Exit(1)
+				}
+				if !to.After(from) {
+					ui.PrintStatus("error", "--until must be after --since")
+					os.Exit(1)
+				}
+			}
+			printLogWindow(from, to, all, limit)
+		},
+	}
+
+	logsCmd.Flags().StringVar(&since, "since", "", "Show logs written after this time (e.g. 2h, 1d, \"2024-05-01 14:00\")")
+	logsCmd.Flags().StringVar(&until, "until", "", "Show logs written before this time (default: now)")
+	logsCmd.Flags().BoolVar(&all, "all", false, "Show every line, not only errors and warnings")
+	logsCmd.Flags().IntVarP(&limit, "lines", "n", 200, "Show at most this many lines per source, newest kept (0 = all)")
+
+	logsCmd.AddCommand(newLogsStatsCmd())
+	logsCmd.AddCommand(newLogsSourcesCmd())
+
+	return logsCmd
+}
+
+// logTimeLayouts are the absolute times accepted by --since and --until, in local time
+var logTimeLayouts = []string{"2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02T15:04:05", "2006-01-02"}
+
+// parseLogTime parses a duration ago (as 'catops alerts --last') or an absolute time
+func parseLogTime(s string) (time.Time, error) {
+	if d, err := parseAlertWindow(s); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	for _, layout := range logTimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q is neither a duration like 2h nor a time like 2024-05-01 14:00", s)
+}
+
+// printLogWindow shows the lines each log source wrote between from and to
+func printLogWindow(from, to time.Time, all bool, limit int) {
+	window := fmt.Sprintf("%s to now", from.Format("2006-01-02 15:04:05"))
+	if !to.IsZero() {
+		window = fmt.Sprintf("%s to %s", from.Format("2006-01-02 15:04:05"), to.Format("2006-01-02 15:04:05"))
+	}
+
+	sources := metrics.GetLogCollector().ReadLogWindow(from, to, all, limit)
+	if len(sources) == 0 {
+		ui.PrintSection("Logs " + window)
+		ui.PrintStatus("info", "No container or pm2 log sources found")
+		ui.PrintSectionEnd()
+		return
+	}
+
+	sort.Slice(sources, func(i, j int) bool { return sources[i].Source.Name < sources[j].Source.Name })
+	for _, w := range sources {
+		ui.PrintSection(fmt.Sprintf("%s: %s (%s)", w.Source.Type, w.Source.Name, window))
+		if w.Note != "" {
+			ui.PrintStatus("warning", w.Note)
+		}
+		if len(w.Lines) == 0 {
+			if all {
+				ui.PrintStatus("info", "No lines in this window")
+			} else {
+				ui.PrintStatus("success", "No errors or warnings in this window")
+			}
+		}
+		for _, line := range w.Lines {
+			fmt.Println("  " + line)
+		}
+		ui.PrintSectionEnd()
+	}
+}
+
+// newLogsStatsCmd creates the logs stats subcommand
+func newLogsStatsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "stats",
+		Short: "Show log deduplication counters of the running daemon",
+		Long: `Show how many log lines the daemon exported and how many it dropped as
+duplicates since it started.
+
+A line is a duplicate when it is byte-for-byte identical to a line sent within
+log_dedup_window seconds (default 600). Docker lines include their timestamp,
+so for containers this only stops the same line from being resent when the log
+tail is re-read; identical messages logged at different times are all kept.
+With log_dedup_window: 0 there is no time limit, a line is not resent while it
+is still in the tail of its log.
+
+With log_rate_limit set, a container or service sends at most that many lines
+per collection; the newest are kept and a marker line reports the rest. Lines
+dropped this way are listed per source.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			ui.PrintHeader()
+			ui.PrintSection("Log Deduplication")
+
+			cfg, err := config.LoadConfig()
+			if err != nil {
+				cfg = config.DefaultConfig()
+			}
+			if cfg.LogDedupWindow > 0 {
+				ui.PrintStatus("info", fmt.Sprintf("Window: %v", time.Duration(cfg.LogDedupWindow)*time.Second))
+			} else {
+				ui.PrintStatus("info", "Window: none (lines are not resent while still in the log tail)")
+			}
+
+			stats, ok := loadLogStats()
+			if !ok {
+				ui.PrintSectionEnd()
+				return
+			}
+
+			ui.PrintStatus("info", fmt.Sprintf("Lines sent: %d", stats.Sent))
+			ui.PrintStatus("info", fmt.Sprintf("Lines deduplicated: %d", stats.Deduplicated))
+			ui.PrintStatus("info", fmt.Sprintf("Lines remembered: %d", stats.Tracked))
+			if stats.RateLimit > 0 {
+				ui.PrintStatus("info", fmt.Sprintf("Rate limit: %d lines per source and collection", stats.RateLimit))
+			}
+			sources := make([]string, 0, len(stats.Dropped))
+			for source := range stats.Dropped {
+				sources = append(sources, source)
+			}
+			sort.Strings(sources)
+			for _, source := range sources {
+				ui.PrintStatus("warning", fmt.Sprintf("Dropped from %s: %d lines (rate limit)", source, stats.Dropped[source]))
+			}
+			ui.PrintStatus("info", fmt.Sprintf("Last changed: %s (%s ago)", stats.UpdatedAt.Format("2006-01-02 15:04:05"), time.Since(stats.UpdatedAt).Round(time.Second)))
+			ui.PrintSectionEnd()
+		},
+	}
+}
+
+// newLogsSourcesCmd creates the logs sources subcommand
+func newLogsSourcesCmd() *cobra.Command {
+	sourcesCmd := &cobra.Command{
+		Use:   "sources",
+		Short: "List the log sources the daemon reads",
+		Long: `List the containers and services whose logs the daemon read in the last
+collections, with their type, log path or container ID and compose service.
+
+A source is disabled with 'catops logs sources disable <source>', where
+<source> is a container name or ID, a pm2 service name or a log file path.
+The setting is kept in log_sources_disabled and applies after a restart.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			ui.PrintHeader()
+			ui.PrintSection("Log Sources")
+
+			cfg, err := config.LoadConfig()
+			if err != nil {
+				cfg = config.DefaultConfig()
+			}
+
+			stats, ok := loadLogStats()
+			seen := make(map[string]bool)
+			if ok {
+				if len(stats.Sources) == 0 {
+					ui.PrintStatus("info", "No container or service logs found")
+				}
+				for _, source := range stats.Sources {
+					seen[source.Name] = true
+					seen[source.Path] = true
+
+					line := fmt.Sprintf("%-6s %s", source.Type, source.Name)
+					if source.Service != "" && source.Service != source.Name {
+						line += fmt.Sprintf(" (service %s)", source.Service)
+					}
+					if source.Path != "" && source.Path != source.Name {
+						line += fmt.Sprintf(" - %s", source.Path)
+					}
+					if source.Disabled {
+						ui.PrintStatus("warning", line+" [disabled]")
+					} else {
+						ui.PrintStatus("success", line)
+					}
+				}
+			}
+
+			for _, name := range cfg.LogSourcesDisabled {
+				if !seen[name] {
+					ui.PrintStatus("warning", fmt.Sprintf("%s [disabled, not seen]", name))
+				}
+			}
+			ui.PrintSectionEnd()
+		},
+	}
+
+	sourcesCmd.AddCommand(newLogsSourcesToggleCmd(true))
+	sourcesCmd.AddCommand(newLogsSourcesToggleCmd(false))
+
+	return sourcesCmd
+}
+
+// newLogsSourcesToggleCmd creates the logs sources disable or enable subcommand
+func newLogsSourcesToggleCmd(disable bool) *cobra.Command {
+	use, short := "enable <source>", "Collect a disabled log source again"
+	if disable {
+		use, short = "disable <source>", "Stop collecting logs from a source"
+	}
+
+	return &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ui.PrintHeader()
+			ui.PrintSection("Log Sources")
+
+			cfg, err := config.LoadConfig()
+			if err != nil {
+				printConfigError("Failed to load configuration", err)
+				ui.PrintSectionEnd()
+				os.Exit(1)
+			}
+
+			source := args[0]
+			index := slices.Index(cfg.LogSourcesDisabled, source)
+			switch {
+			case disable && index >= 0:
+				ui.PrintStatus("info", fmt.Sprintf("%s is already disabled", source))
+				ui.PrintSectionEnd()
+				return
+			case !disable && index < 0:
+				ui.PrintStatus("info", fmt.Sprintf("%s is not disabled", source))
+				ui.PrintSectionEnd()
+				return
+			case disable:
+				cfg.LogSourcesDisabled = append(cfg.LogSourcesDisabled, source)
+			default:
+				cfg.LogSourcesDisabled = slices.Delete(cfg.LogSourcesDisabled, index, index+1)
+			}
+
+			if err := config.SaveConfig(cfg); err != nil {
+				printConfigError("Failed to save config", err)
+				ui.PrintSectionEnd()
+				os.Exit(1)
+			}
+
+			if disable {
+				ui.PrintStatus("success", fmt.Sprintf("Disabled log collection from %s", source))
+			} else {
+				ui.PrintStatus("success", fmt.Sprintf("Enabled log collection from %s", source))
+			}
+			ui.PrintStatus("info", "Run 'catops restart' to apply")
+			ui.PrintSectionEnd()
+		},
+	}
+}
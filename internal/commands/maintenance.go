@@ -0,0 +1,149 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	constants "catops/config"
+	"catops/internal/config"
+	"catops/internal/ui"
+)
+
+// NewMaintenanceCmd creates the maintenance command with start/stop subcommands
+func NewMaintenanceCmd() *cobra.Command {
+	maintenanceCmd := &cobra.Command{
+		Use:   "maintenance",
+		Short: "Suppress alert notifications during planned work",
+		Long: `Manage the maintenance window. While it is active the daemon keeps
+collecting and exporting metrics but does not send alert notifications.
+The window expires on its own (at most 24h).
+
+Commands:
+  start    Start a maintenance window
+  stop     End the maintenance window
+
+Examples:
+  catops maintenance                      # Show maintenance status
+  catops maintenance start --duration 1h  # Silence alerts for an hour
+  catops maintenance stop                 # Resume alerts`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			ui.PrintHeader()
+			ui.PrintSection("Maintenance")
+
+			cfg, err := config.LoadConfig()
+			if err != nil {
+				printConfigError("Failed to load configuration", err)
+				ui.PrintSectionEnd()
+				return
+			}
+
+			if cfg.InMaintenance() {
+				until := time.Unix(cfg.MaintenanceUntil, 0)
+				ui.PrintStatus("warning", fmt.Sprintf("Maintenance active until %s (%s left)",
+					until.Format("2006-01-02 15:04:05"), time.Until(until).Round(time.Second)))
+			} else {
+				ui.PrintStatus("info", "No maintenance window active")
+			}
+			ui.PrintSectionEnd()
+		},
+	}
+
+	maintenanceCmd.AddCommand(newMaintenanceStartCmd())
+	maintenanceCmd.AddCommand(newMaintenanceStopCmd())
+
+	return maintenanceCmd
+}
+
+// newMaintenanceStartCmd creates the maintenance start subcommand
+func newMaintenanceStartCmd() *cobra.Command {
+	var duration time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "start",
+		Short: "Start a maintenance window",
+		Run: func(cmd *cobra.Command, args []string) {
+			startMaintenance(duration)
+		},
+	}
+
+	cmd.Flags().DurationVar(&duration, "duration", time.Duration(constants.DEFAULT_MAINTENANCE_DURATION)*time.Second, "How long to suppress alerts (e.g. 30m, 2h)")
+
+	return cmd
+}
+
+// startMaintenance saves a maintenance window ending duration from now
+func startMaintenance(duration time.Duration) {
+	ui.PrintHeader()
+	ui.PrintSection("Maintenance")
+
+	maxDuration := time.Duration(constants.MAX_MAINTENANCE_DURATION) * time.Second
+	if duration <= 0 || duration > maxDuration {
+		ui.PrintStatus("error", fmt.Sprintf("Duration must be between 1s and %v", maxDuration))
+		ui.PrintSectionEnd()
+		os.Exit(1)
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		printConfigError("Failed to load configuration", err)
+		ui.PrintSectionEnd()
+		return
+	}
+
+	until := time.Now().Add(duration)
+	cfg.MaintenanceUntil = until.Unix()
+	if err := config.SaveConfig(cfg); err != nil {
+		printConfigError("Failed to save config", err)
+		ui.PrintSectionEnd()
+		return
+	}
+
+	ui.PrintStatus("success", fmt.Sprintf("Maintenance active until %s", until.Format("2006-01-02 15:04:05")))
+	ui.PrintStatus("info", "Metrics are still collected and exported, alert notifications are suppressed")
+	ui.PrintStatus("info", "Run 'catops maintenance stop' to end it early")
+	ui.PrintSectionEnd()
+}
+
+// newMaintenanceStopCmd creates the maintenance stop subcommand
+func newMaintenanceStopCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "stop",
+		Short: "End the maintenance window",
+		Run: func(cmd *cobra.Command, args []string) {
+			stopMaintenance()
+		},
+	}
+}
+
+// stopMaintenance ends the current maintenance window
+func stopMaintenance() {
+	ui.PrintHeader()
+	ui.PrintSection("Maintenance")
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		printConfigError("Failed to load configuration", err)
+		ui.PrintSectionEnd()
+		return
+	}
+
+	if !cfg.InMaintenance() {
+		ui.PrintStatus("info", "No maintenance window active")
+		ui.PrintSectionEnd()
+		return
+	}
+
+	cfg.MaintenanceUntil = 0
+	if err := config.SaveConfig(cfg); err != nil {
+		printConfigError("Failed to save config", err)
+		ui.PrintSectionEnd()
+		return
+	}
+
+	ui.PrintStatus("success", "Maintenance ended, alerts resume on the next check")
+	ui.PrintSectionEnd()
+}
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+)
+
+// ansiEscape matches terminal color/style sequences, stripped from file output
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`)
+
+// writeOutput runs render with stdout captured and writes what it printed to path.
+// The file is written to a temp file and renamed, so a failed or interrupted run
+// never leaves a truncated report; if render fails the existing file is kept.
+func writeOutput(path string, render func() error) error {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return fmt.Errorf("failed to capture output: %w", err)
+	}
+
+	var buf bytes.Buffer
+	copied := make(chan struct{})
+	go func() {
+		io.Copy(&buf, r)
+		close(copied)
+	}()
+
+	stdout := os.Stdout
+	os.Stdout = w
+	renderErr := render()
+	os.Stdout = stdout
+	w.Close()
+	<-copied
+	r.Close()
+
+	if renderErr != nil {
+		return renderErr
+	}
+
+	tmpFile := path + ".tmp"
+	if err := os.WriteFile(tmpFile, ansiEscape.ReplaceAll(buf.Bytes(), nil), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Rename(tmpFile, path); err != nil {
+		os.Remove(tmpFile)
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
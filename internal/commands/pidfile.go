@@ -0,0 +1,187 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/process"
+
+	constants "catops/config"
+	"catops/internal/logger"
+)
+
+// acquirePIDFile records this daemon's PID in PID_FILE. It fails only when the
+// file belongs to another live catops daemon; a file left behind by a killed
+// daemon (or pointing at a reused PID) is removed and replaced.
+func acquirePIDFile() error {
+	if pid, ok := readPIDFile(); ok && pid != os.Getpid() {
+		if isDaemonProcess(pid) {
+			return fmt.Errorf("daemon already running (PID %d)", pid)
+		}
+		logger.Warning("Removing stale PID file %s (PID %d is not a catops daemon)", constants.PID_FILE, pid)
+		os.Remove(constants.PID_FILE)
+	}
+
+	return os.WriteFile(constants.PID_FILE, []byte(strconv.Itoa(os.Getpid())), 0644)
+}
+
+// releasePIDFile removes PID_FILE if it still holds this daemon's PID
+func releasePIDFile() {
+	if pid, ok := readPIDFile(); ok && pid == os.Getpid() {
+		os.Remove(constants.PID_FILE)
+	}
+}
+
+// readPIDFile returns the PID stored in PID_FILE
+func readPIDFile() (int, bool) {
+	data, err := os.ReadFile(constants.PID_FILE)
+	if err != nil {
+		return 0, false
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 {
+		return 0, false
+	}
+	return pid, true
+}
+
+// isDaemonProcess reports whether pid is a running "catops daemon" process.
+// The command line is checked, not just existence, since PIDs get reused.
+func isDaemonProcess(pid int) bool {
+	p, err := process.NewProcess(int32(pid))
+	if err != nil {
+		return false
+	}
+	args, err := p.CmdlineSlice()
+	if err != nil {
+		return false
+	}
+	return isDaemonCmdline(args)
+}
+
+// isDaemonCmdline reports whether args is a "catops daemon" command line
+func isDaemonCmdline(args []string) bool {
+	if len(args) < 2 {
+		return false
+	}
+	name := strings.TrimSuffix(filepath.Base(args[0]), ".exe")
+	return strings.HasPrefix(name, "catops") && args[1] == "daemon"
+}
+
+// daemonCandidate is a running process as seen by stopDaemonProcesses
+type daemonCandidate struct {
+	pid    int
+	args   []string // command line
+	exe    string   // executable path
+	exeErr error    // set when the executable could not be read
+}
+
+// shouldStopDaemon decides whether c is a daemon to stop. self is never
+// stopped (a daemon restarting itself is usually the PID in the PID file).
+// Otherwise the command line must be "catops daemon" and the executable an
+// installed catops binary; when the executable can't be read, only the PID
+// recorded in the PID file is trusted. skip tells why a process is left alone.
+func shouldStopDaemon(c daemonCandidate, self, pidFilePID int, binaries map[string]bool) (stop bool, skip string) {
+	switch {
+	case c.pid == self:
+		return false, "this process"
+	case !isDaemonCmdline(c.args):
+		return false, "not a catops daemon"
+	case c.exeErr != nil:
+		if c.pid != pidFilePID {
+			return false, fmt.Sprintf("executable not readable: %v", c.exeErr)
+		}
+		return true, ""
+	case !binaries[resolveBinary(strings.TrimSuffix(c.exe, " (deleted)"))]:
+		return false, fmt.Sprintf("%s is not an installed catops binary", c.exe)
+	}
+	return true, ""
+}
+
+// daemonStopGrace is how long a daemon gets to exit after SIGTERM before it is killed
+const daemonStopGrace = 5 * time.Second
+
+// stopDaemonProcesses terminates running catops daemons, except this process.
+// A process is only signaled when its command line is "catops daemon" and its
+// executable is an installed catops binary, so an unrelated process that reused
+// a PID or merely shares the name is never touched. Each daemon gets SIGTERM and
+// daemonStopGrace to shut down cleanly before SIGKILL. Returns how many were stopped.
+func stopDaemonProcesses() int {
+	procs, err := process.Processes()
+	if err != nil {
+		logger.Warning("Failed to list processes: %v", err)
+		return 0
+	}
+
+	binaries := installedBinaries()
+	pidFilePID, _ := readPIDFile()
+
+	var targets []*process.Process
+	for _, p := range procs {
+		pid := int(p.Pid)
+		args, err := p.CmdlineSlice()
+		if err != nil || pid == os.Getpid() || !isDaemonCmdline(args) {
+			continue
+		}
+
+		c := daemonCandidate{pid: pid, args: args}
+		// Not readable for another user's process without root
+		c.exe, c.exeErr = p.Exe()
+		if stop, skip := shouldStopDaemon(c, os.Getpid(), pidFilePID, binaries); !stop {
+			logger.Debug("Skipping PID %d: %s", pid, skip)
+			continue
+		}
+
+		if err := p.Terminate(); err != nil {
+			logger.Warning("Failed to stop daemon PID %d: %v", pid, err)
+			continue
+		}
+		targets = append(targets, p)
+	}
+
+	deadline := time.Now().Add(daemonStopGrace)
+	for _, p := range targets {
+		for time.Now().Before(deadline) {
+			if running, err := p.IsRunning(); err != nil || !running {
+				break
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+		if running, err := p.IsRunning(); err == nil && running {
+			logger.Warning("Daemon PID %d did not exit after %v, killing it", p.Pid, daemonStopGrace)
+			p.Kill()
+		}
+	}
+
+	return len(targets)
+}
+
+// installedBinaries returns the resolved paths of catops binaries that may run
+// the daemon: this executable plus the standard install locations
+func installedBinaries() map[string]bool {
+	paths := []string{"/usr/local/bin/catops", "/usr/bin/catops"}
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ".local", "bin", "catops"))
+	}
+	if self, err := os.Executable(); err == nil {
+		paths = append(paths, self)
+	}
+
+	binaries := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		binaries[resolveBinary(path)] = true
+	}
+	return binaries
+}
+
+// resolveBinary returns path with symlinks resolved (path itself when it can't be)
+func resolveBinary(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	return path
+}
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsDaemonCmdline(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{[]string{"/usr/local/bin/catops", "daemon"}, true},
+		{[]string{"catops", "daemon", "--interval", "10"}, true},
+		{[]string{"catops.exe", "daemon"}, true},
+		{[]string{"/usr/local/bin/catops", "status"}, false},
+		{[]string{"/usr/local/bin/catops"}, false},
+		{[]string{"/usr/bin/python3", "daemon"}, false},
+		{nil, false},
+	}
+
+	for _, tt := range tests {
+		if got := isDaemonCmdline(tt.args); got != tt.want {
+			t.Errorf("isDaemonCmdline(%q) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestShouldStopDaemon(t *testing.T) {
+	dir := t.TempDir()
+	installed := filepath.Join(dir, "catops")
+	if err := os.WriteFile(installed, []byte("binary"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(dir, "catops-link")
+	if err := os.Symlink(installed, link); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	binaries := map[string]bool{resolveBinary(installed): true}
+
+	const self, pidFilePID, other = 100, 200, 300
+	daemon := []string{installed, "daemon"}
+	unreadable := errors.New("permission denied")
+
+	tests := []struct {
+		name string
+		c    daemonCandidate
+		want bool
+	}{
+		{"self restarting from the PID file", daemonCandidate{pid: self, args: daemon, exe: installed}, false},
+		{"PID file daemon", daemonCandidate{pid: pidFilePID, args: daemon, exe: installed}, true},
+		{"PID file daemon, executable not readable", daemonCandidate{pid: pidFilePID, args: daemon, exeErr: unreadable}, true},
+		{"PID file PID reused by another program", daemonCandidate{pid: pidFilePID, args: []string{"nginx", "-g", "daemon off;"}, exe: "/usr/sbin/nginx"}, false},
+		{"PID file PID reused, executable not readable", daemonCandidate{pid: pidFilePID, args: []string{"postgres"}, exeErr: unreadable}, false},
+		{"other daemon of the installed binary", daemonCandidate{pid: other, args: daemon, exe: installed}, true},
+		{"other daemon started through a symlink", daemonCandidate{pid: other, args: daemon, exe: link}, true},
+		{"other daemon whose binary was replaced by an update", daemonCandidate{pid: other, args: daemon, exe: installed + " (deleted)"}, true},
+		{"other daemon, executable not readable", daemonCandidate{pid: other, args: daemon, exeErr: unreadable}, false},
+		{"same name, foreign binary", daemonCandidate{pid: other, args: daemon, exe: "/opt/other/catops"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stop, skip := shouldStopDaemon(tt.c, self, pidFilePID, binaries)
+			if stop != tt.want {
+				t.Errorf("shouldStopDaemon() = %v (%s), want %v", stop, skip, tt.want)
+			}
+			if !stop && skip == "" {
+				t.Error("shouldStopDaemon() skipped a process without a reason")
+			}
+		})
+	}
+}
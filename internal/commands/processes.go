@@ -2,7 +2,10 @@ package commands
 
 import (
 	"fmt"
+	"os"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -18,71 +21,143 @@ func NewProcessesCmd() *cobra.Command {
 		Long: `Display detailed information about system processes including:
   • Top processes by CPU usage
   • Top processes by memory usage
+  • Top processes by disk IO rate (--sort io)
   • Process details (PID, user, command, resource usage)
 
+With --sort a single table is shown instead, ordered by one key:
+  cpu, mem (or memory), rss, io   highest first
+  pid, name                       ascending
+--limit applies after sorting.
+
 Examples:
   catops processes        # Show all process information
-  catops processes -n 20 # Show top 20 processes`,
+  catops processes -n 20 # Show top 20 processes
+  catops processes --sort io  # Show the processes reading and writing the most
+  catops processes --sort rss -n 5  # The five largest resident sets
+  catops processes --sort name      # Tracked processes alphabetically
+  catops processes -o /var/log/catops-processes.txt  # Write to a file`,
 		Run: func(cmd *cobra.Command, args []string) {
-			ui.PrintHeader()
-			ui.PrintSection("Process Information")
-
-			// get metrics with process information
-			currentMetrics, err := metrics.GetMetrics()
-			if err != nil {
-				ui.PrintStatus("error", fmt.Sprintf("Error getting metrics: %v", err))
-				ui.PrintSectionEnd()
-				return
-			}
-
-			// get limit from flags
 			limit, _ := cmd.Flags().GetInt("limit")
+			output, _ := cmd.Flags().GetString("output")
+			sortBy, _ := cmd.Flags().GetString("sort")
 
-			// show top processes by CPU
-			ui.PrintSection("Top Processes by CPU Usage")
-			if len(currentMetrics.TopProcesses) > 0 {
-				// sort by CPU usage
-				sortedProcesses := make([]metrics.ProcessInfo, len(currentMetrics.TopProcesses))
-				copy(sortedProcesses, currentMetrics.TopProcesses)
-				sort.Slice(sortedProcesses, func(i, j int) bool {
-					return sortedProcesses[i].CPUUsage > sortedProcesses[j].CPUUsage
-				})
-
-				// show top N processes
-				if limit < len(sortedProcesses) {
-					sortedProcesses = sortedProcesses[:limit]
-				}
+			if _, ok := processSorts[sortBy]; sortBy != "" && sortBy != "io" && !ok {
+				ui.PrintStatus("error", fmt.Sprintf("Unknown sort %q (use cpu, mem, rss, pid, name or io)", sortBy))
+				os.Exit(1)
+			}
 
-				fmt.Print(ui.CreateProcessTable(sortedProcesses))
-			} else {
-				ui.PrintStatus("warning", "No process information available")
+			render := func() error {
+				return printProcesses(limit, sortBy)
 			}
-			ui.PrintTableSectionEnd()
-
-			// show top processes by memory
-			ui.PrintSection("Top Processes by Memory Usage")
-			if len(currentMetrics.TopProcesses) > 0 {
-				// sort by memory usage
-				sortedProcesses := make([]metrics.ProcessInfo, len(currentMetrics.TopProcesses))
-				copy(sortedProcesses, currentMetrics.TopProcesses)
-				sort.Slice(sortedProcesses, func(i, j int) bool {
-					return sortedProcesses[i].MemoryUsage > sortedProcesses[j].MemoryUsage
-				})
-
-				// show top N processes
-				if limit < len(sortedProcesses) {
-					sortedProcesses = sortedProcesses[:limit]
-				}
 
-				fmt.Print(ui.CreateProcessTableByMemory(sortedProcesses))
-			} else {
-				ui.PrintStatus("warning", "No process information available")
+			if output != "" {
+				if err := writeOutput(output, render); err != nil {
+					ui.PrintStatus("error", err.Error())
+					os.Exit(1)
+				}
+			} else if err := render(); err != nil {
+				ui.PrintStatus("error", err.Error())
+				ui.PrintSectionEnd()
 			}
-			ui.PrintTableSectionEnd()
 		},
 	}
 
 	cmd.Flags().IntP("limit", "n", 10, "Number of processes to show")
+	cmd.Flags().StringP("output", "o", "", "Write the output to a file (atomically) instead of stdout")
+	cmd.Flags().String("sort", "", "Show a single table sorted by cpu, mem, rss, pid, name or io (default: cpu and memory tables)")
 
 	return cmd
 }
+
+// processSort orders processes for --sort and names the table column it marks
+type processSort struct {
+	column    string
+	ascending bool
+	less      func(a, b *metrics.ProcessInfo) bool
+}
+
+// processSorts are the --sort keys rendered with the standard process table
+// (io has its own table with read and write rates)
+var processSorts = map[string]processSort{
+	"cpu":    {column: "CPU%", less: func(a, b *metrics.ProcessInfo) bool { return a.CPUUsage > b.CPUUsage }},
+	"mem":    {column: "MEM%", less: func(a, b *metrics.ProcessInfo) bool { return a.MemoryUsage > b.MemoryUsage }},
+	"memory": {column: "MEM%", less: func(a, b *metrics.ProcessInfo) bool { return a.MemoryUsage > b.MemoryUsage }},
+	"rss":    {column: "MEMORY", less: func(a, b *metrics.ProcessInfo) bool { return a.MemoryKB > b.MemoryKB }},
+	"pid":    {column: "PID", ascending: true, less: func(a, b *metrics.ProcessInfo) bool { return a.PID < b.PID }},
+	"name": {column: "COMMAND", ascending: true, less: func(a, b *metrics.ProcessInfo) bool {
+		if an, bn := strings.ToLower(a.Command), strings.ToLower(b.Command); an != bn {
+			return an < bn
+		}
+		return a.PID < b.PID
+	}},
+}
+
+// ioSampleInterval is the wait between the two collections IO rates are computed from
+const ioSampleInterval = time.Second
+
+// printProcesses renders the top processes by CPU and by memory, or a single
+// table ordered by sortBy (see processSorts, or io)
+func printProcesses(limit int, sortBy string) error {
+	ui.PrintHeader()
+	ui.PrintSection("Process Information")
+
+	// IO rates are deltas, the first collection only sets the baseline
+	if sortBy == "io" {
+		if _, err := metrics.GetMetrics(); err != nil {
+			return fmt.Errorf("error getting metrics: %w", err)
+		}
+		time.Sleep(ioSampleInterval)
+	}
+
+	// get metrics with process information
+	currentMetrics, err := metrics.GetMetrics()
+	if err != nil {
+		return fmt.Errorf("error getting metrics: %w", err)
+	}
+
+	if len(currentMetrics.TopProcesses) == 0 {
+		ui.PrintStatus("warning", "No process information available")
+		ui.PrintSectionEnd()
+		return nil
+	}
+
+	if sortBy == "io" {
+		ui.PrintSection("Top Processes by Disk IO")
+		fmt.Print(ui.CreateProcessTableByIO(sortProcesses(currentMetrics.TopProcesses, limit, func(a, b *metrics.ProcessInfo) bool {
+			return a.IOReadRate+a.IOWriteRate > b.IOReadRate+b.IOWriteRate
+		})))
+		ui.PrintTableSectionEnd()
+		return nil
+	}
+
+	if order, ok := processSorts[sortBy]; ok {
+		ui.PrintSection("Processes by " + sortBy)
+		fmt.Print(ui.CreateProcessTableSortedBy(sortProcesses(currentMetrics.TopProcesses, limit, order.less),
+			order.column, order.ascending))
+		ui.PrintTableSectionEnd()
+		return nil
+	}
+
+	// show top processes by CPU and by memory
+	ui.PrintSection("Top Processes by CPU Usage")
+	fmt.Print(ui.CreateProcessTable(sortProcesses(currentMetrics.TopProcesses, limit, processSorts["cpu"].less)))
+	ui.PrintTableSectionEnd()
+
+	ui.PrintSection("Top Processes by Memory Usage")
+	fmt.Print(ui.CreateProcessTableByMemory(sortProcesses(currentMetrics.TopProcesses, limit, processSorts["mem"].less)))
+	ui.PrintTableSectionEnd()
+	return nil
+}
+
+// sortProcesses returns a sorted copy of processes cut to the first limit
+func sortProcesses(processes []metrics.ProcessInfo, limit int, less func(a, b *metrics.ProcessInfo) bool) []metrics.ProcessInfo {
+	sorted := make([]metrics.ProcessInfo, len(processes))
+	copy(sorted, processes)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return less(&sorted[i], &sorted[j])
+	})
+	if limit < len(sorted) {
+		sorted = sorted[:limit]
+	}
+	return sorted
+}
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/spf13/cobra"
 
@@ -20,6 +21,9 @@ func NewRestartCmd() *cobra.Command {
 This ensures the monitoring service uses the latest configuration.
 The Telegram bot will also be restarted if configured.
 
+The configuration is validated first; if it is invalid the running
+service is left untouched so monitoring doesn't go down.
+
 Examples:
   catops restart         # Restart monitoring with current config`,
 		Run: func(cmd *cobra.Command, args []string) {
@@ -29,52 +33,66 @@ Examples:
 			// Load configuration
 			cfg, err := config.LoadConfig()
 			if err != nil {
-				ui.PrintErrorWithSupport(fmt.Sprintf("Failed to load config: %v", err))
+				printConfigError("Failed to load config", err)
 				ui.PrintSectionEnd()
 				return
 			}
 
-			svc, err := service.New()
-			if err != nil {
-				ui.PrintErrorWithSupport(fmt.Sprintf("Failed to create service: %v", err))
+			if !restartService(cfg) {
 				ui.PrintSectionEnd()
-				return
+				os.Exit(1)
 			}
+			ui.PrintSectionEnd()
+		},
+	}
+}
+
+// restartService stops and starts the monitoring service, installing it if needed.
+// The configuration is validated first and the running service left alone when
+// it is invalid. Returns true when the service was started.
+func restartService(cfg *config.Config) bool {
+	// Validate before stopping, a daemon that can't start would leave monitoring down
+	if !checkConfig(cfg) {
+		ui.PrintStatus("error", "Invalid configuration, the running service was left untouched")
+		ui.PrintStatus("info", "Fix the settings above and run 'catops restart' again")
+		return false
+	}
 
-			// Stop current service
-			svc.Stop()
-			ui.PrintStatus("info", "Service stopped")
+	svc, err := service.New()
+	if err != nil {
+		ui.PrintErrorWithSupport(fmt.Sprintf("Failed to create service: %v", err))
+		return false
+	}
 
-			// Start service
-			status, err := svc.Start()
-			if err != nil {
-				// Service might not be installed, try installing it first
-				ui.PrintStatus("warning", "Service not installed, installing...")
-				installStatus, installErr := svc.Install()
-				if installErr != nil {
-					ui.PrintErrorWithSupport(fmt.Sprintf("Failed to install service: %v", installErr))
-					ui.PrintSectionEnd()
-					return
-				}
-				ui.PrintStatus("success", installStatus)
+	// Stop current service
+	svc.Stop()
+	ui.PrintStatus("info", "Service stopped")
 
-				// Now try starting again
-				status, err = svc.Start()
-				if err != nil {
-					ui.PrintErrorWithSupport(fmt.Sprintf("Failed to start: %v", err))
-					ui.PrintSectionEnd()
-					return
-				}
-			}
+	// Start service
+	status, err := svc.Start()
+	if err != nil {
+		// Service might not be installed, try installing it first
+		ui.PrintStatus("warning", "Service not installed, installing...")
+		installStatus, installErr := svc.Install()
+		if installErr != nil {
+			ui.PrintErrorWithSupport(fmt.Sprintf("Failed to install service: %v", installErr))
+			return false
+		}
+		ui.PrintStatus("success", installStatus)
 
-			ui.PrintStatus("success", status)
+		// Now try starting again
+		status, err = svc.Start()
+		if err != nil {
+			ui.PrintErrorWithSupport(fmt.Sprintf("Failed to start: %v", err))
+			return false
+		}
+	}
 
-			// Send service_restart event
-			if cfg.AuthToken != "" && cfg.ServerID != "" {
-				analytics.NewSender(cfg, GetCurrentVersion()).SendEvent("service_restart")
-			}
+	ui.PrintStatus("success", status)
 
-			ui.PrintSectionEnd()
-		},
+	// Send service_restart event
+	if cfg.AuthToken != "" && cfg.ServerID != "" {
+		analytics.NewSender(cfg, GetCurrentVersion()).SendEvent("service_restart")
 	}
+	return true
 }
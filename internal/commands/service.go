@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -25,6 +26,7 @@ Examples:
   catops service start     # Start the service
   catops service stop      # Stop the service
   catops service status    # Check service status
+  catops service repair    # Point the service at the current binary again
   catops service remove    # Remove the service`,
 	}
 
@@ -34,6 +36,7 @@ Examples:
 	cmd.AddCommand(newServiceStopCmd())
 	cmd.AddCommand(newServiceStatusCmd())
 	cmd.AddCommand(newServiceRestartCmd())
+	cmd.AddCommand(newServiceRepairCmd())
 
 	return cmd
 }
@@ -221,3 +224,46 @@ func newServiceRestartCmd() *cobra.Command {
 		},
 	}
 }
+
+func newServiceRepairCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "repair",
+		Short: "Regenerate the service definition for the current binary",
+		Long: `Regenerate the service definition (systemd unit, launchd plist or
+Scheduled Task) so it starts the binary at its current location, reload it
+and re-enable it. A running service is restarted.
+
+Only an installed service is repaired. Use this when the binary was moved
+or updated and the service still points at the old path.
+
+Examples:
+  catops service repair`,
+		Run: func(cmd *cobra.Command, args []string) {
+			ui.PrintHeader()
+			ui.PrintSection("Repairing Service")
+
+			svc, err := service.New()
+			if err != nil {
+				ui.PrintErrorWithSupport(fmt.Sprintf("Failed to create service: %v", err))
+				ui.PrintSectionEnd()
+				os.Exit(1)
+			}
+
+			status, err := svc.Repair()
+			if errors.Is(err, service.ErrNotInstalled) {
+				ui.PrintStatus("info", "Service is not installed, nothing to repair")
+				ui.PrintStatus("info", "Run 'catops service install' to enable autostart")
+				ui.PrintSectionEnd()
+				return
+			}
+			if err != nil {
+				ui.PrintErrorWithSupport(fmt.Sprintf("Failed to repair: %v", err))
+				ui.PrintSectionEnd()
+				os.Exit(1)
+			}
+
+			ui.PrintStatus("success", status)
+			ui.PrintSectionEnd()
+		},
+	}
+}
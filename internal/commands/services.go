@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"catops/internal/config"
+	"catops/internal/metrics"
+	"catops/internal/ui"
+)
+
+// NewServicesCmd creates the services command
+func NewServicesCmd() *cobra.Command {
+	var serviceType string
+
+	cmd := &cobra.Command{
+		Use:   "services",
+		Short: "List detected services with their health",
+		Long: `Display services detected on this server including:
+  • Service name and type (nginx, redis, postgres, node_app, ...)
+  • PIDs and listening ports
+  • CPU and memory usage
+  • Health status
+
+Commands:
+  check    Verify that required_services are running (exit code 1 if not)
+
+Examples:
+  catops services                # Show all detected services
+  catops services --type redis   # Show only redis services
+  catops services check nginx    # Fail unless nginx is running`,
+		Run: func(cmd *cobra.Command, args []string) {
+			ui.PrintHeader()
+			ui.PrintSection("Detected Services")
+
+			services, err := metrics.GetServices()
+			if err != nil {
+				ui.PrintStatus("error", fmt.Sprintf("Error detecting services: %v", err))
+				ui.PrintSectionEnd()
+				return
+			}
+
+			if serviceType != "" {
+				var filtered []metrics.ServiceInfo
+				for _, svc := range services {
+					if strings.EqualFold(string(svc.ServiceType), serviceType) {
+						filtered = append(filtered, svc)
+					}
+				}
+				services = filtered
+			}
+
+			sort.Slice(services, func(i, j int) bool {
+				if services[i].ServiceType != services[j].ServiceType {
+					return services[i].ServiceType < services[j].ServiceType
+				}
+				return services[i].ServiceName < services[j].ServiceName
+			})
+
+			fmt.Print(ui.CreateServiceTable(services))
+			ui.PrintTableSectionEnd()
+
+			if len(services) == 0 && serviceType != "" {
+				ui.PrintStatus("info", fmt.Sprintf("No services of type '%s' detected", serviceType))
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&serviceType, "type", "t", "", "Only show services of this type (e.g. nginx, redis, node_app)")
+
+	cmd.AddCommand(newServicesCheckCmd())
+
+	return cmd
+}
+
+// newServicesCheckCmd creates the services check subcommand
+func newServicesCheckCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "check [service...]",
+		Short: "Verify that required services are running",
+		Long: `Check once that every service in required_services (or the names given as
+arguments) is detected on this server, matching service name, type or container
+name. Exits with status 1 when one is missing, for CI and health probes.
+
+Examples:
+  catops services check                  # Check required_services from the config
+  catops services check nginx postgres   # Check these instead
+  catops -q services check               # Plain output for scripts`,
+		Run: func(cmd *cobra.Command, args []string) {
+			ui.PrintHeader()
+			ui.PrintSection("Required Services")
+
+			required := args
+			if len(required) == 0 {
+				cfg, err := config.LoadConfig()
+				if err != nil {
+					cfg = config.DefaultConfig()
+				}
+				required = cfg.RequiredServices
+			}
+			if len(required) == 0 {
+				ui.PrintStatus("info", "No required services configured")
+				ui.PrintStatus("info", "Add required_services to ~/.catops/config.yaml or pass names: catops services check nginx")
+				ui.PrintSectionEnd()
+				return
+			}
+
+			services, err := metrics.GetServices()
+			if err != nil {
+				ui.PrintStatus("error", fmt.Sprintf("Error detecting services: %v", err))
+				ui.PrintSectionEnd()
+				os.Exit(1)
+			}
+
+			missing := metrics.MissingServices(services, required)
+			for _, name := range required {
+				if slices.Contains(missing, name) {
+					ui.PrintStatus("error", fmt.Sprintf("%s: not running", name))
+				} else {
+					ui.PrintStatus("success", fmt.Sprintf("%s: running", name))
+				}
+			}
+			ui.PrintSectionEnd()
+
+			if len(missing) > 0 {
+				os.Exit(1)
+			}
+		},
+	}
+}
@@ -2,58 +2,165 @@ package commands
 
 import (
 	"fmt"
+	"math"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"catops/internal/alerts"
 	"catops/internal/analytics"
 	"catops/internal/config"
+	"catops/internal/service"
 	"catops/internal/ui"
 	"catops/pkg/utils"
 )
 
 // NewSetCmd creates the set command
 func NewSetCmd() *cobra.Command {
-	return &cobra.Command{
+	var apply, dryRun, explain bool
+
+	cmd := &cobra.Command{
 		Use:   "set",
 		Short: "Configure monitoring settings",
 		Long: `Set monitoring configuration options.
-After changing settings, run 'catops restart' to apply changes to the running service.
+After changing settings, run 'catops restart' (or pass --apply) to apply changes
+to the running service.
 
 Supported settings:
   • interval     - Metrics collection interval in seconds (10-300)
+  • export_interval - OTLP export interval in seconds (10-300, 0 = same as interval)
+  • alert_interval  - Local alert check interval in seconds (10-3600)
+  • http_timeout    - Backend API request timeout in seconds (1-300, default 10)
+  • upload_timeout  - OTLP export timeout in seconds (5-600, default 30)
+  • log_dedup_window - Seconds a sent log line is remembered so it isn't resent (0 = while in the log tail, default 600)
+  • log_rate_limit - Log lines one container or service may send per collection (0 = unlimited)
+  • iops         - Per-device IOPS alert threshold in ops/s (0 disables)
+  • throughput   - Per-device throughput alert threshold in MB/s, or with a unit like 1GB (0 disables)
+  • fd           - Process open file descriptors alert, % of its nofile limit, e.g. 80% (0 disables)
+  • process_io   - Per-process disk read+write alert threshold in MB/s, or with a unit like 500MB (0 disables)
+  • net_rate     - Per-interface network rate alert: MB/s (or a unit like 100MB/s), or % of link speed like 80% (0 disables)
+  • mem_available_min - Alert when available memory drops below this, in MB or with a unit like 4GB (0 disables)
+  • temperature  - Alert when a hardware temperature sensor exceeds this many °C, and on CPU
+                   thermal throttling (0 disables; most VMs have no sensors)
+  • sustained_duration - Seconds (or 5m) a local alert condition must last before it fires (0 = immediately)
+  • alert_warmup - Seconds (or 2m) after start without local alerts (0 = one collection interval)
+  • export_stale_after - Seconds (or 15m) without a successful metrics export before a local alert (0 = default)
+  • min_free_disk - Free space catops leaves when writing its buffers, history and snapshots, in MB or like 1GB (0 disables)
+  • alert_trend  - Add a sparkline of recent values and rising/falling to local alerts (on/off)
+  • iops.crit, throughput.crit, fd.crit - Critical tier for the alerts above; critical alerts
+                   are sent even while the warning is already active (iops.warn etc. set the warning tier)
+  • analytics    - Send service events, server info and update checks (on/off)
+  • otlp         - OTLP metrics export (on/off)
+  • config_integrity - Sign config.yaml and warn on out-of-band edits (on/off)
+  • display_name - Name shown on dashboards instead of the hostname (empty resets)
+  • labels       - Server labels as key=value pairs, comma separated (empty clears)
+  • environment  - Deployment environment attached to metrics, logs and events, e.g. prod (empty clears)
+  • region       - Region attached to metrics, logs and events, e.g. eu-west-1 (empty clears)
 
 Examples:
-  catops set interval=30         # Collect metrics every 30 seconds`,
+  catops set interval=30         # Collect metrics every 30 seconds
+  catops set iops=5000           # Alert when a disk exceeds 5000 ops/s
+  catops set fd.warn=80 fd.crit=95  # Warning at 80%, critical at 95%
+  catops set analytics=off       # Disable all outbound analytics
+  catops set fd=80 --apply       # Save and restart the daemon to apply
+  catops set iops=5000 --dry-run # Validate and preview without saving
+  catops set --explain           # Describe what the current thresholds trigger
+  catops set display_name=web-01 labels=team=payments
+  catops set environment=prod region=eu-west-1`,
 		Run: func(cmd *cobra.Command, args []string) {
 			ui.PrintHeader()
+			if len(args) == 0 && explain {
+				cfg, err := config.LoadConfig()
+				if err != nil {
+					cfg = config.DefaultConfig()
+				}
+				explainThresholds(cfg)
+				return
+			}
 			ui.PrintSection("Configuring Monitoring Settings")
 
 			// Load configuration
 			cfg, err := config.LoadConfig()
 			if err != nil {
-				ui.PrintStatus("error", "Failed to load configuration")
-				ui.PrintStatus("info", "Using default values")
-				cfg = &config.Config{}
+				// Saving defaults over an unreadable file would lose the token
+				printConfigError("Failed to load configuration", err)
+				ui.PrintSectionEnd()
+				return
 			}
-
 			if len(args) == 0 {
 				ui.PrintStatus("error", "Usage: catops set interval=30")
-				ui.PrintStatus("info", "Supported: interval")
+				ui.PrintStatus("info", "Supported: interval, export_interval, alert_interval, http_timeout, upload_timeout, log_dedup_window, log_rate_limit, iops, throughput, fd, iops.crit, throughput.crit, fd.crit, process_io, net_rate, mem_available_min, temperature, sustained_duration, alert_warmup, export_stale_after, min_free_disk, alert_trend, analytics, otlp, config_integrity, display_name, labels, environment, region")
 				ui.PrintSectionEnd()
 				return
 			}
 
+			// Snapshot for the --dry-run before/after preview
+			before := *cfg
+
 			// parse arguments and update config
 			for _, arg := range args {
-				parts := strings.Split(arg, "=")
+				parts := strings.SplitN(arg, "=", 2)
 				if len(parts) != 2 {
 					ui.PrintStatus("error", fmt.Sprintf("Invalid format: %s", arg))
 					continue
 				}
 
 				metric := parts[0]
-				value, err := utils.ParseFloat(parts[1])
+				metric = strings.TrimSuffix(metric, ".warn") // iops.warn is the same as iops
+
+				// Server identity takes free-form values
+				switch metric {
+				case "display_name":
+					cfg.DisplayName = strings.TrimSpace(parts[1])
+					ui.PrintStatus("success", fmt.Sprintf("Set display name to %s", cfg.DisplayHostname()))
+					continue
+				case "labels":
+					labels, err := parseLabels(parts[1])
+					if err != nil {
+						ui.PrintStatus("error", fmt.Sprintf("Invalid labels: %v", err))
+						continue
+					}
+					cfg.ServerLabels = labels
+					ui.PrintStatus("success", fmt.Sprintf("Set %d server label(s)", len(labels)))
+					continue
+				case "environment", "region":
+					value := strings.TrimSpace(parts[1])
+					if len(value) > 64 {
+						ui.PrintStatus("error", fmt.Sprintf("Invalid %s: at most 64 characters", metric))
+						continue
+					}
+					if metric == "environment" {
+						cfg.Environment = value
+					} else {
+						cfg.Region = value
+					}
+					if value == "" {
+						ui.PrintStatus("success", fmt.Sprintf("Cleared %s", metric))
+					} else {
+						ui.PrintStatus("success", fmt.Sprintf("Set %s to %s", metric, value))
+					}
+					continue
+				}
+
+				// Feature toggles take on/off values
+				if toggle := featureToggle(cfg, metric); toggle != nil {
+					enabled, ok := parseToggle(parts[1])
+					if !ok {
+						ui.PrintStatus("error", fmt.Sprintf("Invalid value for %s: %s (use on/off)", metric, parts[1]))
+						continue
+					}
+					*toggle = enabled
+					state := "disabled"
+					if enabled {
+						state = "enabled"
+					}
+					ui.PrintStatus("success", fmt.Sprintf("Set %s to %s", metric, state))
+					continue
+				}
+
+				value, err := parseSettingValue(metric, parts[1])
 				if err != nil {
 					ui.PrintStatus("error", fmt.Sprintf("Invalid value for %s: %s", metric, parts[1]))
 					continue
@@ -67,24 +174,215 @@ Examples:
 					}
 					cfg.CollectionInterval = int(value)
 					ui.PrintStatus("success", fmt.Sprintf("Set collection interval to %d seconds", int(value)))
+				case "export_interval":
+					if value != 0 && (value < 10 || value > 300) {
+						ui.PrintStatus("error", "Export interval must be 0 or between 10 and 300 seconds")
+						continue
+					}
+					cfg.OTLPExportInterval = int(value)
+					ui.PrintStatus("success", fmt.Sprintf("Set OTLP export interval to %d seconds", int(value)))
+				case "alert_interval":
+					if value < 10 || value > 3600 {
+						ui.PrintStatus("error", "Alert check interval must be between 10 and 3600 seconds")
+						continue
+					}
+					cfg.AlertCheckInterval = int(value)
+					ui.PrintStatus("success", fmt.Sprintf("Set alert check interval to %d seconds", int(value)))
+				case "http_timeout":
+					if value < 1 || value > 300 {
+						ui.PrintStatus("error", "HTTP timeout must be between 1 and 300 seconds")
+						continue
+					}
+					cfg.HTTPTimeout = int(value)
+					ui.PrintStatus("success", fmt.Sprintf("Set backend request timeout to %d seconds", int(value)))
+				case "upload_timeout":
+					if value < 5 || value > 600 {
+						ui.PrintStatus("error", "Upload timeout must be between 5 and 600 seconds")
+						continue
+					}
+					cfg.UploadTimeout = int(value)
+					ui.PrintStatus("success", fmt.Sprintf("Set OTLP export timeout to %d seconds", int(value)))
+				case "log_dedup_window":
+					if value < 0 || value > 86400 {
+						ui.PrintStatus("error", "Log dedup window must be between 0 (disabled) and 86400 seconds")
+						continue
+					}
+					cfg.LogDedupWindow = int(value)
+					ui.PrintStatus("success", fmt.Sprintf("Set log dedup window to %d seconds", int(value)))
+				case "log_rate_limit":
+					if value < 0 {
+						ui.PrintStatus("error", "Log rate limit must be 0 (unlimited) or positive")
+						continue
+					}
+					cfg.LogRateLimit = int(value)
+					ui.PrintStatus("success", fmt.Sprintf("Set log rate limit to %d lines per source and collection", int(value)))
+				case "iops":
+					if value < 0 {
+						ui.PrintStatus("error", "IOPS threshold must be 0 (disabled) or positive")
+						continue
+					}
+					cfg.IOPSThreshold = int(value)
+					ui.PrintStatus("success", fmt.Sprintf("Set IOPS alert threshold to %d ops/s", int(value)))
+				case "throughput":
+					if value < 0 {
+						ui.PrintStatus("error", "Throughput threshold must be 0 (disabled) or positive")
+						continue
+					}
+					cfg.ThroughputThreshold = value
+					ui.PrintStatus("success", fmt.Sprintf("Set throughput alert threshold to %g MB/s", value))
+				case "fd":
+					if value < 0 || value > 100 {
+						ui.PrintStatus("error", "FD threshold must be between 0 (disabled) and 100 percent")
+						continue
+					}
+					cfg.FDThreshold = value
+					ui.PrintStatus("success", fmt.Sprintf("Set file descriptor alert threshold to %g%% of the limit", value))
+				case "process_io":
+					if value < 0 {
+						ui.PrintStatus("error", "Process IO threshold must be 0 (disabled) or positive")
+						continue
+					}
+					cfg.ProcessIOThreshold = value
+					ui.PrintStatus("success", fmt.Sprintf("Set process disk IO alert threshold to %g MB/s", value))
+				case "mem_available_min":
+					if value < 0 {
+						ui.PrintStatus("error", "Available memory floor must be 0 (disabled) or positive")
+						continue
+					}
+					cfg.MemAvailableMin = value
+					ui.PrintStatus("success", fmt.Sprintf("Set available memory alert floor to %s", utils.FormatBytes(int64(value*1024*1024))))
+				case "temperature":
+					if value < 0 || value > 150 {
+						ui.PrintStatus("error", "Temperature threshold must be between 0 (disabled) and 150 °C")
+						continue
+					}
+					cfg.TemperatureThreshold = value
+					ui.PrintStatus("success", fmt.Sprintf("Set temperature alert threshold to %g °C", value))
+				case "net_rate":
+					if value < 0 {
+						ui.PrintStatus("error", "Network rate threshold must be 0 (disabled) or positive")
+						continue
+					}
+					if strings.HasSuffix(strings.TrimSpace(parts[1]), "%") {
+						if value > 100 {
+							ui.PrintStatus("error", "Network rate percentage must be between 0 and 100")
+							continue
+						}
+						cfg.NetRatePercent = value
+						ui.PrintStatus("success", fmt.Sprintf("Set network rate alert threshold to %g%% of link speed", value))
+					} else {
+						cfg.NetRateThreshold = value
+						ui.PrintStatus("success", fmt.Sprintf("Set network rate alert threshold to %g MB/s", value))
+					}
+				case "sustained_duration":
+					if value < 0 || value > 86400 {
+						ui.PrintStatus("error", "Sustained duration must be between 0 (alert immediately) and 86400 seconds")
+						continue
+					}
+					cfg.SustainedDuration = int(value)
+					ui.PrintStatus("success", fmt.Sprintf("Set sustained duration to %v", time.Duration(cfg.SustainedDuration)*time.Second))
+				case "alert_warmup":
+					if value < 0 || value > 3600 {
+						ui.PrintStatus("error", "Alert warm-up must be between 0 (one collection interval) and 3600 seconds")
+						continue
+					}
+					cfg.AlertWarmUp = int(value)
+					ui.PrintStatus("success", fmt.Sprintf("Set alert warm-up to %v", cfg.AlertWarmUpPeriod()))
+				case "min_free_disk":
+					if value < 0 {
+						ui.PrintStatus("error", "Minimum free disk must be 0 (no check) or positive")
+						continue
+					}
+					cfg.MinFreeDisk = int(math.Ceil(value))
+					ui.PrintStatus("success", fmt.Sprintf("Set minimum free disk to %d MB", cfg.MinFreeDisk))
+				case "export_stale_after":
+					if value != 0 && (value < 60 || value > 86400) {
+						ui.PrintStatus("error", "Export stale period must be 0 (default) or between 60 and 86400 seconds")
+						continue
+					}
+					cfg.ExportStaleAfter = int(value)
+					ui.PrintStatus("success", fmt.Sprintf("Alert when no metrics were exported for %v", cfg.ExportStalePeriod()))
+				case "iops.crit":
+					if value < 0 {
+						ui.PrintStatus("error", "IOPS critical threshold must be 0 (disabled) or positive")
+						continue
+					}
+					cfg.IOPSCritical = int(value)
+					ui.PrintStatus("success", fmt.Sprintf("Set IOPS critical threshold to %d ops/s", int(value)))
+				case "throughput.crit":
+					if value < 0 {
+						ui.PrintStatus("error", "Throughput critical threshold must be 0 (disabled) or positive")
+						continue
+					}
+					cfg.ThroughputCritical = value
+					ui.PrintStatus("success", fmt.Sprintf("Set throughput critical threshold to %g MB/s", value))
+				case "fd.crit":
+					if value < 0 || value > 100 {
+						ui.PrintStatus("error", "FD critical threshold must be between 0 (disabled) and 100 percent")
+						continue
+					}
+					cfg.FDCritical = value
+					ui.PrintStatus("success", fmt.Sprintf("Set file descriptor critical threshold to %g%% of the limit", value))
 				default:
 					ui.PrintStatus("error", fmt.Sprintf("Unknown setting: %s", metric))
 					continue
 				}
 			}
 
+			// Export/alert periods can't be shorter than collection, they'd only re-read the same snapshot
+			if cfg.OTLPExportInterval > 0 && cfg.OTLPExportInterval < cfg.CollectionInterval {
+				ui.PrintStatus("warning", fmt.Sprintf("Export interval is shorter than collection interval, %v will be used", cfg.ExportPeriod()))
+			}
+			if cfg.AlertCheckInterval < cfg.CollectionInterval {
+				ui.PrintStatus("warning", fmt.Sprintf("Alert check interval is shorter than collection interval, %v will be used", cfg.AlertPeriod()))
+			}
+			if cfg.UploadTimeout > 0 && cfg.UploadTimeout < cfg.HTTPTimeout {
+				ui.PrintStatus("warning", fmt.Sprintf("Upload timeout is shorter than HTTP timeout, %v will be used", cfg.ExportTimeout()))
+			}
+
+			// A critical tier at or below the warning tier makes the warning unreachable
+			for _, tier := range []struct {
+				name           string
+				warn, critical float64
+			}{
+				{"iops", float64(cfg.IOPSThreshold), float64(cfg.IOPSCritical)},
+				{"throughput", cfg.ThroughputThreshold, cfg.ThroughputCritical},
+				{"fd", cfg.FDThreshold, cfg.FDCritical},
+			} {
+				if tier.warn > 0 && tier.critical > 0 && tier.critical <= tier.warn {
+					ui.PrintStatus("warning", fmt.Sprintf("%s.crit (%g) is not above %s.warn (%g), only critical alerts will fire", tier.name, tier.critical, tier.name, tier.warn))
+				}
+			}
+
+			if dryRun {
+				printSettingChanges(&before, cfg)
+				ui.PrintStatus("info", "Dry run - configuration not saved")
+				ui.PrintSectionEnd()
+				if explain {
+					explainThresholds(cfg)
+				}
+				return
+			}
+
 			// save configuration
 			err = config.SaveConfig(cfg)
 			if err != nil {
-				ui.PrintStatus("error", fmt.Sprintf("Failed to save config: %v", err))
+				printConfigError("Failed to save config", err)
 				ui.PrintSectionEnd()
 				return
 			}
 
 			ui.PrintStatus("success", "Configuration saved successfully")
+			if explain {
+				ui.PrintSectionEnd()
+				explainThresholds(cfg)
+				ui.PrintSection("Applying")
+			}
 
 			// Send config_change event
-			if cfg.AuthToken != "" && cfg.ServerID != "" {
+			if cfg.AuthToken != "" && cfg.ServerID != "" && !cfg.AnalyticsEnabled {
+				ui.PrintStatus("info", "Analytics disabled - config_change event not sent")
+			} else if cfg.AuthToken != "" && cfg.ServerID != "" {
 				ui.PrintStatus("info", "Sending config_change event to backend...")
 				analytics.NewSender(cfg, GetCurrentVersion()).SendEventSync("config_change")
 				ui.PrintStatus("success", "Config change event sent")
@@ -92,8 +390,254 @@ Examples:
 				ui.PrintStatus("info", "Cloud mode not configured - event not sent")
 			}
 
-			ui.PrintStatus("info", "Run 'catops restart' to apply changes")
+			// The daemon reads its config at startup, so changes need a restart
+			if !apply {
+				ui.PrintStatus("info", "Run 'catops restart' (or 'catops set ... --apply') to apply changes")
+				ui.PrintSectionEnd()
+				return
+			}
+
+			svc, err := service.New()
+			if err != nil || !svc.IsRunning() {
+				ui.PrintStatus("info", "Monitoring daemon is not running, no restart needed")
+				ui.PrintStatus("info", "Changes apply on next 'catops start'")
+				ui.PrintSectionEnd()
+				return
+			}
+
+			if restartService(cfg) {
+				ui.PrintStatus("success", "Daemon restarted, new settings are active")
+			} else {
+				ui.PrintStatus("warning", "Daemon was not restarted, run 'catops restart' to apply changes")
+			}
 			ui.PrintSectionEnd()
 		},
 	}
+
+	cmd.Flags().BoolVar(&apply, "apply", false, "Restart the monitoring daemon after saving so changes take effect")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Validate and show before/after values without saving")
+	cmd.Flags().BoolVar(&explain, "explain", false, "Describe what each configured alert threshold triggers")
+
+	return cmd
+}
+
+// featureToggle returns the config field for a toggle setting, or nil if the setting is not a toggle
+func featureToggle(cfg *config.Config, setting string) *bool {
+	switch setting {
+	case "analytics":
+		return &cfg.AnalyticsEnabled
+	case "otlp":
+		return &cfg.OTLPEnabled
+	case "config_integrity":
+		return &cfg.ConfigIntegrity
+	case "alert_trend":
+		return &cfg.AlertTrend
+	}
+	return nil
+}
+
+// parseSettingValue parses a numeric setting. Percentages may carry a trailing "%",
+// throughput, process_io, net_rate, mem_available_min and min_free_disk accept size units (KB/MB/GB, optional "/s", bare numbers are MB)
+// and sustained_duration, alert_warmup and export_stale_after accept durations like 5m.
+func parseSettingValue(setting, raw string) (float64, error) {
+	if setting == "sustained_duration" || setting == "alert_warmup" || setting == "export_stale_after" {
+		if d, err := time.ParseDuration(strings.TrimSpace(raw)); err == nil {
+			return d.Seconds(), nil
+		}
+	}
+	isRate := strings.HasPrefix(setting, "throughput") || setting == "process_io" || setting == "mem_available_min" || setting == "min_free_disk" || (setting == "net_rate" && !strings.HasSuffix(strings.TrimSpace(raw), "%"))
+	if !isRate {
+		return utils.ParsePercentage(raw)
+	}
+
+	raw = strings.TrimSuffix(strings.TrimSpace(raw), "/s")
+	if value, err := utils.ParseFloat(raw); err == nil {
+		return value, nil
+	}
+	bytes, err := utils.ParseSize(raw)
+	if err != nil {
+		return 0, err
+	}
+	return bytes / (1024 * 1024), nil
+}
+
+// explainThresholds describes in plain words what each configured local alert
+// threshold triggers, with the check interval and channels actually in effect
+func explainThresholds(cfg *config.Config) {
+	ui.PrintSection("What Your Alert Settings Do")
+
+	when := fmt.Sprintf("checked every %v", cfg.AlertPeriod())
+	if cfg.SustainedDuration > 0 {
+		when += fmt.Sprintf(", once it lasts %v", time.Duration(cfg.SustainedDuration)*time.Second)
+	}
+
+	var notify []string
+	for _, channel := range cfg.AlertChannels() {
+		switch channel {
+		case "backend":
+			notify = append(notify, "Telegram + analytics")
+		case "hook":
+			notify = append(notify, "alert_hook "+cfg.AlertHook)
+		case "email":
+			notify = append(notify, "email")
+		}
+	}
+	delivery := "only logged (no notification channel configured)"
+	if len(notify) > 0 {
+		delivery = "notifies " + strings.Join(notify, " + ")
+	}
+
+	explained := 0
+	explain := func(subject string, warning, critical float64, unit string) {
+		var limit string
+		switch {
+		case warning > 0 && critical > 0:
+			limit = fmt.Sprintf("%g%s (critical at %g%s)", warning, unit, critical, unit)
+		case warning > 0:
+			limit = fmt.Sprintf("%g%s", warning, unit)
+		case critical > 0:
+			limit = fmt.Sprintf("%g%s (critical)", critical, unit)
+		default:
+			return
+		}
+		ui.PrintStatus("info", fmt.Sprintf("Alert fires when %s exceeds %s - %s, %s.", subject, limit, when, delivery))
+		explained++
+	}
+
+	fd := alerts.ThresholdsFromConfig(cfg).FDPercent // includes the default critical tier
+	explain("a disk's IOPS rate", float64(cfg.IOPSThreshold), float64(cfg.IOPSCritical), " ops/s")
+	explain("a disk's read+write throughput", cfg.ThroughputThreshold, cfg.ThroughputCritical, " MB/s")
+	explain("a process's open file descriptor count", fd.Warning, fd.Critical, "% of its limit")
+	explain("a process's disk read+write", cfg.ProcessIOThreshold, 0, " MB/s")
+	explain("an interface's receive or send rate", cfg.NetRateThreshold, 0, " MB/s")
+	explain("an interface's receive or send rate", cfg.NetRatePercent, 0, "% of its link speed")
+	if cfg.TemperatureThreshold > 0 {
+		ui.PrintStatus("info", fmt.Sprintf("Alert fires when a temperature sensor exceeds %g °C (critical at the sensor's own critical mark) or the CPU is thermally throttled - %s, %s.",
+			cfg.TemperatureThreshold, when, delivery))
+		explained++
+	}
+	if cfg.MemAvailableMin > 0 {
+		ui.PrintStatus("info", fmt.Sprintf("Alert fires when available memory drops below %s - %s, %s.",
+			utils.FormatBytes(int64(cfg.MemAvailableMin*1024*1024)), when, delivery))
+		explained++
+	}
+	if len(cfg.RequiredServices) > 0 {
+		ui.PrintStatus("info", fmt.Sprintf("Critical alert when %s is not running - %s, %s.", strings.Join(cfg.RequiredServices, ", "), when, delivery))
+		explained++
+	}
+
+	if cfg.IsCloudMode() && cfg.OTLPEnabled {
+		ui.PrintStatus("info", fmt.Sprintf("Critical alert when no metrics export succeeds for %v (export_stale_after) - %s.",
+			cfg.ExportStalePeriod(), delivery))
+	}
+
+	if explained == 0 {
+		ui.PrintStatus("info", "No local alert thresholds are set, e.g. 'catops set iops=5000 fd=80'")
+	} else {
+		ui.PrintStatus("info", fmt.Sprintf("No alerts in the first %v after the daemon starts (alert_warmup).", cfg.AlertWarmUpPeriod()))
+		ui.PrintStatus("info", "Every alert is also written to the daemon log and 'catops alerts'.")
+	}
+	if cfg.InMaintenance() {
+		ui.PrintStatus("warning", fmt.Sprintf("Maintenance is active until %s, notifications are suppressed",
+			time.Unix(cfg.MaintenanceUntil, 0).Format("2006-01-02 15:04:05")))
+	}
+	ui.PrintSectionEnd()
+}
+
+// settingValues renders the settings managed by 'catops set' for display
+func settingValues(cfg *config.Config) [][2]string {
+	onOff := func(enabled bool) string {
+		if enabled {
+			return "on"
+		}
+		return "off"
+	}
+
+	labels := make([]string, 0, len(cfg.ServerLabels))
+	for k, v := range cfg.ServerLabels {
+		labels = append(labels, k+"="+v)
+	}
+	sort.Strings(labels)
+
+	return [][2]string{
+		{"interval", fmt.Sprintf("%d", cfg.CollectionInterval)},
+		{"export_interval", fmt.Sprintf("%d", cfg.OTLPExportInterval)},
+		{"alert_interval", fmt.Sprintf("%d", cfg.AlertCheckInterval)},
+		{"http_timeout", fmt.Sprintf("%d", cfg.HTTPTimeout)},
+		{"upload_timeout", fmt.Sprintf("%d", cfg.UploadTimeout)},
+		{"log_dedup_window", fmt.Sprintf("%d", cfg.LogDedupWindow)},
+		{"log_rate_limit", fmt.Sprintf("%d", cfg.LogRateLimit)},
+		{"iops", fmt.Sprintf("%d", cfg.IOPSThreshold)},
+		{"throughput", fmt.Sprintf("%g", cfg.ThroughputThreshold)},
+		{"fd", fmt.Sprintf("%g", cfg.FDThreshold)},
+		{"iops.crit", fmt.Sprintf("%d", cfg.IOPSCritical)},
+		{"throughput.crit", fmt.Sprintf("%g", cfg.ThroughputCritical)},
+		{"fd.crit", fmt.Sprintf("%g", cfg.FDCritical)},
+		{"process_io", fmt.Sprintf("%g", cfg.ProcessIOThreshold)},
+		{"net_rate", fmt.Sprintf("%g", cfg.NetRateThreshold)},
+		{"net_rate", fmt.Sprintf("%g%%", cfg.NetRatePercent)}, // the % form sets the link speed share
+		{"mem_available_min", fmt.Sprintf("%g", cfg.MemAvailableMin)},
+		{"temperature", fmt.Sprintf("%g", cfg.TemperatureThreshold)},
+		{"sustained_duration", fmt.Sprintf("%d", cfg.SustainedDuration)},
+		{"alert_warmup", fmt.Sprintf("%d", cfg.AlertWarmUp)},
+		{"export_stale_after", fmt.Sprintf("%d", cfg.ExportStaleAfter)},
+		{"min_free_disk", fmt.Sprintf("%d", cfg.MinFreeDisk)},
+		{"alert_trend", onOff(cfg.AlertTrend)},
+		{"analytics", onOff(cfg.AnalyticsEnabled)},
+		{"otlp", onOff(cfg.OTLPEnabled)},
+		{"config_integrity", onOff(cfg.ConfigIntegrity)},
+		{"display_name", cfg.DisplayName},
+		{"labels", strings.Join(labels, ",")},
+		{"environment", cfg.Environment},
+		{"region", cfg.Region},
+	}
+}
+
+// printSettingChanges prints before -> after for every setting that changed
+func printSettingChanges(before, after *config.Config) {
+	old, updated := settingValues(before), settingValues(after)
+
+	changed := 0
+	for i := range updated {
+		if old[i][1] == updated[i][1] {
+			continue
+		}
+		ui.PrintStatus("info", fmt.Sprintf("%s: %q -> %q", updated[i][0], old[i][1], updated[i][1]))
+		changed++
+	}
+	if changed == 0 {
+		ui.PrintStatus("info", "No changes")
+	}
+}
+
+// parseLabels parses "key=value,key2=value2" into a label map
+func parseLabels(value string) (map[string]string, error) {
+	labels := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		kv := strings.SplitN(pair, "=", 2)
+		key := strings.ToLower(strings.TrimSpace(kv[0])) // viper lowercases map keys on load
+		if len(kv) != 2 || key == "" {
+			return nil, fmt.Errorf("expected key=value, got %q", pair)
+		}
+		if strings.ContainsAny(key, " .:\"'") {
+			return nil, fmt.Errorf("invalid label key %q", key)
+		}
+		labels[key] = strings.TrimSpace(kv[1])
+	}
+	return labels, nil
+}
+
+// parseToggle parses on/off style values
+func parseToggle(value string) (bool, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "on", "true", "yes", "1", "enabled":
+		return true, true
+	case "off", "false", "no", "0", "disabled":
+		return false, true
+	}
+	return false, false
 }
@@ -0,0 +1,12 @@
+//go:build !windows
+// +build !windows
+
+package commands
+
+import (
+	"os"
+	"syscall"
+)
+
+// dumpSignals trigger an on-demand metrics dump in the daemon
+var dumpSignals = []os.Signal{syscall.SIGUSR1}
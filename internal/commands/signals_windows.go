@@ -0,0 +1,9 @@
+//go:build windows
+// +build windows
+
+package commands
+
+import "os"
+
+// dumpSignals is empty on Windows, which has no SIGUSR1
+var dumpSignals []os.Signal
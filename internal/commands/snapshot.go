@@ -0,0 +1,175 @@
+package commands
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"catops/internal/config"
+	"catops/internal/logger"
+	"catops/internal/metrics"
+	"catops/internal/service"
+	"catops/internal/ui"
+	"catops/pkg/utils"
+)
+
+// snapshotLogLines is the number of daemon log lines included in a snapshot
+const snapshotLogLines = 500
+
+// NewSnapshotCmd creates the snapshot command
+func NewSnapshotCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "snapshot",
+		Short: "Write a diagnostic bundle for support",
+		Long: `Collect a diagnostic bundle into a timestamped .tar.gz in the current directory.
+
+The bundle contains:
+  • A full metrics dump (JSON)
+  • The full configuration, as 'catops config dump' shows it (secrets masked)
+  • The tail of the daemon log
+  • Daemon/autostart status
+  • Version and OS information
+
+Examples:
+  catops snapshot          # Write catops-snapshot-<timestamp>.tar.gz`,
+		Run: func(cmd *cobra.Command, args []string) {
+			ui.PrintHeader()
+			ui.PrintSection("Diagnostic Snapshot")
+
+			files := make(map[string][]byte)
+
+			// metrics dump
+			allMetrics, err := metrics.CollectAllMetrics()
+			if err != nil {
+				ui.PrintStatus("warning", fmt.Sprintf("Metrics collected with errors: %v", err))
+			}
+			if allMetrics != nil {
+				if data, err := json.MarshalIndent(allMetrics, "", "  "); err == nil {
+					files["metrics.json"] = data
+				}
+			}
+
+			// configuration (redacted)
+			cfg, err := config.LoadConfig()
+			if err != nil {
+				cfg = config.DefaultConfig()
+			}
+			files["config.yaml"] = []byte(cfg.Dump(false))
+
+			// daemon log tail
+			files["daemon.log"] = []byte(tailFile(logger.Path(), snapshotLogLines))
+
+			// service status, version and OS info
+			files["status.txt"] = []byte(snapshotStatus())
+
+			filename := fmt.Sprintf("catops-snapshot-%s.tar.gz", time.Now().Format("20060102-150405"))
+			if err := writeSnapshotArchive(filename, files); err != nil {
+				ui.PrintStatus("error", fmt.Sprintf("Failed to write snapshot: %v", err))
+				ui.PrintSectionEnd()
+				return
+			}
+
+			ui.PrintStatus("success", fmt.Sprintf("Snapshot written to %s", filename))
+			ui.PrintStatus("info", "Secrets are masked, the bundle is safe to share with support")
+			ui.PrintSectionEnd()
+		},
+	}
+}
+
+// tailFile returns the last n lines of a file
+func tailFile(path string, n int) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Sprintf("could not read %s: %v\n", path, err)
+	}
+
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// snapshotStatus describes version, OS and daemon state
+func snapshotStatus() string {
+	var b strings.Builder
+
+	hostname, _ := os.Hostname()
+	version := "unknown"
+	if GetCurrentVersion != nil {
+		version = GetCurrentVersion()
+	}
+
+	fmt.Fprintf(&b, "Generated: %s\n", time.Now().Format(time.RFC3339))
+	fmt.Fprintf(&b, "Version: %s\n", version)
+	fmt.Fprintf(&b, "Hostname: %s\n", hostname)
+	fmt.Fprintf(&b, "OS: %s\n", runtime.GOOS)
+	fmt.Fprintf(&b, "Arch: %s\n", runtime.GOARCH)
+	fmt.Fprintf(&b, "Go: %s\n", runtime.Version())
+	fmt.Fprintf(&b, "UID: %d\n", os.Geteuid())
+
+	svc, err := service.New()
+	if err != nil {
+		fmt.Fprintf(&b, "Service: unavailable (%v)\n", err)
+		return b.String()
+	}
+	status, err := svc.Status()
+	if err != nil {
+		fmt.Fprintf(&b, "Service: error (%v)\n", err)
+	} else {
+		fmt.Fprintf(&b, "Service: %s\n", status)
+	}
+	fmt.Fprintf(&b, "Daemon Running: %t\n", svc.IsRunning())
+
+	return b.String()
+}
+
+// writeSnapshotArchive writes files into a gzip-compressed tarball
+func writeSnapshotArchive(filename string, files map[string][]byte) error {
+	// Uncompressed size, about the most the archive takes
+	var size int64
+	for _, data := range files {
+		size += int64(len(data))
+	}
+	if err := utils.EnsureFreeSpace(filename, size); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+
+	dir := strings.TrimSuffix(filename, ".tar.gz")
+	now := time.Now()
+	for name, data := range files {
+		header := &tar.Header{
+			Name:    dir + "/" + name,
+			Mode:    0600,
+			Size:    int64(len(data)),
+			ModTime: now,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := tw.Write(data); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
@@ -1,101 +1,273 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"catops/internal/config"
+	"catops/internal/logger"
 	"catops/internal/metrics"
 	"catops/internal/service"
 	"catops/internal/ui"
 	"catops/pkg/utils"
 )
 
+// exportStatusFileName holds when the daemon last exported metrics
+const exportStatusFileName = "export_status.json"
+
+// exportStatus is written by the daemon after each collection for 'catops status'
+type exportStatus struct {
+	// Since is when the daemon started exporting, LastSuccess is zero until the first export
+	Since       time.Time `json:"since"`
+	LastSuccess time.Time `json:"last_success"`
+	StaleAfter  float64   `json:"stale_after_seconds"`
+}
+
+// exportStatusPath returns where the daemon writes its export status
+func exportStatusPath() string {
+	return filepath.Join(config.ConfigDir(), exportStatusFileName)
+}
+
+// saveExportStatus records the last successful export for 'catops status'
+func saveExportStatus(status exportStatus) {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return
+	}
+	if err := os.WriteFile(exportStatusPath(), data, 0600); err != nil {
+		logger.Debug("Failed to write export status: %v", err)
+	}
+}
+
+// printExportStatus reports how long ago the running daemon last exported
+func printExportStatus() {
+	data, err := os.ReadFile(exportStatusPath())
+	if err != nil {
+		return // local mode, or the daemon has not collected yet
+	}
+	var status exportStatus
+	if err := json.Unmarshal(data, &status); err != nil {
+		return
+	}
+	staleAfter := time.Duration(status.StaleAfter * float64(time.Second))
+	if status.LastSuccess.IsZero() {
+		if age := time.Since(status.Since); age > staleAfter {
+			ui.PrintStatus("error", fmt.Sprintf("No successful metrics export since the daemon started %v ago",
+				age.Round(time.Second)))
+		} else {
+			ui.PrintStatus("info", "Waiting for the first metrics export")
+		}
+		return
+	}
+	age := time.Since(status.LastSuccess).Round(time.Second)
+	if age > staleAfter {
+		ui.PrintStatus("error", fmt.Sprintf("Last successful metrics export %v ago (%s), the dashboard is stale",
+			age, status.LastSuccess.Format("2006-01-02 15:04:05")))
+	} else {
+		ui.PrintStatus("success", fmt.Sprintf("Last successful metrics export %v ago", age))
+	}
+}
+
 // NewStatusCmd creates the status command
 func NewStatusCmd() *cobra.Command {
-	return &cobra.Command{
+	var verbose bool
+	var remote string
+	var output string
+
+	cmd := &cobra.Command{
 		Use:   "status",
 		Short: "Display current system metrics",
 		Long: `Display real-time system information including:
   • System Information (Hostname, OS, IP, Uptime)
   • Current Metrics (CPU, Memory, Disk, HTTPS Connections)
 
+With --remote, a minimal set (CPU, memory, root disk, load, uptime) is
+collected from a Linux host over SSH, without catops installed there.
+Key-based SSH authentication is required.
+
 Examples:
-  catops status          # Show all system information`,
+  catops status          # Show all system information
+  catops status -V       # Also print collection timing breakdown to stderr
+  catops status --remote admin@db-01  # Show metrics of a remote host
+  catops -q status -o /var/log/catops-status.txt  # Write a report for cron`,
 		Run: func(cmd *cobra.Command, args []string) {
-			// Load configuration
-			cfg, err := config.LoadConfig()
-			if err != nil {
-				ui.PrintStatus("error", "Failed to load configuration")
-				cfg = &config.Config{}
+			render := func() error {
+				if remote != "" {
+					return printRemoteStatus(remote)
+				}
+				return printLocalStatus(verbose)
 			}
 
-			// get system information
-			hostname, _ := os.Hostname()
-			// Use cached metrics for faster response (avoids 1-second CPU measurement delay)
-			currentMetrics, err := metrics.GetMetricsWithCache()
-			if err != nil {
-				ui.PrintStatus("error", fmt.Sprintf("Error getting metrics: %v", err))
+			if output != "" {
+				if err := writeOutput(output, render); err != nil {
+					ui.PrintStatus("error", err.Error())
+					os.Exit(1)
+				}
+			} else if err := render(); err != nil {
+				ui.PrintStatus("error", err.Error())
 				return
 			}
+		},
+	}
 
-			// system information section
-			ui.PrintSection("System Information")
-			systemData := map[string]string{
-				"Hostname": hostname,
-				"OS":       currentMetrics.OSName,
-				"IP":       currentMetrics.IPAddress,
-				"Uptime":   currentMetrics.Uptime,
-			}
-			fmt.Print(ui.CreateBeautifulList(systemData))
-			ui.PrintSectionEnd()
+	cmd.Flags().BoolVarP(&verbose, "verbose", "V", false, "Print per-collector timing breakdown and errors to stderr")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write the output to a file (atomically) instead of stdout")
+	cmd.Flags().StringVar(&remote, "remote", "", "Collect metrics from a remote Linux host over SSH ([user@]host[:port])")
 
-			// timestamp section
-			ui.PrintSection("Timestamp")
-			timestampData := map[string]string{
-				"Current Time": currentMetrics.Timestamp,
-			}
-			fmt.Print(ui.CreateBeautifulList(timestampData))
-			ui.PrintSectionEnd()
-
-			// metrics section
-			ui.PrintSection("Current Metrics")
-			metricsData := map[string]string{
-				"CPU Usage":         fmt.Sprintf("%s (%d cores, %d active)", utils.FormatPercentage(currentMetrics.CPUUsage), currentMetrics.CPUDetails.Total, currentMetrics.CPUDetails.Used),
-				"Memory Usage":      fmt.Sprintf("%s (%s / %s)", utils.FormatPercentage(currentMetrics.MemoryUsage), utils.FormatBytes(currentMetrics.MemoryDetails.Used*1024), utils.FormatBytes(currentMetrics.MemoryDetails.Total*1024)),
-				"Disk Usage":        fmt.Sprintf("%s (%s / %s)", utils.FormatPercentage(currentMetrics.DiskUsage), utils.FormatBytes(currentMetrics.DiskDetails.Used*1024), utils.FormatBytes(currentMetrics.DiskDetails.Total*1024)),
-				"HTTPS Connections": utils.FormatNumber(currentMetrics.HTTPSRequests),
-				"IOPS":              utils.FormatNumber(currentMetrics.IOPS),
-				"I/O Wait":          utils.FormatPercentage(currentMetrics.IOWait),
-			}
-			fmt.Print(ui.CreateBeautifulList(metricsData))
-			ui.PrintSectionEnd()
-
-			// monitoring settings section
-			ui.PrintSection("Monitoring Settings")
-			settingsData := map[string]string{
-				"Collection Interval": fmt.Sprintf("%d seconds", cfg.CollectionInterval),
-				"Mode":                cfg.Mode,
-			}
-			fmt.Print(ui.CreateBeautifulList(settingsData))
-			ui.PrintSectionEnd()
-
-			// daemon status
-			ui.PrintSection("Daemon Status")
-			svc, svcErr := service.New()
-			if svcErr == nil {
-				status, statusErr := svc.Status()
-				if statusErr == nil && status != "" {
-					ui.PrintStatus("success", "Monitoring daemon is running")
-				} else {
-					ui.PrintStatus("warning", "Monitoring daemon is not running")
-				}
-			} else {
-				ui.PrintStatus("warning", "Could not check daemon status")
-			}
-			ui.PrintSectionEnd()
-		},
+	return cmd
+}
+
+// printLocalStatus renders the status of this host. With verbose, the metrics
+// are collected fresh and the collection timing is printed to stderr.
+func printLocalStatus(verbose bool) error {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		printConfigError("Failed to load configuration", err)
+		cfg = config.DefaultConfig()
+	}
+
+	// get system information
+	hostname, _ := os.Hostname()
+	var currentMetrics *metrics.Metrics
+	if verbose {
+		start := time.Now()
+		all, timings, collectErr := metrics.CollectAllMetricsWithTimings()
+		printCollectionTimings(timings, time.Since(start), collectErr)
+		currentMetrics, err = metrics.LegacyMetrics(all, collectErr)
+	} else {
+		// Use cached metrics for faster response (avoids 1-second CPU measurement delay)
+		currentMetrics, err = metrics.GetMetricsWithCache()
+	}
+	if err != nil {
+		return fmt.Errorf("error getting metrics: %w", err)
+	}
+
+	// system information section
+	ui.PrintSection("System Information")
+	systemData := map[string]string{
+		"Hostname": hostname,
+		"OS":       currentMetrics.OSName,
+		"IP":       currentMetrics.IPAddress,
+		"Uptime":   currentMetrics.Uptime,
+	}
+	fmt.Print(ui.CreateBeautifulList(systemData))
+	ui.PrintSectionEnd()
+
+	// timestamp section
+	ui.PrintSection("Timestamp")
+	timestampData := map[string]string{
+		"Current Time": currentMetrics.Timestamp,
+	}
+	fmt.Print(ui.CreateBeautifulList(timestampData))
+	ui.PrintSectionEnd()
+
+	// metrics section
+	ui.PrintSection("Current Metrics")
+	metricsData := map[string]string{
+		"CPU Usage":         fmt.Sprintf("%s (%d cores, %d active)", utils.FormatPercentage(currentMetrics.CPUUsage), currentMetrics.CPUDetails.Total, currentMetrics.CPUDetails.Used),
+		"Memory Usage":      fmt.Sprintf("%s (%s / %s)", utils.FormatPercentage(currentMetrics.MemoryUsage), utils.FormatBytes(currentMetrics.MemoryDetails.Used*1024), utils.FormatBytes(currentMetrics.MemoryDetails.Total*1024)),
+		"Disk Usage":        fmt.Sprintf("%s (%s / %s)", utils.FormatPercentage(currentMetrics.DiskUsage), utils.FormatBytes(currentMetrics.DiskDetails.Used*1024), utils.FormatBytes(currentMetrics.DiskDetails.Total*1024)),
+		"HTTPS Connections": httpsLabel(currentMetrics.HTTPSRequests),
+		"IOPS":              utils.FormatNumber(currentMetrics.IOPS),
+		"I/O Wait":          utils.FormatPercentage(currentMetrics.IOWait),
+	}
+	fmt.Print(ui.CreateBeautifulList(metricsData))
+	ui.PrintSectionEnd()
+
+	// monitoring settings section
+	ui.PrintSection("Monitoring Settings")
+	settingsData := map[string]string{
+		"Collection Interval": fmt.Sprintf("%d seconds", cfg.CollectionInterval),
+		"Mode":                cfg.Mode,
+	}
+	fmt.Print(ui.CreateBeautifulList(settingsData))
+	ui.PrintSectionEnd()
+
+	// daemon status
+	ui.PrintSection("Daemon Status")
+	svc, svcErr := service.New()
+	if svcErr == nil {
+		status, statusErr := svc.Status()
+		if statusErr == nil && status != "" {
+			ui.PrintStatus("success", "Monitoring daemon is running")
+			printExportStatus()
+		} else {
+			ui.PrintStatus("warning", "Monitoring daemon is not running")
+		}
+	} else {
+		ui.PrintStatus("warning", "Could not check daemon status")
+	}
+	if cfg.InMaintenance() {
+		ui.PrintStatus("warning", fmt.Sprintf("Maintenance active until %s, alerts suppressed",
+			time.Unix(cfg.MaintenanceUntil, 0).Format("2006-01-02 15:04:05")))
 	}
+	ui.PrintSectionEnd()
+	return nil
+}
+
+// httpsLabel formats the HTTPS connection count with its direction
+func httpsLabel(count int64) string {
+	if metrics.HTTPSInbound() {
+		return utils.FormatNumber(count) + " inbound"
+	}
+	return utils.FormatNumber(count) + " outbound"
+}
+
+// printCollectionTimings reports how long each collector took and their errors
+func printCollectionTimings(timings []metrics.CollectorTiming, total time.Duration, err error) {
+	fmt.Fprintln(os.Stderr, "Collection timing:")
+	for _, t := range timings {
+		fmt.Fprintf(os.Stderr, "  %-12s %8s\n", t.Name, t.Duration.Round(time.Millisecond))
+	}
+	fmt.Fprintf(os.Stderr, "  %-12s %8s\n", "total", total.Round(time.Millisecond))
+	// Collector errors are joined, one per line
+	if err != nil {
+		for _, line := range strings.Split(err.Error(), "\n") {
+			fmt.Fprintf(os.Stderr, "  error: %s\n", line)
+		}
+	}
+}
+
+// printRemoteStatus collects metrics from a host over SSH and renders them like local status
+func printRemoteStatus(target string) error {
+	ui.PrintStatus("info", fmt.Sprintf("Collecting metrics from %s over SSH...", target))
+	remoteMetrics, err := metrics.GetRemoteMetrics(target)
+	if err != nil {
+		return fmt.Errorf("error getting remote metrics: %w", err)
+	}
+
+	ui.PrintSection("System Information")
+	fmt.Print(ui.CreateBeautifulList(map[string]string{
+		"Hostname": remoteMetrics.Hostname,
+		"OS":       remoteMetrics.OSName,
+		"IP":       remoteMetrics.IPAddress,
+		"Uptime":   remoteMetrics.Uptime,
+	}))
+	ui.PrintSectionEnd()
+
+	ui.PrintSection("Timestamp")
+	fmt.Print(ui.CreateBeautifulList(map[string]string{
+		"Current Time": remoteMetrics.Timestamp,
+	}))
+	ui.PrintSectionEnd()
+
+	ui.PrintSection("Current Metrics")
+	fmt.Print(ui.CreateBeautifulList(map[string]string{
+		"CPU Usage":    fmt.Sprintf("%s (%d cores)", utils.FormatPercentage(remoteMetrics.CPUUsage), remoteMetrics.CPUDetails.Total),
+		"Memory Usage": fmt.Sprintf("%s (%s / %s)", utils.FormatPercentage(remoteMetrics.MemoryUsage), utils.FormatBytes(remoteMetrics.MemoryDetails.Used*1024), utils.FormatBytes(remoteMetrics.MemoryDetails.Total*1024)),
+		"Disk Usage":   fmt.Sprintf("%s (%s / %s)", utils.FormatPercentage(remoteMetrics.DiskUsage), utils.FormatBytes(remoteMetrics.DiskDetails.Used*1024), utils.FormatBytes(remoteMetrics.DiskDetails.Total*1024)),
+		"Load Average": fmt.Sprintf("%.2f, %.2f, %.2f", remoteMetrics.Load[0], remoteMetrics.Load[1], remoteMetrics.Load[2]),
+		"I/O Wait":     utils.FormatPercentage(remoteMetrics.IOWait),
+	}))
+	ui.PrintSectionEnd()
+
+	ui.PrintStatus("info", "Remote mode: processes, services and daemon status are not available")
+	return nil
 }
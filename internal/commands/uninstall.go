@@ -11,6 +11,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"catops/internal/config"
+	"catops/internal/logger"
 	"catops/internal/server"
 	"catops/internal/service"
 	"catops/internal/ui"
@@ -40,9 +41,9 @@ Examples:
 			// Load configuration
 			cfg, err := config.LoadConfig()
 			if err != nil {
-				ui.PrintStatus("error", "Failed to load configuration")
+				printConfigError("Failed to load configuration", err)
 				ui.PrintStatus("info", "Continuing with uninstall without backend notification")
-				cfg = &config.Config{} // Use empty config
+				cfg = config.DefaultConfig() // Use empty config
 			}
 
 			// check if --yes flag is set
@@ -104,6 +105,10 @@ Examples:
 					exec.Command("launchctl", "unload", launchAgent).Run()
 					os.Remove(launchAgent)
 				}
+			case "windows":
+				// Scheduled task is normally removed above, retry in case the service manager failed
+				exec.Command("schtasks", "/End", "/TN", "CatOps").Run()
+				exec.Command("schtasks", "/Delete", "/TN", "CatOps", "/F").Run()
 			}
 
 			// remove configuration directory
@@ -122,7 +127,7 @@ Examples:
 			// remove log files only if backend was notified successfully
 			if backendNotified {
 				logFiles := []string{
-					"/tmp/catops.log",
+					logger.Path(),
 					"/tmp/catops.pid",
 				}
 
@@ -137,8 +142,10 @@ Examples:
 				ui.PrintStatus("info", "Keeping log files for debugging (backend not notified)")
 			}
 
-			// Kill any remaining processes
-			exec.Command("pkill", "-9", "-f", "catops daemon").Run()
+			// Stop any remaining daemons (on Windows ending the scheduled task stops the daemon)
+			if runtime.GOOS != "windows" {
+				stopDaemonProcesses()
+			}
 			ui.PrintStatus("success", "All processes stopped")
 
 			// remove ALL CatOps binaries from PATH LAST
@@ -152,11 +159,13 @@ Examples:
 			)
 
 			// also search for any other catops binaries in PATH
-			pathSeparator := ":"
-			pathDirs := strings.Split(os.Getenv("PATH"), pathSeparator)
+			binaryName := "catops"
+			if runtime.GOOS == "windows" {
+				binaryName = "catops.exe"
+			}
+			pathDirs := filepath.SplitList(os.Getenv("PATH"))
 			for _, dir := range pathDirs {
 				if strings.Contains(dir, "catops") || strings.Contains(dir, ".local") || strings.Contains(dir, "bin") {
-					binaryName := "catops"
 					potentialPath := filepath.Join(dir, binaryName)
 					if _, err := os.Stat(potentialPath); err == nil {
 						binaryPaths = append(binaryPaths, potentialPath)
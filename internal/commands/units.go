@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"catops/internal/metrics"
+	"catops/internal/ui"
+)
+
+// NewUnitsCmd creates the units command
+func NewUnitsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "units",
+		Short: "Show failed and tracked systemd units",
+		Long: `List systemd units that are in the failed state, plus the state of every
+unit listed in systemd_units. The daemon alerts when a unit fails or when a
+tracked unit is inactive. Only available on hosts running systemd.
+
+Configure in ~/.catops/config.yaml:
+  systemd_units: ["nginx", "postgresql", "backup.timer"]
+
+Examples:
+  catops units             # Show failed and tracked units`,
+		Run: func(cmd *cobra.Command, args []string) {
+			ui.PrintHeader()
+			ui.PrintSection("systemd Units")
+
+			if !metrics.IsSystemd() {
+				ui.PrintStatus("info", "systemd is not running on this host")
+				ui.PrintSectionEnd()
+				return
+			}
+
+			units, err := metrics.GetUnits()
+			if err != nil {
+				ui.PrintStatus("error", fmt.Sprintf("Failed to query systemd: %v", err))
+				ui.PrintSectionEnd()
+				return
+			}
+
+			if len(units) == 0 {
+				ui.PrintStatus("success", "No failed units")
+				ui.PrintStatus("info", "Add systemd_units to ~/.catops/config.yaml to track specific units")
+				ui.PrintSectionEnd()
+				return
+			}
+
+			for _, u := range units {
+				status := "success"
+				switch {
+				case u.ActiveState == "failed":
+					status = "error"
+				case u.ActiveState != "active":
+					status = "warning"
+				}
+
+				line := fmt.Sprintf("%s: %s (%s)", u.Name, u.ActiveState, u.SubState)
+				if u.LoadState == "not-found" {
+					line = fmt.Sprintf("%s: not installed", u.Name)
+				}
+				if u.Description != "" && u.LoadState != "not-found" {
+					line += " - " + u.Description
+				}
+				ui.PrintStatus(status, line)
+			}
+			ui.PrintSectionEnd()
+		},
+	}
+}
@@ -13,15 +13,19 @@ import (
 
 // NewUpdateCmd creates the update command
 func NewUpdateCmd() *cobra.Command {
-	return &cobra.Command{
+	var useScript bool
+
+	cmd := &cobra.Command{
 		Use:   "update",
 		Short: "Download and install the latest version",
 		Long: `Check for and install the latest version of CatOps.
 This will check if updates are available and install them if found.
-The update process is handled by the official update script.
+The release binary is downloaded, verified against the published SHA256
+checksums and swapped in atomically. The previous binary is kept as catops.backup.1.
 
 Examples:
-  catops update          # Check and install updates`,
+  catops update          # Check and install updates
+  catops update --script # Use the official update script instead`,
 		Run: func(cmd *cobra.Command, args []string) {
 			ui.PrintHeader()
 			ui.PrintSection("Checking for Updates")
@@ -29,9 +33,9 @@ Examples:
 			// Load configuration
 			cfg, err := config.LoadConfig()
 			if err != nil {
-				ui.PrintStatus("error", "Failed to load configuration")
+				printConfigError("Failed to load configuration", err)
 				ui.PrintStatus("info", "Continuing with update check without server version")
-				cfg = &config.Config{}
+				cfg = config.DefaultConfig()
 			}
 
 			// Check if we have authentication
@@ -41,7 +45,7 @@ Examples:
 				ui.PrintStatus("info", "Continuing with basic update check...")
 
 				// Fallback to basic update check
-				server.CheckBasicUpdate(GetCurrentVersion())
+				server.CheckBasicUpdate(GetCurrentVersion(), useScript)
 				return
 			}
 
@@ -51,7 +55,7 @@ Examples:
 			if err != nil {
 				ui.PrintStatus("warning", fmt.Sprintf("Failed to check server version: %v", err))
 				ui.PrintStatus("info", "Falling back to basic update check...")
-				server.CheckBasicUpdate(GetCurrentVersion())
+				server.CheckBasicUpdate(GetCurrentVersion(), useScript)
 				return
 			}
 
@@ -73,10 +77,11 @@ Examples:
 			}
 
 			ui.PrintStatus("info", "Update available! Installing...")
-			ui.PrintSectionEnd()
-
-			// Execute the update script
-			server.ExecuteUpdateScript(GetCurrentVersion())
+			server.RunUpdate(currentVersion, latestVersion, useScript)
 		},
 	}
+
+	cmd.Flags().BoolVar(&useScript, "script", false, "Install via the update script instead of the verified binary download")
+
+	return cmd
 }
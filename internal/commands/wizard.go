@@ -0,0 +1,344 @@
+package commands
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	constants "catops/config"
+	"catops/internal/config"
+	"catops/internal/server"
+	"catops/internal/ui"
+	"catops/pkg/utils"
+)
+
+// wizard reads answers to setup questions from the terminal
+type wizard struct {
+	in *bufio.Reader
+}
+
+// errInputEnded is returned when stdin closes before the wizard is done
+var errInputEnded = errors.New("input ended before the wizard was done")
+
+// ask prompts until check accepts the answer; an empty answer keeps def
+func (w *wizard) ask(question, def string, check func(string) error) (string, error) {
+	return w.prompt(question, def, def, check, w.readLine)
+}
+
+// secret is ask for a secret: the current value is shown masked and the
+// answer is read without echoing it
+func (w *wizard) secret(question, def string, visible int, check func(string) error) (string, error) {
+	return w.prompt(question, def, utils.MaskSecret(def, visible), check, w.readHidden)
+}
+
+// prompt asks question, showing shown as the default, until check accepts the
+// answer read by read; an empty answer keeps def
+func (w *wizard) prompt(question, def, shown string, check func(string) error, read func() (string, error)) (string, error) {
+	for {
+		if shown != "" {
+			fmt.Printf("  %s [%s]: ", question, shown)
+		} else {
+			fmt.Printf("  %s: ", question)
+		}
+		answer, err := read()
+		if err != nil {
+			fmt.Println()
+			return "", err
+		}
+		if answer == "" {
+			answer = def
+		}
+		if check != nil {
+			if err := check(answer); err != nil {
+				ui.PrintStatus("error", err.Error())
+				continue
+			}
+		}
+		return answer, nil
+	}
+}
+
+// readLine reads one answer, errInputEnded once stdin is closed
+func (w *wizard) readLine() (string, error) {
+	line, err := w.in.ReadString('\n')
+	if errors.Is(err, io.EOF) && line != "" {
+		err = nil // last line without a newline
+	} else if errors.Is(err, io.EOF) {
+		err = errInputEnded
+	}
+	return strings.TrimSpace(line), err
+}
+
+// readHidden reads one answer without echoing it to the terminal
+func (w *wizard) readHidden() (string, error) {
+	answer, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Println()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(answer)), nil
+}
+
+// confirm asks a yes/no question
+func (w *wizard) confirm(question string, def bool) (bool, error) {
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+	answer, err := w.ask(fmt.Sprintf("%s (%s)", question, hint), "", nil)
+	if err != nil || answer == "" {
+		return def, err
+	}
+	answer = strings.ToLower(answer)
+	return answer == "y" || answer == "yes", nil
+}
+
+// number asks for a setting parsed like 'catops set' does (units, percentages)
+func (w *wizard) number(question, setting string, def float64, min, max float64) (float64, error) {
+	answer, err := w.ask(question, strconv.FormatFloat(def, 'g', -1, 64), func(s string) error {
+		value, err := parseSettingValue(setting, s)
+		if err != nil {
+			return fmt.Errorf("invalid value %q", s)
+		}
+		if value < min || value > max {
+			return fmt.Errorf("must be between %g and %g", min, max)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	value, _ := parseSettingValue(setting, answer)
+	return value, nil
+}
+
+// isTerminal reports whether stdin is an interactive terminal
+func isTerminal() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// newConfigWizardCmd creates the config wizard subcommand
+func newConfigWizardCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "wizard",
+		Short: "Set up CatOps interactively",
+		Long: `Walk through first-time setup: collection interval, server name, local alert
+thresholds, email alerts and cloud login. Every question shows the current
+value, press Enter to keep it. Answers are validated as you go and nothing
+is written until you confirm the summary at the end. A new cloud token is
+saved only once the server is registered with it.
+
+The wizard needs an interactive terminal. In scripts use 'catops set',
+'catops auth login <token>' and 'catops config import <file>' instead.
+
+Examples:
+  catops config wizard`,
+		Run: func(cmd *cobra.Command, args []string) {
+			ui.PrintHeader()
+
+			if !isTerminal() {
+				ui.PrintSection("Setup Wizard")
+				ui.PrintStatus("error", "The setup wizard needs an interactive terminal")
+				ui.PrintStatus("info", "Non-interactive setup: 'catops set interval=30 iops=5000', 'catops auth login <token>'")
+				ui.PrintStatus("info", "or apply a bundle from another server with 'catops config import <file>'")
+				ui.PrintSectionEnd()
+				os.Exit(1)
+			}
+
+			cfg, err := config.LoadConfig()
+			if err != nil {
+				ui.PrintSection("Setup Wizard")
+				printConfigError("Failed to load configuration", err)
+				ui.PrintSectionEnd()
+				os.Exit(1)
+			}
+			before := *cfg
+			w := &wizard{in: bufio.NewReader(os.Stdin)}
+
+			newToken, err := askWizardQuestions(w, cfg)
+			if err != nil {
+				ui.PrintStatus("error", fmt.Sprintf("Setup aborted: %v", err))
+				ui.PrintStatus("info", "Nothing was saved")
+				ui.PrintSectionEnd()
+				os.Exit(1)
+			}
+
+			ui.PrintSection("Summary")
+			changes := configChanges(&before, cfg)
+			if newToken != "" && newToken != before.AuthToken {
+				changes = append(changes, "auth_token: "+utils.MaskSecret(newToken, 10))
+			}
+			if len(changes) == 0 {
+				ui.PrintStatus("success", "No changes")
+				ui.PrintSectionEnd()
+				return
+			}
+			for _, change := range changes {
+				ui.PrintStatus("info", change)
+			}
+			if !checkConfig(cfg) {
+				ui.PrintSectionEnd()
+				os.Exit(1)
+			}
+			save, err := w.confirm("Save this configuration", true)
+			if err != nil || !save {
+				ui.PrintStatus("info", "Nothing was saved")
+				ui.PrintSectionEnd()
+				if err != nil {
+					os.Exit(1)
+				}
+				return
+			}
+
+			// Same registration as 'catops auth login'
+			if newToken != "" && newToken != before.AuthToken {
+				registered := false
+				if cfg.ServerID != "" && before.AuthToken != "" {
+					registered = server.TransferServerOwnership(before.AuthToken, newToken, cfg.ServerID, GetCurrentVersion())
+				} else {
+					registered = server.RegisterServer(newToken, GetCurrentVersion(), cfg)
+				}
+				if registered {
+					cfg.AuthToken = newToken
+					ui.PrintStatus("success", "Server registered with your account")
+				} else {
+					ui.PrintStatus("error", "Failed to register server, token not saved")
+					ui.PrintStatus("info", "Retry later with 'catops auth login <token>'")
+				}
+			}
+
+			if err := config.SaveConfig(cfg); err != nil {
+				printConfigError("Failed to save config", err)
+				ui.PrintSectionEnd()
+				os.Exit(1)
+			}
+			ui.PrintStatus("success", "Configuration saved")
+			ui.PrintStatus("info", "Run 'catops restart' (or 'catops start') to apply it")
+			ui.PrintSectionEnd()
+		},
+	}
+}
+
+// askWizardQuestions walks through the setup questions, updating cfg, and
+// returns the cloud token entered (empty when not connecting). It stops at the
+// first input error, leaving cfg partly updated.
+func askWizardQuestions(w *wizard, cfg *config.Config) (string, error) {
+	var err error
+	var value float64
+
+	ui.PrintSection("Collection")
+	if value, err = w.number("Collection interval in seconds", "interval",
+		cfg.CollectionPeriod().Seconds(), constants.MIN_COLLECTION_INTERVAL, 300); err != nil {
+		return "", err
+	}
+	cfg.CollectionInterval = int(value)
+	if cfg.DisplayName, err = w.ask("Display name (empty = hostname)", cfg.DisplayName, nil); err != nil {
+		return "", err
+	}
+	ui.PrintSectionEnd()
+
+	ui.PrintSection("Local Alerts")
+	ui.PrintStatus("info", "0 disables a threshold")
+	if value, err = w.number("Disk IOPS per device", "iops", float64(cfg.IOPSThreshold), 0, 1e9); err != nil {
+		return "", err
+	}
+	cfg.IOPSThreshold = int(value)
+	if cfg.ThroughputThreshold, err = w.number("Disk throughput per device (MB/s, or e.g. 1GB)", "throughput", cfg.ThroughputThreshold, 0, 1e9); err != nil {
+		return "", err
+	}
+	if cfg.FDThreshold, err = w.number("Open file descriptors (% of a process's limit)", "fd", cfg.FDThreshold, 0, 100); err != nil {
+		return "", err
+	}
+	if cfg.MemAvailableMin, err = w.number("Available memory floor (MB, or e.g. 4GB)", "mem_available_min", cfg.MemAvailableMin, 0, 1e9); err != nil {
+		return "", err
+	}
+	ui.PrintSectionEnd()
+
+	ui.PrintSection("Email Alerts")
+	useEmail, err := w.confirm("Send local alerts by email", cfg.SMTPHost != "")
+	if err != nil {
+		return "", err
+	}
+	if useEmail {
+		if cfg.SMTPHost, err = w.ask("SMTP relay host", cfg.SMTPHost, func(s string) error {
+			if s == "" {
+				return errors.New("a host is required")
+			}
+			return nil
+		}); err != nil {
+			return "", err
+		}
+		if value, err = w.number("SMTP port", "smtp_port", float64(cfg.SMTPPort), 1, 65535); err != nil {
+			return "", err
+		}
+		cfg.SMTPPort = int(value)
+		if cfg.SMTPUser, err = w.ask("SMTP user (empty = no authentication)", cfg.SMTPUser, nil); err != nil {
+			return "", err
+		}
+		if cfg.SMTPUser != "" {
+			if cfg.SMTPPass, err = w.secret("SMTP password", cfg.SMTPPass, 4, nil); err != nil {
+				return "", err
+			}
+		}
+		to, err := w.ask("Recipients, comma separated", strings.Join(cfg.AlertEmailTo, ","), func(s string) error {
+			for _, addr := range splitList(s) {
+				if !strings.Contains(addr, "@") {
+					return fmt.Errorf("%q is not an email address", addr)
+				}
+			}
+			if len(splitList(s)) == 0 {
+				return errors.New("at least one recipient is required")
+			}
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+		cfg.AlertEmailTo = splitList(to)
+	} else {
+		cfg.SMTPHost = ""
+		cfg.AlertEmailTo = nil
+	}
+	ui.PrintSectionEnd()
+
+	ui.PrintSection("Cloud Mode")
+	newToken := ""
+	connect, err := w.confirm("Connect to CatOps cloud (dashboard, Telegram notifications)", cfg.AuthToken != "")
+	if err != nil {
+		return "", err
+	}
+	if connect {
+		if newToken, err = w.secret("Auth token from "+constants.CATOPS_WEBSITE, cfg.AuthToken, 10, func(s string) error {
+			if s == "" {
+				return errors.New("a token is required")
+			}
+			return nil
+		}); err != nil {
+			return "", err
+		}
+	} else if cfg.AuthToken != "" {
+		ui.PrintStatus("info", "Keeping the current login, 'catops auth logout' disconnects this server")
+	}
+	ui.PrintSectionEnd()
+
+	return newToken, nil
+}
+
+// splitList splits a comma separated answer, dropping empty entries
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
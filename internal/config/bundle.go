@@ -0,0 +1,102 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// hostKeys are per-host or secret settings never written to, or taken from,
+// a config bundle: credentials, server identity and runtime state
+var hostKeys = map[string]bool{
+	"auth_token":            true,
+	"server_id":             true,
+	"display_name":          true,
+	"smtp_pass":             true,
+	"http_headers":          true,
+	"http_headers_hosts":    true,
+	"http_headers_insecure": true,
+	"maintenance_until":     true,
+}
+
+// ExportBundle renders the portable part of the configuration (everything but
+// hostKeys) in config file format, for templating a fleet with ImportBundle
+func (cfg *Config) ExportBundle() string {
+	portable := *cfg
+	portable.AuthToken = ""
+	portable.ServerID = ""
+	portable.DisplayName = ""
+	portable.SMTPPass = ""
+	portable.HTTPHeaders = nil
+	portable.HTTPHeadersHosts = nil
+	portable.HTTPHeadersInsecure = false
+	portable.MaintenanceUntil = 0
+	portable.secretRefs = nil
+
+	return "# CatOps config bundle (no credentials or host identity)\n" +
+		"# Apply with: catops config import <file>\n\n" +
+		strings.TrimLeft(portable.render(), "\n") + "\n"
+}
+
+// ImportBundle reads a bundle written by ExportBundle and applies it on top of
+// defaults, keeping the host settings of current. Unknown keys are an error so a
+// typo doesn't silently fall back to a default; host keys in the bundle are
+// ignored and returned so the caller can report them.
+func ImportBundle(path string, current *Config) (*Config, []string, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil {
+		return nil, nil, newFileError("read", path, err)
+	}
+
+	known := make(map[string]bool)
+	t := reflect.TypeOf(Config{})
+	for i := 0; i < t.NumField(); i++ {
+		if key := t.Field(i).Tag.Get("mapstructure"); key != "" {
+			known[key] = true
+		}
+	}
+
+	var unknown, ignored []string
+	seen := make(map[string]bool)
+	for _, key := range v.AllKeys() {
+		key, _, _ = strings.Cut(key, ".") // server_labels.<name>
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		switch {
+		case !known[key]:
+			unknown = append(unknown, key)
+		case hostKeys[key]:
+			ignored = append(ignored, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, nil, fmt.Errorf("unknown settings in %s: %s", path, strings.Join(unknown, ", "))
+	}
+
+	cfg := DefaultConfig()
+	if err := v.Unmarshal(cfg); err != nil {
+		return nil, nil, &FileError{Op: "read", Path: path, Kind: ErrConfigInvalid, Err: err}
+	}
+
+	cfg.AuthToken = current.AuthToken
+	cfg.ServerID = current.ServerID
+	cfg.DisplayName = current.DisplayName
+	cfg.SMTPPass = current.SMTPPass
+	cfg.HTTPHeaders = current.HTTPHeaders
+	cfg.HTTPHeadersHosts = current.HTTPHeadersHosts
+	cfg.HTTPHeadersInsecure = current.HTTPHeadersInsecure
+	cfg.MaintenanceUntil = current.MaintenanceUntil
+	cfg.secretRefs = current.secretRefs
+	cfg.determineMode()
+
+	sort.Strings(ignored)
+	return cfg, ignored, nil
+}
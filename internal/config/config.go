@@ -3,6 +3,10 @@ package config
 import (
 	"fmt"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	constants "catops/config"
 
@@ -15,13 +19,173 @@ type Config struct {
 	ServerID  string `mapstructure:"server_id"`
 	Mode      string `mapstructure:"mode"`
 
+	// Server identity overrides for dashboards (fall back to the real hostname)
+	DisplayName  string            `mapstructure:"display_name"`
+	ServerLabels map[string]string `mapstructure:"server_labels"`
+	Environment  string            `mapstructure:"environment"` // e.g. prod, staging
+	Region       string            `mapstructure:"region"`      // e.g. eu-west-1
+
 	// Monitoring configuration
-	CollectionInterval int `mapstructure:"collection_interval"` // in seconds, default 15
+	CollectionInterval int `mapstructure:"collection_interval"`  // in seconds, default 15
+	OTLPExportInterval int `mapstructure:"otlp_export_interval"` // in seconds, 0 = same as collection_interval
+	AlertCheckInterval int `mapstructure:"alert_check_interval"` // in seconds, default 60
+	ExportStaleAfter   int `mapstructure:"export_stale_after"`   // seconds without a successful export before alerting, 0 = default
+
+	// Network timeouts in seconds (0 = default)
+	HTTPTimeout   int `mapstructure:"http_timeout"`   // backend API requests, default 10
+	UploadTimeout int `mapstructure:"upload_timeout"` // OTLP metric/log exports, default 30
+
+	// User-Agent for every outbound request, and extra headers for proxies and
+	// WAFs that require them ("" / empty = defaults). The headers only go to
+	// http_headers_hosts (empty = the CatOps backend), and never over plain
+	// http:// unless http_headers_insecure is set.
+	UserAgent           string            `mapstructure:"user_agent"`
+	HTTPHeaders         map[string]string `mapstructure:"http_headers"`
+	HTTPHeadersHosts    []string          `mapstructure:"http_headers_hosts"`
+	HTTPHeadersInsecure bool              `mapstructure:"http_headers_insecure"`
+
+	// OTLP endpoints metrics are exported to (a single value or a list, default CatOps cloud)
+	OTLPEndpoints []string `mapstructure:"otlp_endpoint"`
+
+	// OTLP instrument groups exported (system, cpu_cores, memory, disk, ...; empty = all)
+	EnabledMetricGroups []string `mapstructure:"enabled_metric_groups"`
+
+	// Local alert thresholds, warning and critical tiers (0 = disabled)
+	IOPSThreshold        int     `mapstructure:"iops_threshold"`        // read+write ops/s per device
+	IOPSCritical         int     `mapstructure:"iops_critical"`         // critical tier for iops_threshold
+	ThroughputThreshold  float64 `mapstructure:"throughput_threshold"`  // read+write MB/s per device
+	ThroughputCritical   float64 `mapstructure:"throughput_critical"`   // critical tier for throughput_threshold
+	FDThreshold          float64 `mapstructure:"fd_threshold"`          // open FDs as % of a process's nofile limit
+	FDCritical           float64 `mapstructure:"fd_critical"`           // critical tier for fd_threshold (default 95 when fd_threshold is set)
+	ProcessIOThreshold   float64 `mapstructure:"process_io_threshold"`  // read+write MB/s per process
+	NetRateThreshold     float64 `mapstructure:"net_rate_threshold"`    // receive or send MB/s per interface
+	NetRatePercent       float64 `mapstructure:"net_rate_percent"`      // receive or send rate as % of the link speed (when known)
+	MemAvailableMin      float64 `mapstructure:"mem_available_min"`     // available memory floor in MB
+	TemperatureThreshold float64 `mapstructure:"temperature_threshold"` // °C on any hardware sensor, also enables the throttling alert
+	SustainedDuration    int     `mapstructure:"sustained_duration"`    // seconds a violation must last before alerting (0 = immediately)
+	AlertWarmUp          int     `mapstructure:"alert_warmup"`          // seconds after start without alerts (0 = one collection interval)
+
+	// Executable run for every fired alert (JSON on stdin), killed after alert_hook_timeout seconds
+	AlertHook        string `mapstructure:"alert_hook"`
+	AlertHookTimeout int    `mapstructure:"alert_hook_timeout"`
+
+	// Email alerts through an SMTP relay (sent when smtp_host and alert_email_to are set)
+	SMTPHost     string   `mapstructure:"smtp_host"`
+	SMTPPort     int      `mapstructure:"smtp_port"`
+	SMTPUser     string   `mapstructure:"smtp_user"`
+	SMTPPass     string   `mapstructure:"smtp_pass"`
+	SMTPFrom     string   `mapstructure:"smtp_from"` // default: smtp_user when it is an address, else catops@<hostname>
+	AlertEmailTo []string `mapstructure:"alert_email_to"`
+
+	// Append a sparkline of the last values and their direction to local alert messages
+	AlertTrend bool `mapstructure:"alert_trend"`
+
+	// Capture full cmdline, cwd and owner (and optionally the environment) of a
+	// process when a process-level alert fires, included in the alert payload
+	CaptureProcessDetailOnAlert bool `mapstructure:"capture_process_detail_on_alert"`
+	CaptureProcessEnv           bool `mapstructure:"capture_process_env"`
+
+	// TLS certificates to watch: PEM file paths and/or host:port endpoints
+	CertPaths             []string `mapstructure:"cert_paths"`
+	CertExpiryWarningDays int      `mapstructure:"cert_expiry_warning_days"`
+
+	// Process name patterns (globs, or /regex/) hidden from / always kept in the top list
+	ProcessExcludes []string `mapstructure:"process_excludes"`
+	ProcessIncludes []string `mapstructure:"process_includes"`
+
+	// Top processes enriched with exe, user, PPID, threads and IO (0 = none)
+	ProcessDetailLimit int `mapstructure:"process_detail_limit"`
+
+	// Free space in MB catops leaves on a filesystem when writing its export
+	// buffer, history, alert log and snapshots (0 disables the check)
+	MinFreeDisk int `mapstructure:"min_free_disk"`
+
+	// When not running as root, don't attempt IO and open fd reads of other
+	// users' processes that the kernel refuses anyway
+	SkipPrivilegedMetrics bool `mapstructure:"skip_privileged_metrics"`
+
+	// Container label keys overriding the service name and supplying the environment ("" = unused)
+	ContainerServiceLabel string `mapstructure:"container_service_label"`
+	ContainerEnvLabel     string `mapstructure:"container_env_label"`
+
+	// systemd units whose state is tracked and alerted on (failed units are always reported)
+	SystemdUnits []string `mapstructure:"systemd_units"`
+
+	// Services that must always be detected (name or type, e.g. nginx, postgres),
+	// a missing one raises a critical alert
+	RequiredServices []string `mapstructure:"required_services"`
+
+	// Seconds a sent log line is remembered so re-reading a log tail doesn't resend it (0 = disabled)
+	LogDedupWindow int `mapstructure:"log_dedup_window"`
+
+	// Log lines one container or service may send per collection, the rest are
+	// dropped behind a marker line (0 = unlimited)
+	LogRateLimit int `mapstructure:"log_rate_limit"`
+
+	// Log sources never read: container names or IDs, service names or log file
+	// paths (managed by 'catops logs sources disable/enable')
+	LogSourcesDisabled []string `mapstructure:"log_sources_disabled"`
+
+	// Filesystem types excluded from disk metrics ("fuse.*" matches by prefix)
+	SkipFstypes []string `mapstructure:"skip_fstypes"`
+
+	// Mount points monitored exclusively, whatever their type (empty = all but skipped ones)
+	DiskMounts []string `mapstructure:"disk_mounts"`
+
+	// Interfaces in the summary network totals: "all" (default, every interface
+	// but loopback and veth) or "primary" (the interface of the primary IP)
+	NetworkSummary string `mapstructure:"network_summary"`
+
+	// Ports counted as HTTPS connections; inbound mode counts connections to these
+	// local ports (our own services) instead of outbound ones to remote ports
+	HTTPSPorts   []int `mapstructure:"https_ports"`
+	HTTPSInbound bool  `mapstructure:"https_inbound"`
+
+	// Feature toggles (all enabled by default)
+	AnalyticsEnabled bool `mapstructure:"analytics_enabled"` // service events, server info and update checks
+	OTLPEnabled      bool `mapstructure:"otlp_enabled"`      // OTLP metrics export
+
+	// Where the daemon writes its own log (empty = constants.LOG_FILE)
+	LogFile string `mapstructure:"log_file"`
+
+	// Sign config.yaml with a machine-local HMAC and verify it on load
+	ConfigIntegrity bool `mapstructure:"config_integrity"`
+
+	// Alert notifications are suppressed until this unix timestamp (0 = no maintenance)
+	MaintenanceUntil int64 `mapstructure:"maintenance_until"`
+
+	// secretRefs are the secret settings read from a file or credential (secrets.go)
+	secretRefs map[string]secretRef
+}
+
+// DefaultConfig returns a configuration populated with default values
+func DefaultConfig() *Config {
+	return &Config{
+		Mode:                  constants.MODE_LOCAL,
+		CollectionInterval:    constants.DEFAULT_COLLECTION_INTERVAL,
+		AlertCheckInterval:    constants.DEFAULT_ALERT_CHECK_INTERVAL,
+		HTTPTimeout:           constants.DEFAULT_HTTP_TIMEOUT,
+		UploadTimeout:         constants.DEFAULT_UPLOAD_TIMEOUT,
+		SkipFstypes:           constants.DEFAULT_SKIP_FSTYPES,
+		HTTPSPorts:            constants.DEFAULT_HTTPS_PORTS,
+		CertExpiryWarningDays: constants.DEFAULT_CERT_EXPIRY_WARNING_DAYS,
+		AlertHookTimeout:      constants.DEFAULT_ALERT_HOOK_TIMEOUT,
+		SMTPPort:              constants.DEFAULT_SMTP_PORT,
+		LogDedupWindow:        constants.DEFAULT_LOG_DEDUP_WINDOW,
+		ProcessDetailLimit:    constants.DEFAULT_PROCESS_DETAIL_LIMIT,
+		MinFreeDisk:           constants.DEFAULT_MIN_FREE_DISK_MB,
+		ContainerServiceLabel: constants.DEFAULT_CONTAINER_SERVICE_LABEL,
+		ContainerEnvLabel:     constants.DEFAULT_CONTAINER_ENV_LABEL,
+		AnalyticsEnabled:      true,
+		OTLPEnabled:           true,
+	}
 }
 
 // determineMode automatically sets the operation mode based on tokens
 func (cfg *Config) determineMode() {
-	if cfg.AuthToken != "" && cfg.ServerID != "" {
+	// A deferred token is set in the service, which runs in cloud mode
+	hasToken := cfg.AuthToken != "" || cfg.SecretDeferred("auth_token")
+	if hasToken && cfg.ServerID != "" {
 		cfg.Mode = constants.MODE_CLOUD
 	} else {
 		cfg.Mode = constants.MODE_LOCAL
@@ -38,6 +202,148 @@ func (cfg *Config) IsLocalMode() bool {
 	return cfg.Mode == constants.MODE_LOCAL
 }
 
+// DisplayHostname returns the configured display name, or the real hostname when unset
+func (cfg *Config) DisplayHostname() string {
+	if cfg.DisplayName != "" {
+		return cfg.DisplayName
+	}
+	hostname, _ := os.Hostname()
+	if hostname == "" {
+		hostname = "unknown"
+	}
+	return hostname
+}
+
+// CollectionPeriod returns how often metrics are collected
+func (cfg *Config) CollectionPeriod() time.Duration {
+	if cfg.CollectionInterval <= 0 {
+		return constants.DEFAULT_COLLECTION_INTERVAL * time.Second
+	}
+	return time.Duration(cfg.CollectionInterval) * time.Second
+}
+
+// ExportPeriod returns how often the OTel reader exports metrics.
+// Exporting faster than collecting would only resend the same cached snapshot,
+// so the result is never shorter than CollectionPeriod.
+func (cfg *Config) ExportPeriod() time.Duration {
+	if cfg.OTLPExportInterval <= 0 {
+		return cfg.CollectionPeriod()
+	}
+	export := time.Duration(cfg.OTLPExportInterval) * time.Second
+	if export < cfg.CollectionPeriod() {
+		return cfg.CollectionPeriod()
+	}
+	return export
+}
+
+// AlertPeriod returns how often local alert thresholds are evaluated.
+// Like ExportPeriod it is never shorter than CollectionPeriod.
+func (cfg *Config) AlertPeriod() time.Duration {
+	alert := time.Duration(cfg.AlertCheckInterval) * time.Second
+	if cfg.AlertCheckInterval <= 0 {
+		alert = constants.DEFAULT_ALERT_CHECK_INTERVAL * time.Second
+	}
+	if alert < cfg.CollectionPeriod() {
+		return cfg.CollectionPeriod()
+	}
+	return alert
+}
+
+// ExportStalePeriod returns how long metrics export may fail before the
+// daemon raises a local alert: export_stale_after, else three export periods
+// but at least the default
+func (cfg *Config) ExportStalePeriod() time.Duration {
+	if cfg.ExportStaleAfter > 0 {
+		return time.Duration(cfg.ExportStaleAfter) * time.Second
+	}
+	return max(3*cfg.ExportPeriod(), constants.DEFAULT_EXPORT_STALE_AFTER*time.Second)
+}
+
+// AlertEmailFrom returns the sender address for email alerts
+func (cfg *Config) AlertEmailFrom() string {
+	if cfg.SMTPFrom != "" {
+		return cfg.SMTPFrom
+	}
+	if strings.Contains(cfg.SMTPUser, "@") {
+		return cfg.SMTPUser
+	}
+	hostname, _ := os.Hostname()
+	return "catops@" + hostname
+}
+
+// AlertWarmUpPeriod returns how long after start local alerts are suppressed
+// while rates and CPU deltas have no baseline (one collection interval when unset)
+func (cfg *Config) AlertWarmUpPeriod() time.Duration {
+	if cfg.AlertWarmUp <= 0 {
+		return cfg.CollectionPeriod()
+	}
+	return time.Duration(cfg.AlertWarmUp) * time.Second
+}
+
+// AlertChannels lists where local alerts are delivered besides the daemon log
+// and alert history: the backend (which notifies Telegram), hook and email
+func (cfg *Config) AlertChannels() []string {
+	var channels []string
+	if cfg.AuthToken != "" && cfg.ServerID != "" && cfg.AnalyticsEnabled {
+		channels = append(channels, "backend")
+	}
+	if cfg.AlertHook != "" {
+		channels = append(channels, "hook")
+	}
+	if cfg.SMTPHost != "" && len(cfg.AlertEmailTo) > 0 {
+		channels = append(channels, "email")
+	}
+	return channels
+}
+
+// AlertHookPeriod returns how long alert_hook may run (default when unset)
+func (cfg *Config) AlertHookPeriod() time.Duration {
+	if cfg.AlertHookTimeout <= 0 {
+		return constants.DEFAULT_ALERT_HOOK_TIMEOUT * time.Second
+	}
+	return time.Duration(cfg.AlertHookTimeout) * time.Second
+}
+
+// RequestTimeout returns the timeout for backend API requests
+func (cfg *Config) RequestTimeout() time.Duration {
+	if cfg.HTTPTimeout <= 0 {
+		return constants.DEFAULT_HTTP_TIMEOUT * time.Second
+	}
+	return time.Duration(cfg.HTTPTimeout) * time.Second
+}
+
+// ExportTimeout returns the timeout for a single OTLP export. It is never
+// shorter than RequestTimeout, since bulk uploads are the larger requests.
+func (cfg *Config) ExportTimeout() time.Duration {
+	upload := time.Duration(cfg.UploadTimeout) * time.Second
+	if cfg.UploadTimeout <= 0 {
+		upload = constants.DEFAULT_UPLOAD_TIMEOUT * time.Second
+	}
+	if upload < cfg.RequestTimeout() {
+		return cfg.RequestTimeout()
+	}
+	return upload
+}
+
+// OTLPEndpointList returns the configured OTLP endpoints, or the CatOps backend when unset
+func (cfg *Config) OTLPEndpointList() []string {
+	var endpoints []string
+	for _, e := range cfg.OTLPEndpoints {
+		if e = strings.TrimSpace(e); e != "" {
+			endpoints = append(endpoints, e)
+		}
+	}
+	if len(endpoints) == 0 {
+		return []string{constants.OTLP_ENDPOINT}
+	}
+	return endpoints
+}
+
+// InMaintenance reports whether a maintenance window is currently active
+func (cfg *Config) InMaintenance() bool {
+	return cfg.MaintenanceUntil > time.Now().Unix()
+}
+
 // getHomeDir returns the user's home directory with fallback for systemd
 // systemd services don't set HOME environment variable by default
 func getHomeDir() string {
@@ -56,7 +362,20 @@ func getHomeDir() string {
 	return home
 }
 
-// LoadConfig loads configuration from file and environment
+// ConfigDir returns the CatOps configuration directory (~/.catops)
+func ConfigDir() string {
+	return getHomeDir() + constants.CONFIG_DIR_NAME
+}
+
+// FileVersion identifies the current version of config.yaml by mtime and size,
+// so a long-running process can skip re-reading a file that didn't change
+func FileVersion() string {
+	return fileState(ConfigDir() + "/config.yaml")
+}
+
+// LoadConfig loads configuration from file and environment. Without a config
+// file the defaults are returned; a file that can't be read or parsed is a
+// *FileError matching ErrConfigPermission or ErrConfigInvalid.
 func LoadConfig() (*Config, error) {
 	viper.SetConfigName("config")
 	viper.SetConfigType("yaml")
@@ -65,15 +384,40 @@ func LoadConfig() (*Config, error) {
 
 	// Set defaults for monitoring configuration
 	viper.SetDefault("collection_interval", constants.DEFAULT_COLLECTION_INTERVAL)
+	viper.SetDefault("alert_check_interval", constants.DEFAULT_ALERT_CHECK_INTERVAL)
+	viper.SetDefault("http_timeout", constants.DEFAULT_HTTP_TIMEOUT)
+	viper.SetDefault("upload_timeout", constants.DEFAULT_UPLOAD_TIMEOUT)
+	viper.SetDefault("log_dedup_window", constants.DEFAULT_LOG_DEDUP_WINDOW)
+	viper.SetDefault("process_detail_limit", constants.DEFAULT_PROCESS_DETAIL_LIMIT)
+	viper.SetDefault("min_free_disk", constants.DEFAULT_MIN_FREE_DISK_MB)
+	viper.SetDefault("container_service_label", constants.DEFAULT_CONTAINER_SERVICE_LABEL)
+	viper.SetDefault("container_env_label", constants.DEFAULT_CONTAINER_ENV_LABEL)
+	viper.SetDefault("skip_fstypes", constants.DEFAULT_SKIP_FSTYPES)
+	viper.SetDefault("https_ports", constants.DEFAULT_HTTPS_PORTS)
+	viper.SetDefault("cert_expiry_warning_days", constants.DEFAULT_CERT_EXPIRY_WARNING_DAYS)
+	viper.SetDefault("alert_hook_timeout", constants.DEFAULT_ALERT_HOOK_TIMEOUT)
+	viper.SetDefault("smtp_port", constants.DEFAULT_SMTP_PORT)
+	viper.SetDefault("analytics_enabled", true)
+	viper.SetDefault("otlp_enabled", true)
 
-	// Read config file
-	viper.ReadInConfig()
+	// Read config file, running on defaults when there is none
+	if err := viper.ReadInConfig(); err != nil && !isConfigNotFound(err) {
+		return nil, newFileError("read", viper.ConfigFileUsed(), err)
+	}
 
 	var cfg Config
 	if err := viper.Unmarshal(&cfg); err != nil {
-		return nil, err
+		return nil, &FileError{Op: "read", Path: viper.ConfigFileUsed(), Kind: ErrConfigInvalid, Err: err}
 	}
 
+	// Detect out-of-band edits (only for the file SaveConfig manages)
+	if configFile := viper.ConfigFileUsed(); configFile != "" && configFile == ConfigDir()+"/config.yaml" {
+		verifyConfigChanged(configFile, cfg.ConfigIntegrity)
+	}
+
+	// Secrets may be references to files or systemd credentials
+	cfg.resolveSecrets()
+
 	// Determine operation mode
 	cfg.determineMode()
 
@@ -84,41 +428,319 @@ func LoadConfig() (*Config, error) {
 func SaveConfig(cfg *Config) error {
 	configDir := getHomeDir() + "/.catops"
 	if err := os.MkdirAll(configDir, 0755); err != nil {
-		return err
+		return newFileError("write", configDir, err)
 	}
 
-	// Build config content with only non-empty values
+	configContent := cfg.render()
+
+	// Write to file with secure permissions (0600 - only owner can read/write)
+	configFile := configDir + "/config.yaml"
+	err := os.WriteFile(configFile, []byte(configContent), 0600)
+	if err != nil {
+		return newFileError("write", configFile, err)
+	}
+
+	// Keep the integrity signature in sync with what we just wrote
+	if cfg.ConfigIntegrity {
+		return signConfig(configFile, []byte(configContent))
+	}
+	os.Remove(configFile + integritySigSuffix)
+	return nil
+}
+
+// render builds the config file content, with only non-empty / non-default values
+func (cfg *Config) render() string {
 	var configLines []string
 
 	// Cloud mode settings
-	if cfg.AuthToken != "" {
-		configLines = append(configLines, fmt.Sprintf("auth_token: %s", cfg.AuthToken))
+	if token := cfg.storedSecret("auth_token", cfg.AuthToken); token != "" {
+		configLines = append(configLines, fmt.Sprintf("auth_token: %s", token))
 	}
 	if cfg.ServerID != "" {
 		configLines = append(configLines, fmt.Sprintf("server_id: %s", cfg.ServerID))
 	}
 
+	// Server identity
+	if cfg.DisplayName != "" || len(cfg.ServerLabels) > 0 || cfg.Environment != "" || cfg.Region != "" {
+		configLines = append(configLines, "")
+		configLines = append(configLines, "# Server identity")
+		if cfg.DisplayName != "" {
+			configLines = append(configLines, fmt.Sprintf("display_name: %q", cfg.DisplayName))
+		}
+		if cfg.Environment != "" {
+			configLines = append(configLines, fmt.Sprintf("environment: %q", cfg.Environment))
+		}
+		if cfg.Region != "" {
+			configLines = append(configLines, fmt.Sprintf("region: %q", cfg.Region))
+		}
+		if len(cfg.ServerLabels) > 0 {
+			configLines = append(configLines, "server_labels:")
+			keys := make([]string, 0, len(cfg.ServerLabels))
+			for k := range cfg.ServerLabels {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				configLines = append(configLines, fmt.Sprintf("  %s: %q", k, cfg.ServerLabels[k]))
+			}
+		}
+	}
+
 	// Monitoring configuration (save if non-default)
 	if cfg.CollectionInterval > 0 && cfg.CollectionInterval != constants.DEFAULT_COLLECTION_INTERVAL {
 		configLines = append(configLines, "")
 		configLines = append(configLines, "# Monitoring configuration")
 		configLines = append(configLines, fmt.Sprintf("collection_interval: %d", cfg.CollectionInterval))
 	}
+	if cfg.OTLPExportInterval > 0 {
+		configLines = append(configLines, fmt.Sprintf("otlp_export_interval: %d", cfg.OTLPExportInterval))
+	}
+	if cfg.AlertCheckInterval > 0 && cfg.AlertCheckInterval != constants.DEFAULT_ALERT_CHECK_INTERVAL {
+		configLines = append(configLines, fmt.Sprintf("alert_check_interval: %d", cfg.AlertCheckInterval))
+	}
+	if cfg.ExportStaleAfter > 0 {
+		configLines = append(configLines, fmt.Sprintf("export_stale_after: %d", cfg.ExportStaleAfter))
+	}
+	if cfg.HTTPTimeout > 0 && cfg.HTTPTimeout != constants.DEFAULT_HTTP_TIMEOUT {
+		configLines = append(configLines, fmt.Sprintf("http_timeout: %d", cfg.HTTPTimeout))
+	}
+	if cfg.UploadTimeout > 0 && cfg.UploadTimeout != constants.DEFAULT_UPLOAD_TIMEOUT {
+		configLines = append(configLines, fmt.Sprintf("upload_timeout: %d", cfg.UploadTimeout))
+	}
+	if cfg.UserAgent != "" {
+		configLines = append(configLines, fmt.Sprintf("user_agent: %q", cfg.UserAgent))
+	}
+	if len(cfg.HTTPHeaders) > 0 {
+		configLines = append(configLines, "http_headers:")
+		keys := make([]string, 0, len(cfg.HTTPHeaders))
+		for k := range cfg.HTTPHeaders {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			configLines = append(configLines, fmt.Sprintf("  %s: %q", k, cfg.HTTPHeaders[k]))
+		}
+	}
+	if len(cfg.HTTPHeadersHosts) > 0 {
+		configLines = append(configLines, fmt.Sprintf("http_headers_hosts: [%s]", strings.Join(quoteAll(cfg.HTTPHeadersHosts), ", ")))
+	}
+	if cfg.HTTPHeadersInsecure {
+		configLines = append(configLines, "http_headers_insecure: true")
+	}
+	if endpoints := cfg.OTLPEndpointList(); len(endpoints) > 1 || endpoints[0] != constants.OTLP_ENDPOINT {
+		configLines = append(configLines, fmt.Sprintf("otlp_endpoint: [%s]", strings.Join(quoteAll(endpoints), ", ")))
+	}
+	if len(cfg.EnabledMetricGroups) > 0 {
+		configLines = append(configLines, fmt.Sprintf("enabled_metric_groups: [%s]", strings.Join(quoteAll(cfg.EnabledMetricGroups), ", ")))
+	}
+	if cfg.SkipFstypes != nil && strings.Join(cfg.SkipFstypes, ",") != strings.Join(constants.DEFAULT_SKIP_FSTYPES, ",") {
+		configLines = append(configLines, fmt.Sprintf("skip_fstypes: [%s]", strings.Join(quoteAll(cfg.SkipFstypes), ", ")))
+	}
+	if len(cfg.DiskMounts) > 0 {
+		configLines = append(configLines, fmt.Sprintf("disk_mounts: [%s]", strings.Join(quoteAll(cfg.DiskMounts), ", ")))
+	}
+	if cfg.NetworkSummary != "" && cfg.NetworkSummary != "all" {
+		configLines = append(configLines, fmt.Sprintf("network_summary: %q", cfg.NetworkSummary))
+	}
+	if cfg.HTTPSPorts != nil && fmt.Sprint(cfg.HTTPSPorts) != fmt.Sprint(constants.DEFAULT_HTTPS_PORTS) {
+		ports := make([]string, len(cfg.HTTPSPorts))
+		for i, p := range cfg.HTTPSPorts {
+			ports[i] = strconv.Itoa(p)
+		}
+		configLines = append(configLines, fmt.Sprintf("https_ports: [%s]", strings.Join(ports, ", ")))
+	}
+	if cfg.HTTPSInbound {
+		configLines = append(configLines, "https_inbound: true")
+	}
 
-	// Join lines with newline
-	configContent := ""
-	for i, line := range configLines {
-		configContent += line
-		if i < len(configLines)-1 {
-			configContent += "\n"
+	// Process filters (save only when set)
+	if len(cfg.ProcessExcludes) > 0 {
+		configLines = append(configLines, fmt.Sprintf("process_excludes: [%s]", strings.Join(quoteAll(cfg.ProcessExcludes), ", ")))
+	}
+	if len(cfg.ProcessIncludes) > 0 {
+		configLines = append(configLines, fmt.Sprintf("process_includes: [%s]", strings.Join(quoteAll(cfg.ProcessIncludes), ", ")))
+	}
+	if cfg.ProcessDetailLimit != constants.DEFAULT_PROCESS_DETAIL_LIMIT {
+		configLines = append(configLines, fmt.Sprintf("process_detail_limit: %d", cfg.ProcessDetailLimit))
+	}
+	if cfg.MinFreeDisk != constants.DEFAULT_MIN_FREE_DISK_MB {
+		configLines = append(configLines, fmt.Sprintf("min_free_disk: %d", cfg.MinFreeDisk))
+	}
+	if cfg.SkipPrivilegedMetrics {
+		configLines = append(configLines, "skip_privileged_metrics: true")
+	}
+
+	// Container label keys (save if non-default, "" disables)
+	if cfg.ContainerServiceLabel != constants.DEFAULT_CONTAINER_SERVICE_LABEL {
+		configLines = append(configLines, fmt.Sprintf("container_service_label: %q", cfg.ContainerServiceLabel))
+	}
+	if cfg.ContainerEnvLabel != constants.DEFAULT_CONTAINER_ENV_LABEL {
+		configLines = append(configLines, fmt.Sprintf("container_env_label: %q", cfg.ContainerEnvLabel))
+	}
+
+	// Log deduplication window (save if non-default, 0 disables)
+	if cfg.LogDedupWindow != constants.DEFAULT_LOG_DEDUP_WINDOW {
+		configLines = append(configLines, fmt.Sprintf("log_dedup_window: %d", cfg.LogDedupWindow))
+	}
+	if cfg.LogRateLimit > 0 {
+		configLines = append(configLines, fmt.Sprintf("log_rate_limit: %d", cfg.LogRateLimit))
+	}
+	if len(cfg.LogSourcesDisabled) > 0 {
+		configLines = append(configLines, fmt.Sprintf("log_sources_disabled: [%s]", strings.Join(quoteAll(cfg.LogSourcesDisabled), ", ")))
+	}
+
+	// Daemon log location (save only when moved off the default)
+	if cfg.LogFile != "" {
+		configLines = append(configLines, fmt.Sprintf("log_file: %q", cfg.LogFile))
+	}
+
+	// Tracked systemd units (save only when set)
+	if len(cfg.SystemdUnits) > 0 {
+		configLines = append(configLines, fmt.Sprintf("systemd_units: [%s]", strings.Join(quoteAll(cfg.SystemdUnits), ", ")))
+	}
+
+	// Required services (save only when set)
+	if len(cfg.RequiredServices) > 0 {
+		configLines = append(configLines, fmt.Sprintf("required_services: [%s]", strings.Join(quoteAll(cfg.RequiredServices), ", ")))
+	}
+
+	// Alert thresholds (save only when set)
+	if cfg.IOPSThreshold > 0 || cfg.ThroughputThreshold > 0 || cfg.FDThreshold > 0 ||
+		cfg.IOPSCritical > 0 || cfg.ThroughputCritical > 0 || cfg.FDCritical > 0 ||
+		cfg.ProcessIOThreshold > 0 || cfg.NetRateThreshold > 0 || cfg.NetRatePercent > 0 || cfg.MemAvailableMin > 0 || cfg.TemperatureThreshold > 0 || cfg.SustainedDuration > 0 || cfg.AlertWarmUp > 0 || cfg.AlertTrend ||
+		cfg.CaptureProcessDetailOnAlert {
+		configLines = append(configLines, "")
+		configLines = append(configLines, "# Alert thresholds")
+		if cfg.IOPSThreshold > 0 {
+			configLines = append(configLines, fmt.Sprintf("iops_threshold: %d", cfg.IOPSThreshold))
+		}
+		if cfg.IOPSCritical > 0 {
+			configLines = append(configLines, fmt.Sprintf("iops_critical: %d", cfg.IOPSCritical))
+		}
+		if cfg.ThroughputThreshold > 0 {
+			configLines = append(configLines, fmt.Sprintf("throughput_threshold: %g", cfg.ThroughputThreshold))
+		}
+		if cfg.ThroughputCritical > 0 {
+			configLines = append(configLines, fmt.Sprintf("throughput_critical: %g", cfg.ThroughputCritical))
+		}
+		if cfg.FDThreshold > 0 {
+			configLines = append(configLines, fmt.Sprintf("fd_threshold: %g", cfg.FDThreshold))
+		}
+		if cfg.FDCritical > 0 {
+			configLines = append(configLines, fmt.Sprintf("fd_critical: %g", cfg.FDCritical))
+		}
+		if cfg.ProcessIOThreshold > 0 {
+			configLines = append(configLines, fmt.Sprintf("process_io_threshold: %g", cfg.ProcessIOThreshold))
+		}
+		if cfg.NetRateThreshold > 0 {
+			configLines = append(configLines, fmt.Sprintf("net_rate_threshold: %g", cfg.NetRateThreshold))
+		}
+		if cfg.NetRatePercent > 0 {
+			configLines = append(configLines, fmt.Sprintf("net_rate_percent: %g", cfg.NetRatePercent))
+		}
+		if cfg.MemAvailableMin > 0 {
+			configLines = append(configLines, fmt.Sprintf("mem_available_min: %g", cfg.MemAvailableMin))
+		}
+		if cfg.TemperatureThreshold > 0 {
+			configLines = append(configLines, fmt.Sprintf("temperature_threshold: %g", cfg.TemperatureThreshold))
+		}
+		if cfg.SustainedDuration > 0 {
+			configLines = append(configLines, fmt.Sprintf("sustained_duration: %d", cfg.SustainedDuration))
+		}
+		if cfg.AlertWarmUp > 0 {
+			configLines = append(configLines, fmt.Sprintf("alert_warmup: %d", cfg.AlertWarmUp))
+		}
+		if cfg.AlertTrend {
+			configLines = append(configLines, "alert_trend: true")
+		}
+		if cfg.CaptureProcessDetailOnAlert {
+			configLines = append(configLines, "capture_process_detail_on_alert: true")
+			if cfg.CaptureProcessEnv {
+				configLines = append(configLines, "capture_process_env: true")
+			}
 		}
 	}
 
-	// Write to file with secure permissions (0600 - only owner can read/write)
-	configFile := configDir + "/config.yaml"
-	err := os.WriteFile(configFile, []byte(configContent), 0600)
-	if err != nil {
-		return err
+	// Alert hook (save only when configured)
+	if cfg.AlertHook != "" {
+		configLines = append(configLines, "")
+		configLines = append(configLines, "# Alert hook")
+		configLines = append(configLines, fmt.Sprintf("alert_hook: %q", cfg.AlertHook))
+		if cfg.AlertHookTimeout > 0 && cfg.AlertHookTimeout != constants.DEFAULT_ALERT_HOOK_TIMEOUT {
+			configLines = append(configLines, fmt.Sprintf("alert_hook_timeout: %d", cfg.AlertHookTimeout))
+		}
 	}
-	return nil
+
+	// Email alerts (save only when configured)
+	if cfg.SMTPHost != "" || len(cfg.AlertEmailTo) > 0 {
+		configLines = append(configLines, "")
+		configLines = append(configLines, "# Email alerts")
+		if cfg.SMTPHost != "" {
+			configLines = append(configLines, fmt.Sprintf("smtp_host: %q", cfg.SMTPHost))
+		}
+		if cfg.SMTPPort > 0 && cfg.SMTPPort != constants.DEFAULT_SMTP_PORT {
+			configLines = append(configLines, fmt.Sprintf("smtp_port: %d", cfg.SMTPPort))
+		}
+		if cfg.SMTPUser != "" {
+			configLines = append(configLines, fmt.Sprintf("smtp_user: %q", cfg.SMTPUser))
+		}
+		if pass := cfg.storedSecret("smtp_pass", cfg.SMTPPass); pass != "" {
+			configLines = append(configLines, fmt.Sprintf("smtp_pass: %q", pass))
+		}
+		if cfg.SMTPFrom != "" {
+			configLines = append(configLines, fmt.Sprintf("smtp_from: %q", cfg.SMTPFrom))
+		}
+		if len(cfg.AlertEmailTo) > 0 {
+			configLines = append(configLines, fmt.Sprintf("alert_email_to: [%s]", strings.Join(quoteAll(cfg.AlertEmailTo), ", ")))
+		}
+	}
+
+	// Certificate monitoring (save only when configured)
+	if len(cfg.CertPaths) > 0 || (cfg.CertExpiryWarningDays > 0 && cfg.CertExpiryWarningDays != constants.DEFAULT_CERT_EXPIRY_WARNING_DAYS) {
+		configLines = append(configLines, "")
+		configLines = append(configLines, "# Certificate monitoring")
+		if len(cfg.CertPaths) > 0 {
+			configLines = append(configLines, fmt.Sprintf("cert_paths: [%s]", strings.Join(quoteAll(cfg.CertPaths), ", ")))
+		}
+		if cfg.CertExpiryWarningDays > 0 && cfg.CertExpiryWarningDays != constants.DEFAULT_CERT_EXPIRY_WARNING_DAYS {
+			configLines = append(configLines, fmt.Sprintf("cert_expiry_warning_days: %d", cfg.CertExpiryWarningDays))
+		}
+	}
+
+	// Feature toggles (save only when disabled)
+	if !cfg.AnalyticsEnabled || !cfg.OTLPEnabled {
+		configLines = append(configLines, "")
+		configLines = append(configLines, "# Feature toggles")
+		if !cfg.AnalyticsEnabled {
+			configLines = append(configLines, "analytics_enabled: false")
+		}
+		if !cfg.OTLPEnabled {
+			configLines = append(configLines, "otlp_enabled: false")
+		}
+	}
+
+	// Maintenance window (expired windows are dropped)
+	if cfg.InMaintenance() {
+		configLines = append(configLines, "")
+		configLines = append(configLines, "# Maintenance window")
+		configLines = append(configLines, fmt.Sprintf("maintenance_until: %d", cfg.MaintenanceUntil))
+	}
+
+	if cfg.ConfigIntegrity {
+		configLines = append(configLines, "")
+		configLines = append(configLines, "# Security")
+		configLines = append(configLines, "config_integrity: true")
+	}
+
+	// Join lines with newline
+	return strings.Join(configLines, "\n")
+}
+
+// quoteAll wraps each value in double quotes for YAML output
+func quoteAll(values []string) []string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return quoted
 }
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"catops/pkg/utils"
+)
+
+// secretKeys are masked by Dump unless secrets are requested
+var secretKeys = map[string]bool{
+	"auth_token":   true,
+	"smtp_pass":    true,
+	"http_headers": true, // values may carry credentials
+}
+
+// Dump renders every config field as YAML, keyed by its config file name.
+// Unlike SaveConfig it includes defaulted and zero values, so the output shows
+// exactly what the CLI and daemon use.
+func (cfg *Config) Dump(showSecrets bool) string {
+	var b strings.Builder
+
+	v := reflect.ValueOf(cfg).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		key := t.Field(i).Tag.Get("mapstructure")
+		if key == "" {
+			continue
+		}
+		field := v.Field(i)
+
+		masked := secretKeys[key] && !showSecrets
+		if source := cfg.SecretSource(key); source != "" {
+			value := field.String()
+			if masked {
+				value = utils.MaskSecret(value, 4)
+			}
+			fmt.Fprintf(&b, "%s: %q # from %s\n", key, value, source)
+			continue
+		}
+		if masked && field.Kind() == reflect.String {
+			fmt.Fprintf(&b, "%s: %q\n", key, utils.MaskSecret(field.String(), 4))
+			continue
+		}
+
+		switch field.Kind() {
+		case reflect.String:
+			fmt.Fprintf(&b, "%s: %q\n", key, field.String())
+		case reflect.Slice:
+			items := make([]string, field.Len())
+			for j := range items {
+				if item := field.Index(j); item.Kind() == reflect.String {
+					items[j] = fmt.Sprintf("%q", item.String())
+				} else {
+					items[j] = fmt.Sprint(item.Interface())
+				}
+			}
+			fmt.Fprintf(&b, "%s: [%s]\n", key, strings.Join(items, ", "))
+		case reflect.Map:
+			if field.Len() == 0 {
+				fmt.Fprintf(&b, "%s: {}\n", key)
+				continue
+			}
+			fmt.Fprintf(&b, "%s:\n", key)
+			keys := make([]string, 0, field.Len())
+			for _, k := range field.MapKeys() {
+				keys = append(keys, k.String())
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				value := fmt.Sprint(field.MapIndex(reflect.ValueOf(k)).Interface())
+				if masked {
+					value = utils.MaskSecret(value, 4)
+				}
+				fmt.Fprintf(&b, "  %s: %q\n", k, value)
+			}
+		default:
+			fmt.Fprintf(&b, "%s: %v\n", key, field.Interface())
+		}
+	}
+	return b.String()
+}
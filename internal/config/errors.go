@@ -0,0 +1,95 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/user"
+
+	"github.com/spf13/viper"
+)
+
+// Config file errors, matched with errors.Is. LoadConfig treats a missing
+// config.yaml as defaults, ErrConfigNotFound is returned for files that were
+// named explicitly (bundles).
+var (
+	ErrConfigNotFound   = errors.New("config file not found")
+	ErrConfigInvalid    = errors.New("config file is not valid YAML or has a wrong value type")
+	ErrConfigPermission = errors.New("permission denied")
+)
+
+// FileError is a failed read or write of a config file. Kind is one of the
+// ErrConfig* errors, or nil when the failure is none of them (disk full, ...).
+type FileError struct {
+	Op   string // "read" or "write"
+	Path string
+	Kind error
+	Err  error
+}
+
+func (e *FileError) Error() string {
+	// *fs.PathError repeats the path, keep only its cause
+	cause := e.Err
+	var pathErr *fs.PathError
+	if errors.As(cause, &pathErr) {
+		cause = pathErr.Err
+	}
+	return fmt.Sprintf("can't %s %s: %v", e.Op, e.Path, cause)
+}
+
+// Unwrap makes both the kind and the underlying error match errors.Is
+func (e *FileError) Unwrap() []error {
+	if e.Kind == nil {
+		return []error{e.Err}
+	}
+	return []error{e.Kind, e.Err}
+}
+
+// Hint suggests how to fix the error, empty when there is nothing specific
+func (e *FileError) Hint() string {
+	switch e.Kind {
+	case ErrConfigNotFound:
+		return fmt.Sprintf("Check the path, %s does not exist", e.Path)
+	case ErrConfigPermission:
+		name := "the current user"
+		if u, err := user.Current(); err == nil {
+			name = u.Username
+		}
+		return fmt.Sprintf("Run catops as the user that owns %s, or give %s access: sudo chown %s %s",
+			e.Path, name, name, e.Path)
+	case ErrConfigInvalid:
+		return fmt.Sprintf("Fix the YAML in %s, or move it aside to start over from defaults", e.Path)
+	}
+	return ""
+}
+
+// ErrorHint returns the Hint of a config file error, empty for other errors
+func ErrorHint(err error) string {
+	var fileErr *FileError
+	if errors.As(err, &fileErr) {
+		return fileErr.Hint()
+	}
+	return ""
+}
+
+// newFileError classifies err from reading or writing path
+func newFileError(op, path string, err error) *FileError {
+	e := &FileError{Op: op, Path: path, Err: err}
+	var parseErr viper.ConfigParseError
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		e.Kind = ErrConfigNotFound
+	case errors.Is(err, fs.ErrPermission):
+		e.Kind = ErrConfigPermission
+	case errors.As(err, &parseErr):
+		e.Kind = ErrConfigInvalid
+	}
+	return e
+}
+
+// isConfigNotFound reports whether viper found no config file to read
+func isConfigNotFound(err error) bool {
+	var notFound viper.ConfigFileNotFoundError
+	return errors.As(err, &notFound) || errors.Is(err, os.ErrNotExist)
+}
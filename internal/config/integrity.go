@@ -0,0 +1,128 @@
+package config
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"catops/internal/logger"
+)
+
+const (
+	integrityKeyFile   = ".integrity_key" // machine-local HMAC secret, 0600
+	integritySigSuffix = ".sig"           // sibling file holding the config HMAC
+	integrityKeyLength = 32
+)
+
+// The config and signature file state at the last verification. LoadConfig
+// verifies again whenever it changed, so edits after the daemon started (hot
+// reload) are caught, without repeating the warning on every reload.
+var (
+	lastVerified   string
+	lastVerifiedMu sync.Mutex
+)
+
+// verifyConfigChanged runs verifyConfig unless neither file changed (by mtime
+// and size) since the last verification
+func verifyConfigChanged(configFile string, integrityEnabled bool) {
+	state := fmt.Sprintf("%v %s %s", integrityEnabled, fileState(configFile), fileState(configFile+integritySigSuffix))
+
+	lastVerifiedMu.Lock()
+	defer lastVerifiedMu.Unlock()
+	if state == lastVerified {
+		return
+	}
+	lastVerified = state
+	verifyConfig(configFile, integrityEnabled)
+}
+
+// fileState identifies a version of a file by mtime and size, "-" when missing
+func fileState(path string) string {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "-"
+	}
+	return fmt.Sprintf("%d/%d", info.ModTime().UnixNano(), info.Size())
+}
+
+// integrityKey returns the machine-local HMAC key, creating it on first use
+func integrityKey(configDir string) ([]byte, error) {
+	keyPath := filepath.Join(configDir, integrityKeyFile)
+
+	if data, err := os.ReadFile(keyPath); err == nil {
+		if key, err := hex.DecodeString(strings.TrimSpace(string(data))); err == nil && len(key) == integrityKeyLength {
+			return key, nil
+		}
+	}
+
+	key := make([]byte, integrityKeyLength)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(keyPath, []byte(hex.EncodeToString(key)), 0600); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// configHMAC computes the hex HMAC-SHA256 of config contents
+func configHMAC(key, content []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(content)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// signConfig writes the HMAC of the config file into its sibling .sig file
+func signConfig(configFile string, content []byte) error {
+	key, err := integrityKey(filepath.Dir(configFile))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(configFile+integritySigSuffix, []byte(configHMAC(key, content)+"\n"), 0600)
+}
+
+// verifyConfig checks the config file against its signature and logs a
+// SECURITY warning when it was modified outside of catops
+func verifyConfig(configFile string, integrityEnabled bool) {
+	sigData, sigErr := os.ReadFile(configFile + integritySigSuffix)
+
+	if !integrityEnabled {
+		// A signature without integrity mode means it was switched off by hand
+		if sigErr == nil {
+			logger.Warning("SECURITY: config integrity was disabled outside of catops (%s)", configFile)
+		}
+		return
+	}
+
+	if sigErr != nil {
+		logger.Warning("SECURITY: config integrity enabled but signature is missing: %s%s", configFile, integritySigSuffix)
+		return
+	}
+
+	content, err := os.ReadFile(configFile)
+	if err != nil {
+		return
+	}
+
+	key, err := os.ReadFile(filepath.Join(filepath.Dir(configFile), integrityKeyFile))
+	if err != nil {
+		logger.Warning("SECURITY: config integrity key is missing, cannot verify %s", configFile)
+		return
+	}
+	keyBytes, err := hex.DecodeString(strings.TrimSpace(string(key)))
+	if err != nil {
+		logger.Warning("SECURITY: config integrity key is corrupted, cannot verify %s", configFile)
+		return
+	}
+
+	expected := configHMAC(keyBytes, content)
+	if !hmac.Equal([]byte(expected), []byte(strings.TrimSpace(string(sigData)))) {
+		logger.Warning("SECURITY: config file %s was modified outside of catops (signature mismatch)", configFile)
+	}
+}
@@ -0,0 +1,161 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// A secret setting may hold a reference instead of the value itself:
+//
+//	auth_token: ${file:/run/secrets/catops_token}
+//	smtp_pass: ${credential:smtp_pass}
+//
+// file reads the path, credential reads a systemd credential from
+// $CREDENTIALS_DIRECTORY (LoadCredential= / SetCredentialEncrypted= in the
+// unit). The value is resolved by LoadConfig and never written back by
+// SaveConfig, which keeps the reference.
+//
+// Outside the unit (the CLI in a shell) credentials are read from the running
+// service's directory, which needs root. When that fails too the reference is
+// deferred: the setting stays empty without an error, it is the service's to
+// resolve.
+
+// serviceCredentialsDir is where systemd exposes the credentials of the
+// running catops service to other processes (root only)
+const serviceCredentialsDir = "/run/credentials/catops.service"
+
+// errSecretDeferred marks a credential that only the service can read
+var errSecretDeferred = errors.New("only readable by the catops service")
+
+// secretRef is a resolved reference for one secret setting
+type secretRef struct {
+	ref   string // as written in the config file
+	value string // what it resolved to, empty on error
+	err   error
+}
+
+// parseSecretRef splits ${kind:arg}, ok is false for a plain value
+func parseSecretRef(raw string) (kind, arg string, ok bool) {
+	inner, found := strings.CutPrefix(strings.TrimSpace(raw), "${")
+	if !found || !strings.HasSuffix(inner, "}") {
+		return "", "", false
+	}
+	kind, arg, found = strings.Cut(strings.TrimSuffix(inner, "}"), ":")
+	return kind, arg, found
+}
+
+// resolveSecretRef reads the value a reference points to, without the
+// trailing newline most secret files end with
+func resolveSecretRef(kind, arg string) (string, error) {
+	var path string
+	switch kind {
+	case "file":
+		path = arg
+	case "credential":
+		if strings.ContainsRune(arg, '/') {
+			return "", fmt.Errorf("credential %q: not a credential name", arg)
+		}
+		dir := os.Getenv("CREDENTIALS_DIRECTORY")
+		if dir == "" {
+			data, err := os.ReadFile(filepath.Join(serviceCredentialsDir, arg))
+			if err != nil {
+				return "", errSecretDeferred
+			}
+			return trimSecret(data, arg)
+		}
+		path = filepath.Join(dir, arg)
+	default:
+		return "", fmt.Errorf("unknown secret source %q (use file or credential)", kind)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return trimSecret(data, path)
+}
+
+// trimSecret drops the trailing newline of a secret read from source
+func trimSecret(data []byte, source string) (string, error) {
+	value := strings.TrimRight(string(data), "\r\n")
+	if value == "" {
+		return "", fmt.Errorf("%s is empty", source)
+	}
+	return value, nil
+}
+
+// resolveSecrets replaces secret references with their values, remembering
+// the reference so render writes it back instead of the secret
+func (cfg *Config) resolveSecrets() {
+	for key, field := range cfg.secretFields() {
+		kind, arg, ok := parseSecretRef(*field)
+		if !ok {
+			continue
+		}
+		ref := secretRef{ref: *field}
+		ref.value, ref.err = resolveSecretRef(kind, arg)
+		if cfg.secretRefs == nil {
+			cfg.secretRefs = make(map[string]secretRef)
+		}
+		cfg.secretRefs[key] = ref
+		*field = ref.value
+	}
+}
+
+// secretFields are the settings that may hold a reference, by config key
+func (cfg *Config) secretFields() map[string]*string {
+	return map[string]*string{
+		"auth_token": &cfg.AuthToken,
+		"smtp_pass":  &cfg.SMTPPass,
+	}
+}
+
+// SecretSource describes where a secret setting came from: the reference it
+// was read from, or "" when it is stored in the config file
+func (cfg *Config) SecretSource(key string) string {
+	field, ok := cfg.secretFields()[key]
+	if !ok {
+		return ""
+	}
+	if stored := cfg.storedSecret(key, *field); stored != *field {
+		return stored
+	}
+	return ""
+}
+
+// SecretDeferred reports whether the setting references a credential only the
+// service can read, so it is empty here but set in the daemon
+func (cfg *Config) SecretDeferred(key string) bool {
+	return errors.Is(cfg.secretRefs[key].err, errSecretDeferred)
+}
+
+// SecretErrors returns the references that could not be read, not counting
+// deferred ones (see SecretDeferred)
+func (cfg *Config) SecretErrors() []error {
+	keys := make([]string, 0, len(cfg.secretRefs))
+	for key := range cfg.secretRefs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var errs []error
+	for _, key := range keys {
+		if ref := cfg.secretRefs[key]; ref.err != nil && !errors.Is(ref.err, errSecretDeferred) {
+			errs = append(errs, fmt.Errorf("%s: %s: %w", key, ref.ref, ref.err))
+		}
+	}
+	return errs
+}
+
+// storedSecret returns what render writes for a secret setting: the reference
+// while value is still what it resolved to, otherwise the value itself (it was
+// replaced, e.g. by 'catops auth login')
+func (cfg *Config) storedSecret(key, value string) string {
+	if ref, ok := cfg.secretRefs[key]; ok && value == ref.value {
+		return ref.ref
+	}
+	return value
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	constants "catops/config"
+)
+
+// Validate checks settings against the ranges 'catops set' enforces, for
+// configuration that did not come through it (bundles, hand edits)
+func (cfg *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(cfg.CollectionInterval == 0 || (cfg.CollectionInterval >= constants.MIN_COLLECTION_INTERVAL && cfg.CollectionInterval <= 300),
+		"collection_interval must be between %d and 300 seconds", constants.MIN_COLLECTION_INTERVAL)
+	check(cfg.OTLPExportInterval == 0 || (cfg.OTLPExportInterval >= 10 && cfg.OTLPExportInterval <= 300),
+		"otlp_export_interval must be 0 or between 10 and 300 seconds")
+	check(cfg.AlertCheckInterval == 0 || (cfg.AlertCheckInterval >= 10 && cfg.AlertCheckInterval <= 3600),
+		"alert_check_interval must be between 10 and 3600 seconds")
+	check(cfg.ExportStaleAfter == 0 || (cfg.ExportStaleAfter >= 60 && cfg.ExportStaleAfter <= 86400),
+		"export_stale_after must be 0 (default) or between 60 and 86400 seconds")
+	check(cfg.HTTPTimeout >= 0 && cfg.HTTPTimeout <= 300, "http_timeout must be between 1 and 300 seconds")
+	check(cfg.UploadTimeout == 0 || (cfg.UploadTimeout >= 5 && cfg.UploadTimeout <= 600), "upload_timeout must be between 5 and 600 seconds")
+	check(cfg.LogDedupWindow >= 0 && cfg.LogDedupWindow <= 86400, "log_dedup_window must be between 0 and 86400 seconds")
+	check(cfg.LogRateLimit >= 0, "log_rate_limit must be 0 (unlimited) or positive")
+	check(cfg.SustainedDuration >= 0 && cfg.SustainedDuration <= 86400, "sustained_duration must be between 0 and 86400 seconds")
+	check(cfg.AlertWarmUp >= 0 && cfg.AlertWarmUp <= 3600, "alert_warmup must be between 0 (one collection interval) and 3600 seconds")
+
+	check(cfg.IOPSThreshold >= 0 && cfg.IOPSCritical >= 0, "iops thresholds must be 0 (disabled) or positive")
+	check(cfg.ThroughputThreshold >= 0 && cfg.ThroughputCritical >= 0, "throughput thresholds must be 0 (disabled) or positive")
+	check(cfg.FDThreshold >= 0 && cfg.FDThreshold <= 100 && cfg.FDCritical >= 0 && cfg.FDCritical <= 100,
+		"fd thresholds must be between 0 (disabled) and 100 percent")
+	check(cfg.ProcessIOThreshold >= 0, "process_io_threshold must be 0 (disabled) or positive")
+	check(cfg.NetRateThreshold >= 0, "net_rate_threshold must be 0 (disabled) or positive")
+	check(cfg.MemAvailableMin >= 0, "mem_available_min must be 0 (disabled) or positive")
+	check(cfg.TemperatureThreshold >= 0 && cfg.TemperatureThreshold <= 150, "temperature_threshold must be between 0 (disabled) and 150 °C")
+	check(cfg.NetworkSummary == "" || cfg.NetworkSummary == "all" || cfg.NetworkSummary == "primary",
+		"network_summary must be \"all\" or \"primary\"")
+	check(cfg.NetRatePercent >= 0 && cfg.NetRatePercent <= 100, "net_rate_percent must be between 0 and 100")
+
+	check(len(cfg.Environment) <= 64 && !strings.ContainsAny(cfg.Environment, "\n\r\t"),
+		"environment must be a single line of at most 64 characters")
+	check(len(cfg.Region) <= 64 && !strings.ContainsAny(cfg.Region, "\n\r\t"),
+		"region must be a single line of at most 64 characters")
+	check(cfg.LogFile == "" || filepath.IsAbs(cfg.LogFile), "log_file must be an absolute path")
+	check(cfg.MinFreeDisk >= 0, "min_free_disk must be 0 (no check) or positive")
+	check(cfg.ProcessDetailLimit >= 0, "process_detail_limit must be 0 or positive")
+	check(cfg.AlertHookTimeout >= 0, "alert_hook_timeout must be 0 or positive")
+	check(cfg.CertExpiryWarningDays >= 0, "cert_expiry_warning_days must be 0 or positive")
+	for _, p := range cfg.HTTPSPorts {
+		check(p >= 1 && p <= 65535, "https_ports: %d is not a valid port", p)
+	}
+
+	check(cfg.SMTPPort >= 0 && cfg.SMTPPort <= 65535, "smtp_port: %d is not a valid port", cfg.SMTPPort)
+	for _, to := range cfg.AlertEmailTo {
+		check(strings.Contains(to, "@"), "alert_email_to: %q is not an email address", to)
+	}
+
+	errs = append(errs, cfg.SecretErrors()...)
+
+	return errors.Join(errs...)
+}
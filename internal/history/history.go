@@ -0,0 +1,167 @@
+// Package history keeps a bounded local record of system summaries so trends
+// can be inspected without the backend (e.g. on air-gapped hosts).
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"catops/internal/config"
+	"catops/internal/metrics"
+	"catops/pkg/utils"
+)
+
+const (
+	historyFileName = "history.jsonl"
+	maxEntries      = 20160              // one week at 30s interval
+	maxAge          = 7 * 24 * time.Hour // entries older than this are dropped
+)
+
+// Entry is a single history sample
+type Entry struct {
+	Timestamp    time.Time `json:"ts"`
+	CPUUsage     float64   `json:"cpu"`
+	MemoryUsage  float64   `json:"memory"`
+	SwapUsage    float64   `json:"swap"`
+	DiskUsage    float64   `json:"disk"`
+	Load1m       float64   `json:"load"`
+	DiskIOPS     uint32    `json:"iops"`
+	NetBytesRecv uint64    `json:"net_recv"`
+	NetBytesSent uint64    `json:"net_sent"`
+}
+
+// Writer appends entries to the on-disk ring buffer
+type Writer struct {
+	path    string
+	entries int
+	mu      sync.Mutex
+}
+
+// Path returns the location of the history file
+func Path() string {
+	return filepath.Join(config.ConfigDir(), historyFileName)
+}
+
+// NewWriter creates a history writer and trims any stale entries left from previous runs
+func NewWriter() *Writer {
+	w := &Writer{path: Path()}
+	w.compact()
+	return w
+}
+
+// Append records a system summary
+func (w *Writer) Append(s *metrics.SystemSummary) error {
+	if s == nil {
+		return nil
+	}
+
+	entry := Entry{
+		Timestamp:    time.Now().UTC(),
+		CPUUsage:     s.CPUUsage,
+		MemoryUsage:  s.MemoryUsage,
+		SwapUsage:    s.SwapUsage,
+		DiskUsage:    s.DiskUsage,
+		Load1m:       s.Load1m,
+		DiskIOPS:     s.DiskIOPSRead + s.DiskIOPSWrite,
+		NetBytesRecv: s.NetBytesRecv,
+		NetBytesSent: s.NetBytesSent,
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(w.path), 0755); err != nil {
+		return err
+	}
+	if err := utils.EnsureFreeSpace(w.path, int64(len(data))+1); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(w.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(data, '\n'))
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	// Rewrite the file once it grows 10% past the cap
+	w.entries++
+	if w.entries > maxEntries+maxEntries/10 {
+		w.compactLocked()
+	}
+	return nil
+}
+
+// compact trims the history file to maxEntries/maxAge
+func (w *Writer) compact() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.compactLocked()
+}
+
+func (w *Writer) compactLocked() {
+	entries, err := Load(time.Now().Add(-maxAge))
+	if err != nil {
+		return
+	}
+	if len(entries) > maxEntries {
+		entries = entries[len(entries)-maxEntries:]
+	}
+
+	tmpFile := w.path + ".tmp"
+	f, err := os.OpenFile(tmpFile, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return
+	}
+	bw := bufio.NewWriter(f)
+	for _, e := range entries {
+		if data, err := json.Marshal(e); err == nil {
+			bw.Write(append(data, '\n'))
+		}
+	}
+	bw.Flush()
+	f.Close()
+
+	if err := os.Rename(tmpFile, w.path); err == nil {
+		w.entries = len(entries)
+	}
+}
+
+// Load reads history entries recorded at or after since, oldest first
+func Load(since time.Time) ([]Entry, error) {
+	f, err := os.Open(Path())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue // skip partially written lines
+		}
+		if e.Timestamp.Before(since) {
+			continue
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, scanner.Err()
+}
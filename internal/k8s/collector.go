@@ -5,11 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	neturl "net/url"
 	"time"
 
 	"catops/internal/encoding"
 	"catops/internal/logger"
 	"catops/internal/metrics"
+	"catops/pkg/utils"
 )
 
 // Collector собирает метрики из Kubernetes
@@ -22,7 +24,11 @@ type Collector struct {
 	secretName    string // Secret name for updating permanent token
 	version       string
 	prometheusURL string            // NEW: Prometheus URL (optional)
+	promPrefixes  []string          // metric prefixes imported from Prometheus
+	podScope      PodScope          // namespaces / label selector of collected pods
+	limitPercent  float64           // usage/limit %, с которого под помечается near_limit (0 = выкл)
 	promClient    *PrometheusClient // NEW: Prometheus client (optional)
+	resource      map[string]string // environment, region и tags для всех метрик
 }
 
 // CollectorConfig конфигурация для Collector
@@ -44,6 +50,11 @@ func NewCollector(client *Client, config interface{}, version string) *Collector
 		GetNamespace() string
 		GetSecretName() string    // NEW: Secret name for permanent token
 		GetPrometheusURL() string // NEW
+		GetPrometheusMetricPrefixes() []string
+		GetPodNamespaces() []string
+		GetPodLabelSelector() string
+		GetPodLimitThreshold() float64
+		GetResourceAttributes() map[string]string
 	})
 
 	c := &Collector{
@@ -54,12 +65,19 @@ func NewCollector(client *Client, config interface{}, version string) *Collector
 		namespace:     cfg.GetNamespace(),
 		secretName:    cfg.GetSecretName(),
 		prometheusURL: cfg.GetPrometheusURL(),
-		version:       version,
+		promPrefixes:  cfg.GetPrometheusMetricPrefixes(),
+		podScope: PodScope{
+			Namespaces:    cfg.GetPodNamespaces(),
+			LabelSelector: cfg.GetPodLabelSelector(),
+		},
+		limitPercent: cfg.GetPodLimitThreshold(),
+		resource:     cfg.GetResourceAttributes(),
+		version:      version,
 	}
 
 	// Try to initialize Prometheus client (optional, non-blocking)
 	if c.prometheusURL != "" {
-		promClient, err := NewPrometheusClient(c.prometheusURL, c.nodeName)
+		promClient, err := NewPrometheusClient(c.prometheusURL, c.nodeName, c.promPrefixes)
 		if err != nil {
 			logger.Warning("⚠️  Prometheus client initialization failed: %v", err)
 			logger.Info("ℹ️  Continuing with basic metrics only")
@@ -89,9 +107,15 @@ type K8sMetrics struct {
 	NodeName  string `json:"node_name"`
 	Namespace string `json:"namespace"`
 
+	// Resource attributes (deployment.environment, cloud.region, catops.label.*)
+	Resource map[string]string `json:"resource_attributes,omitempty"`
+
 	// Node metrics (переиспользуем существующий код)
 	Node *metrics.Metrics `json:"node_metrics"`
 
+	// Node-level detail from Prometheus (optional)
+	NodeExtended *ExtendedNodeMetrics `json:"node_extended,omitempty"`
+
 	// K8s-specific metrics
 	Pods    []PodMetric     `json:"pods"`
 	Cluster *ClusterMetrics `json:"cluster"`
@@ -113,6 +137,16 @@ type PodMetric struct {
 	RestartCount   int32   `json:"restart_count"`
 	ContainerCount int     `json:"container_count"`
 
+	// Requests/limits из spec (сумма по контейнерам; limit 0 = не задан хотя бы
+	// у одного контейнера) и использование относительно limit
+	CPURequest         float64 `json:"cpu_request_cores,omitempty"`
+	CPULimit           float64 `json:"cpu_limit_cores,omitempty"`
+	MemoryRequest      int64   `json:"memory_request_bytes,omitempty"`
+	MemoryLimit        int64   `json:"memory_limit_bytes,omitempty"`
+	CPULimitPercent    float64 `json:"cpu_limit_percent,omitempty"`
+	MemoryLimitPercent float64 `json:"memory_limit_percent,omitempty"`
+	NearLimit          bool    `json:"near_limit,omitempty"`
+
 	// Extended fields (from Prometheus, optional)
 	Labels     map[string]string `json:"labels,omitempty"`
 	OwnerKind  string            `json:"owner_kind,omitempty"`
@@ -151,18 +185,19 @@ func (c *Collector) CollectAndSend(ctx context.Context) error {
 	}
 
 	// 3. НОВОЕ: Если Prometheus доступен, обогащаем данные расширенными метриками
+	var nodeExtended *ExtendedNodeMetrics
 	if c.promClient != nil {
 		logger.Info("🔍 Fetching extended metrics from Prometheus...")
 
 		// Обогащаем node metrics
 		extendedNode, err := c.promClient.QueryNodeMetrics(ctx)
 		if err == nil && extendedNode != nil {
-			// TODO: Merge extended node metrics into nodeMetrics
-			// For now, we'll add them in the payload structure
+			nodeExtended = extendedNode
 			logger.Info("✅ Node metrics enriched with Prometheus data")
 			logger.Debug("  CPU per core: %d cores", len(extendedNode.CPUPerCore))
 			logger.Debug("  Disk I/O devices: %d", len(extendedNode.DiskIOPerDevice))
 			logger.Debug("  Network interfaces: %d", len(extendedNode.NetworkPerInterface))
+			logger.Debug("  Series by prefix: %d", len(extendedNode.Series))
 		} else {
 			logger.Warning("⚠️  Failed to fetch Prometheus node metrics: %v", err)
 		}
@@ -190,13 +225,15 @@ func (c *Collector) CollectAndSend(ctx context.Context) error {
 
 	// 5. Собираем всё в одну структуру
 	k8sMetrics := &K8sMetrics{
-		Timestamp: time.Now().UTC().Format(time.RFC3339),
-		NodeName:  c.nodeName,
-		Namespace: c.namespace,
-		Node:      nodeMetrics,
-		Pods:      podMetrics,
-		Cluster:   clusterMetrics,
-		UserToken: c.authToken,
+		Timestamp:    time.Now().UTC().Format(time.RFC3339),
+		NodeName:     c.nodeName,
+		Namespace:    c.namespace,
+		Resource:     c.resource,
+		Node:         nodeMetrics,
+		NodeExtended: nodeExtended,
+		Pods:         podMetrics,
+		Cluster:      clusterMetrics,
+		UserToken:    c.authToken,
 	}
 
 	// 5. Отправляем в backend
@@ -209,6 +246,17 @@ func (c *Collector) CollectAndSend(ctx context.Context) error {
 	logger.Info("   Node metrics: CPU=%.1f%%, Memory=%.1f%%, Disk=%.1f%%",
 		nodeMetrics.CPUUsage, nodeMetrics.MemoryUsage, nodeMetrics.DiskUsage)
 	logger.Info("   Pods on this node: %d", len(podMetrics))
+	if c.limitPercent > 0 {
+		nearLimit := 0
+		for _, pod := range podMetrics {
+			if pod.NearLimit {
+				nearLimit++
+			}
+		}
+		if nearLimit > 0 {
+			logger.Info("   Pods near limits (≥%.0f%%): %d", c.limitPercent, nearLimit)
+		}
+	}
 
 	if clusterMetrics != nil {
 		logger.Info("   Cluster: %d/%d nodes ready, %d/%d pods running",
@@ -235,7 +283,7 @@ func (c *Collector) collectNodeMetrics() (*metrics.Metrics, error) {
 
 // collectPodMetrics собирает метрики подов на текущей ноде
 func (c *Collector) collectPodMetrics(ctx context.Context) ([]PodMetric, error) {
-	pods, err := c.client.GetPodsOnNode(ctx, c.nodeName)
+	pods, err := c.client.GetPodsOnNode(ctx, c.nodeName, c.podScope)
 	if err != nil {
 		return nil, err
 	}
@@ -257,11 +305,19 @@ func (c *Collector) collectPodMetrics(ctx context.Context) ([]PodMetric, error)
 			metric.RestartCount += containerStatus.RestartCount
 		}
 
+		// Requests/limits из spec
+		resources := GetPodResources(&pod)
+		metric.CPURequest = resources.CPURequest
+		metric.CPULimit = resources.CPULimit
+		metric.MemoryRequest = resources.MemoryRequest
+		metric.MemoryLimit = resources.MemoryLimit
+
 		// Получаем resource usage через metrics API
 		usage, err := c.client.GetPodMetrics(ctx, pod.Namespace, pod.Name)
 		if err == nil && usage != nil {
 			metric.CPUUsage = usage.CPUUsage
 			metric.MemoryUsage = usage.MemoryUsage
+			c.applyLimitUsage(&metric)
 		}
 
 		podMetrics = append(podMetrics, metric)
@@ -270,6 +326,25 @@ func (c *Collector) collectPodMetrics(ctx context.Context) ([]PodMetric, error)
 	return podMetrics, nil
 }
 
+// applyLimitUsage считает использование относительно limits и помечает под,
+// превысивший limitPercent хотя бы по одному ресурсу
+func (c *Collector) applyLimitUsage(metric *PodMetric) {
+	if metric.CPULimit > 0 {
+		metric.CPULimitPercent = metric.CPUUsage / metric.CPULimit * 100
+	}
+	if metric.MemoryLimit > 0 {
+		metric.MemoryLimitPercent = float64(metric.MemoryUsage) / float64(metric.MemoryLimit) * 100
+	}
+	if c.limitPercent <= 0 {
+		return
+	}
+	if metric.CPULimitPercent >= c.limitPercent || metric.MemoryLimitPercent >= c.limitPercent {
+		metric.NearLimit = true
+		logger.Warning("⚠️  Pod %s/%s near its limits: CPU %.0f%%, memory %.0f%% of limit",
+			metric.Namespace, metric.Name, metric.CPULimitPercent, metric.MemoryLimitPercent)
+	}
+}
+
 // collectClusterMetrics собирает метрики всего кластера
 func (c *Collector) collectClusterMetrics(ctx context.Context) (*ClusterMetrics, error) {
 	metrics := &ClusterMetrics{}
@@ -291,8 +366,8 @@ func (c *Collector) collectClusterMetrics(ctx context.Context) (*ClusterMetrics,
 		}
 	}
 
-	// Получаем все поды
-	pods, err := c.client.GetAllPods(ctx)
+	// Получаем все поды (в пределах scope)
+	pods, err := c.client.GetAllPods(ctx, c.podScope)
 	if err != nil {
 		return nil, err
 	}
@@ -327,13 +402,16 @@ func (c *Collector) sendMetrics(metrics *K8sMetrics) error {
 
 	// Prepare headers
 	headers := map[string]string{
-		"User-Agent": "CatOps-CLI/1.0.0",
 		"X-Platform": "linux",
 		"X-Version":  c.version,
 	}
+	target, _ := neturl.Parse(url)
+	for k, v := range utils.RequestHeaders(target) {
+		headers[k] = v
+	}
 
 	// Send CBOR-encoded request
-	client := &http.Client{Timeout: 10 * time.Second}
+	client := utils.NewHTTPClient()
 	resp, err := encoding.SendCBORRequest(client, url, metrics, headers)
 	if err != nil {
 		return fmt.Errorf("failed to send request: %w", err)
@@ -0,0 +1,103 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"catops/internal/logger"
+)
+
+// healthCheckTimeout ограничивает проверку Kubernetes API в /readyz
+const healthCheckTimeout = 5 * time.Second
+
+// HealthServer отдает liveness (/healthz) и readiness (/readyz) пробы для DaemonSet.
+// Готовность: последний успешный CollectAndSend не старше 2× интервала и
+// Kubernetes API отвечает на HealthCheck.
+type HealthServer struct {
+	client      *Client
+	interval    time.Duration
+	lastSuccess atomic.Int64 // unix nano последнего успешного CollectAndSend
+	failures    atomic.Int64 // ошибок CollectAndSend подряд
+}
+
+// NewHealthServer создает health server для коннектора
+func NewHealthServer(client *Client, interval time.Duration) *HealthServer {
+	return &HealthServer{client: client, interval: interval}
+}
+
+// RecordSuccess отмечает успешный CollectAndSend и сбрасывает счетчик ошибок
+func (h *HealthServer) RecordSuccess() {
+	h.lastSuccess.Store(time.Now().UnixNano())
+	h.failures.Store(0)
+}
+
+// RecordFailure отмечает неудачный CollectAndSend
+func (h *HealthServer) RecordFailure() {
+	h.failures.Add(1)
+}
+
+// Serve слушает port до отмены ctx
+func (h *HealthServer) Serve(ctx context.Context, port int) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		h.writeStatus(w, http.StatusOK, "ok")
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if err := h.ready(r.Context()); err != nil {
+			h.writeStatus(w, http.StatusServiceUnavailable, err.Error())
+			return
+		}
+		h.writeStatus(w, http.StatusOK, "ok")
+	})
+
+	server := &http.Server{
+		Addr:              net.JoinHostPort("", strconv.Itoa(port)),
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	logger.Info("🩺 Health endpoints listening on :%d (/healthz, /readyz)", port)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("health server: %w", err)
+	}
+	return nil
+}
+
+// writeStatus пишет статус и gauge ошибок подряд, сбрасываемый при успехе
+func (h *HealthServer) writeStatus(w http.ResponseWriter, code int, status string) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(code)
+	fmt.Fprintln(w, status)
+	fmt.Fprintf(w, "consecutive_failures %d\n", h.failures.Load())
+	if last := h.lastSuccess.Load(); last != 0 {
+		fmt.Fprintf(w, "last_success %s\n", time.Unix(0, last).UTC().Format(time.RFC3339))
+	}
+}
+
+// ready возвращает причину, по которой коннектор не готов
+func (h *HealthServer) ready(ctx context.Context) error {
+	last := h.lastSuccess.Load()
+	if last == 0 {
+		return fmt.Errorf("no successful collection yet")
+	}
+	if age := time.Since(time.Unix(0, last)); age > 2*h.interval {
+		return fmt.Errorf("last successful collection %s ago (interval %s)", age.Round(time.Second), h.interval)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	return h.client.HealthCheck(ctx)
+}
@@ -4,7 +4,10 @@ import (
 	"context"
 	"fmt"
 
+	"catops/internal/logger"
+
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -14,27 +17,104 @@ type PodMetrics struct {
 	MemoryUsage int64   // bytes
 }
 
-// GetPodsOnNode получает все поды на указанной ноде
-func (c *Client) GetPodsOnNode(ctx context.Context, nodeName string) ([]corev1.Pod, error) {
+// PodResources requests/limits пода, суммированные по контейнерам
+type PodResources struct {
+	CPURequest    float64 // cores
+	CPULimit      float64 // cores, 0 = не задан
+	MemoryRequest int64   // bytes
+	MemoryLimit   int64   // bytes, 0 = не задан
+}
+
+// GetPodResources суммирует requests/limits контейнеров пода. Если хотя бы у
+// одного контейнера limit не задан, limit пода не ограничен и остается 0
+func GetPodResources(pod *corev1.Pod) PodResources {
+	var r PodResources
+	cpuLimited, memoryLimited := true, true
+	for _, container := range pod.Spec.Containers {
+		requests := container.Resources.Requests
+		limits := container.Resources.Limits
+
+		r.CPURequest += float64(requests.Cpu().MilliValue()) / 1000.0
+		r.MemoryRequest += requests.Memory().Value()
+
+		if cpu, ok := limits[corev1.ResourceCPU]; ok {
+			r.CPULimit += float64(cpu.MilliValue()) / 1000.0
+		} else {
+			cpuLimited = false
+		}
+		if memory, ok := limits[corev1.ResourceMemory]; ok {
+			r.MemoryLimit += memory.Value()
+		} else {
+			memoryLimited = false
+		}
+	}
+	if !cpuLimited {
+		r.CPULimit = 0
+	}
+	if !memoryLimited {
+		r.MemoryLimit = 0
+	}
+	return r
+}
+
+// PodScope ограничивает собираемые поды: список namespaces (пусто = все) и
+// label selector (пусто = без фильтра)
+type PodScope struct {
+	Namespaces    []string
+	LabelSelector string
+}
+
+// GetPodsOnNode получает поды из scope на указанной ноде
+func (c *Client) GetPodsOnNode(ctx context.Context, nodeName string, scope PodScope) ([]corev1.Pod, error) {
 	// Используем field selector для фильтрации по ноде
-	pods, err := c.Clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{
-		FieldSelector: fmt.Sprintf("spec.nodeName=%s", nodeName),
-	})
+	pods, err := c.listPods(ctx, scope, fmt.Sprintf("spec.nodeName=%s", nodeName))
 	if err != nil {
 		return nil, fmt.Errorf("failed to list pods on node %s: %w", nodeName, err)
 	}
 
-	return pods.Items, nil
+	return pods, nil
 }
 
-// GetAllPods получает все поды в кластере
-func (c *Client) GetAllPods(ctx context.Context) ([]corev1.Pod, error) {
-	pods, err := c.Clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+// GetAllPods получает все поды из scope в кластере
+func (c *Client) GetAllPods(ctx context.Context, scope PodScope) ([]corev1.Pod, error) {
+	pods, err := c.listPods(ctx, scope, "")
 	if err != nil {
 		return nil, fmt.Errorf("failed to list all pods: %w", err)
 	}
 
-	return pods.Items, nil
+	return pods, nil
+}
+
+// listPods перебирает namespaces из scope. Namespace, который service account
+// не может читать (RBAC), логируется и пропускается, а не валит весь цикл
+func (c *Client) listPods(ctx context.Context, scope PodScope, fieldSelector string) ([]corev1.Pod, error) {
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+		LabelSelector: scope.LabelSelector,
+	}
+
+	if len(scope.Namespaces) == 0 {
+		pods, err := c.Clientset.CoreV1().Pods("").List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		return pods.Items, nil
+	}
+
+	var all []corev1.Pod
+	for _, namespace := range scope.Namespaces {
+		pods, err := c.Clientset.CoreV1().Pods(namespace).List(ctx, opts)
+		if err != nil {
+			if apierrors.IsForbidden(err) {
+				logger.Warning("⚠️  Skipping namespace %s: no permission to list pods", namespace)
+				continue
+			}
+			return nil, fmt.Errorf("namespace %s: %w", namespace, err)
+		}
+		all = append(all, pods.Items...)
+	}
+
+	return all, nil
 }
 
 // GetAllNodes получает все ноды в кластере
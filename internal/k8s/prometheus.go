@@ -3,6 +3,9 @@ package k8s
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"sort"
+	"strings"
 	"time"
 
 	"catops/internal/logger"
@@ -12,14 +15,20 @@ import (
 	"github.com/prometheus/common/model"
 )
 
+// maxPrometheusSeries caps the series imported by metric prefix per collection
+const maxPrometheusSeries = 500
+
 // PrometheusClient wraps Prometheus API client for querying metrics
 type PrometheusClient struct {
 	api      v1.API
 	nodeName string
+	prefixes []string // metric name prefixes imported as raw series
 }
 
-// NewPrometheusClient creates a new Prometheus client
-func NewPrometheusClient(prometheusURL, nodeName string) (*PrometheusClient, error) {
+// NewPrometheusClient creates a new Prometheus client. Series whose names start
+// with one of prefixes are imported for this node as-is, next to the built-in
+// CPU/memory/disk/network breakdown.
+func NewPrometheusClient(prometheusURL, nodeName string, prefixes []string) (*PrometheusClient, error) {
 	if prometheusURL == "" {
 		return nil, fmt.Errorf("prometheus URL is empty")
 	}
@@ -34,6 +43,7 @@ func NewPrometheusClient(prometheusURL, nodeName string) (*PrometheusClient, err
 	return &PrometheusClient{
 		api:      v1.NewAPI(client),
 		nodeName: nodeName,
+		prefixes: prefixes,
 	}, nil
 }
 
@@ -43,6 +53,14 @@ type ExtendedNodeMetrics struct {
 	MemoryBreakdown     *MemoryBreakdown             `json:"memory_breakdown,omitempty"`
 	DiskIOPerDevice     map[string]*DiskIO           `json:"disk_io_per_device,omitempty"`
 	NetworkPerInterface map[string]*NetworkInterface `json:"network_per_interface,omitempty"`
+	Series              []PrometheusSample           `json:"series,omitempty"`
+}
+
+// PrometheusSample is one series imported by metric prefix
+type PrometheusSample struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+	Value  float64           `json:"value"`
 }
 
 // MemoryBreakdown contains detailed memory information
@@ -110,6 +128,11 @@ func (p *PrometheusClient) QueryNodeMetrics(ctx context.Context) (*ExtendedNodeM
 		logger.Warning("Failed to query network: %v", err)
 	}
 
+	// Query series selected by prefix
+	if err := p.querySeries(ctx, extended); err != nil {
+		logger.Warning("Failed to query series by prefix: %v", err)
+	}
+
 	return extended, nil
 }
 
@@ -258,6 +281,55 @@ func (p *PrometheusClient) queryNetwork(ctx context.Context, extended *ExtendedN
 	return nil
 }
 
+// querySeries imports the current value of every node series whose name starts
+// with one of the configured prefixes, up to maxPrometheusSeries
+func (p *PrometheusClient) querySeries(ctx context.Context, extended *ExtendedNodeMetrics) error {
+	if len(p.prefixes) == 0 {
+		return nil
+	}
+
+	patterns := make([]string, len(p.prefixes))
+	for i, prefix := range p.prefixes {
+		patterns[i] = regexp.QuoteMeta(prefix) + ".*"
+	}
+	query := fmt.Sprintf(`{__name__=~"%s", instance=~".*%s.*"}`, strings.Join(patterns, "|"), p.nodeName)
+
+	result, warnings, err := p.api.Query(ctx, query, time.Now())
+	if err != nil {
+		return err
+	}
+	if len(warnings) > 0 {
+		logger.Warning("Prometheus warnings: %v", warnings)
+	}
+
+	vector, ok := result.(model.Vector)
+	if !ok {
+		return nil
+	}
+	if len(vector) > maxPrometheusSeries {
+		logger.Warning("Prometheus prefixes matched %d series, importing the first %d", len(vector), maxPrometheusSeries)
+		sort.Slice(vector, func(i, j int) bool { return vector[i].Metric.String() < vector[j].Metric.String() })
+		vector = vector[:maxPrometheusSeries]
+	}
+
+	for _, sample := range vector {
+		s := PrometheusSample{Name: string(sample.Metric[model.MetricNameLabel]), Value: float64(sample.Value)}
+		for k, v := range sample.Metric {
+			// instance/job identify the scrape target, not the series
+			if k == model.MetricNameLabel || k == "instance" || k == "job" {
+				continue
+			}
+			if s.Labels == nil {
+				s.Labels = make(map[string]string)
+			}
+			s.Labels[string(k)] = string(v)
+		}
+		extended.Series = append(extended.Series, s)
+	}
+
+	return nil
+}
+
 // QueryPodMetrics queries enhanced pod metrics from kube-state-metrics
 func (p *PrometheusClient) QueryPodMetrics(ctx context.Context) (map[string]*ExtendedPodMetrics, error) {
 	extendedPods := make(map[string]*ExtendedPodMetrics)
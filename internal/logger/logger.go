@@ -3,6 +3,7 @@ package logger
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -33,7 +34,7 @@ func New(filePath string) *Logger {
 
 	isKubernetes := os.Getenv("NODE_NAME") != ""
 	if filePath != "" && !isKubernetes {
-		logFile, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		logFile, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
 		if err == nil {
 			logger.logFile = logFile
 		}
@@ -42,6 +43,35 @@ func New(filePath string) *Logger {
 	return logger
 }
 
+// SetPath moves the logger to filePath. The directory is created if needed;
+// on error the logger keeps writing where it was.
+func (l *Logger) SetPath(filePath string) error {
+	if filePath == l.filePath {
+		return nil
+	}
+
+	var logFile *os.File
+	if os.Getenv("NODE_NAME") == "" {
+		if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			return err
+		}
+		f, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return err
+		}
+		logFile = f
+	}
+
+	l.mu.Lock()
+	old := l.logFile
+	l.filePath, l.logFile = filePath, logFile
+	l.mu.Unlock()
+	if old != nil {
+		old.Close()
+	}
+	return nil
+}
+
 // Default returns a logger with default settings
 func Default() *Logger {
 	return New(constants.LOG_FILE)
@@ -56,11 +86,14 @@ func (l *Logger) write(level Level, message string, args ...interface{}) {
 
 	if isKubernetes {
 		fmt.Print(logEntry)
-	} else if l.logFile != nil {
-		l.mu.Lock()
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.logFile != nil {
 		l.logFile.WriteString(logEntry)
 		l.logFile.Sync() // Force write to disk immediately
-		l.mu.Unlock()
 	}
 }
 
@@ -100,6 +133,16 @@ func (l *Logger) Debug(message string, args ...interface{}) {
 // Global logger instance for convenience
 var defaultLogger = Default()
 
+// Path returns the file the default logger writes to
+func Path() string {
+	return defaultLogger.filePath
+}
+
+// SetPath moves the default logger to filePath (the log_file setting)
+func SetPath(filePath string) error {
+	return defaultLogger.SetPath(filePath)
+}
+
 // Info logs an informational message using the default logger
 func Info(message string, args ...interface{}) {
 	defaultLogger.Info(message, args...)
@@ -0,0 +1,160 @@
+package metrics
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/process"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var (
+	// lastCycleDuration is the wall time of the last CollectAllMetricsWithTimings, in nanoseconds
+	lastCycleDuration atomic.Int64
+
+	// lastExportSuccess is when an OTLP export last succeeded on any endpoint, in unix nanoseconds
+	lastExportSuccess atomic.Int64
+
+	// agentProcess is catops's own process, looked up once
+	agentProcess     *process.Process
+	agentProcessOnce sync.Once
+)
+
+// recordCycleDuration stores how long a full collection cycle took
+func recordCycleDuration(d time.Duration) {
+	lastCycleDuration.Store(int64(d))
+}
+
+// LastCycleDuration returns how long the last full collection cycle took (0 before the first)
+func LastCycleDuration() time.Duration {
+	return time.Duration(lastCycleDuration.Load())
+}
+
+// recordExportSuccess notes that an OTLP export was accepted
+func recordExportSuccess() {
+	lastExportSuccess.Store(time.Now().UnixNano())
+}
+
+// LastExportSuccess returns when metrics (and the logs they carry) were last
+// accepted by an OTLP endpoint, zero if never since the daemon started
+func LastExportSuccess() time.Time {
+	if last := lastExportSuccess.Load(); last != 0 {
+		return time.Unix(0, last)
+	}
+	return time.Time{}
+}
+
+// getAgentProcess returns catops's own process, nil if it can't be opened
+func getAgentProcess() *process.Process {
+	agentProcessOnce.Do(func() {
+		agentProcess, _ = process.NewProcess(int32(os.Getpid()))
+	})
+	return agentProcess
+}
+
+func registerAgentMetrics() error {
+	// catops.agent.* - the daemon's own footprint, to catch catops leaking or overloading the host
+	_, err := meter.Float64ObservableGauge(
+		"catops.agent.cpu",
+		metric.WithDescription("CPU used by the catops process since the previous export"),
+		metric.WithUnit("%"),
+		metric.WithFloat64Callback(func(ctx context.Context, o metric.Float64Observer) error {
+			p := getAgentProcess()
+			if p == nil {
+				return nil
+			}
+			// Percent(0) compares against the previous call, i.e. the previous export
+			if percent, err := p.PercentWithContext(ctx, 0); err == nil {
+				o.Observe(percent)
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.Int64ObservableGauge(
+		"catops.agent.memory",
+		metric.WithDescription("Resident memory of the catops process"),
+		metric.WithUnit("By"),
+		metric.WithInt64Callback(func(ctx context.Context, o metric.Int64Observer) error {
+			p := getAgentProcess()
+			if p == nil {
+				return nil
+			}
+			if mem, err := p.MemoryInfoWithContext(ctx); err == nil {
+				o.Observe(int64(mem.RSS))
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.Int64ObservableGauge(
+		"catops.agent.goroutines",
+		metric.WithDescription("Goroutines in the catops process"),
+		metric.WithInt64Callback(func(ctx context.Context, o metric.Int64Observer) error {
+			o.Observe(int64(runtime.NumGoroutine()))
+			return nil
+		}),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.Int64ObservableGauge(
+		"catops.agent.open_fds",
+		metric.WithDescription("Open file descriptors of the catops process"),
+		metric.WithInt64Callback(func(ctx context.Context, o metric.Int64Observer) error {
+			p := getAgentProcess()
+			if p == nil {
+				return nil
+			}
+			// Not supported on every platform
+			if fds, err := p.NumFDsWithContext(ctx); err == nil {
+				o.Observe(int64(fds))
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.Float64ObservableGauge(
+		"catops.agent.collection.duration",
+		metric.WithDescription("Duration of the last metrics collection cycle"),
+		metric.WithUnit("s"),
+		metric.WithFloat64Callback(func(ctx context.Context, o metric.Float64Observer) error {
+			if d := LastCycleDuration(); d > 0 {
+				o.Observe(d.Seconds())
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		return err
+	}
+
+	// Observed when the next export is built, so after an outage the backend
+	// sees how long the gap was
+	_, err = meter.Float64ObservableGauge(
+		"catops.agent.export.age",
+		metric.WithDescription("Time since the last successful OTLP export"),
+		metric.WithUnit("s"),
+		metric.WithFloat64Callback(func(ctx context.Context, o metric.Float64Observer) error {
+			if last := LastExportSuccess(); !last.IsZero() {
+				o.Observe(time.Since(last).Seconds())
+			}
+			return nil
+		}),
+	)
+	return err
+}
@@ -12,14 +12,21 @@
 package metrics
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/shirou/gopsutil/v4/disk"
@@ -28,6 +35,9 @@ import (
 	"github.com/shirou/gopsutil/v4/mem"
 	"github.com/shirou/gopsutil/v4/net"
 	"github.com/shirou/gopsutil/v4/process"
+
+	constants "catops/config"
+	"catops/internal/logger"
 )
 
 // =============================================================================
@@ -56,14 +66,49 @@ var (
 	prevProcCPUTimes map[int32]float64 // PID -> total CPU time (user + system)
 	prevProcCPUTime  time.Time
 	prevProcCPUMu    sync.RWMutex
+
+	// Per-process IO rate tracking, taken together with the CPU times above
+	prevProcIO map[int32][2]uint64 // PID -> cumulative disk read, write bytes
+
+	// Network/remote filesystem types excluded from disk metrics
+	skippedFstypes   = constants.DEFAULT_SKIP_FSTYPES
+	skippedFstypesMu sync.RWMutex
+
+	// Mount points collected exclusively when set (disk_mounts), bypassing the skip rules
+	diskMounts   map[string]bool
+	diskMountsMu sync.RWMutex
+
+	// Top processes enriched with exe/user/PPID/IO details (process_detail_limit)
+	processDetailLimit   = constants.DEFAULT_PROCESS_DETAIL_LIMIT
+	processDetailLimitMu sync.RWMutex
+
+	// Interfaces summed into the summary network totals (network_summary)
+	networkSummaryPrimary atomic.Bool
 )
 
+// diskUsageTimeout bounds a single disk.Usage call (protects against hung remote mounts)
+const diskUsageTimeout = 2 * time.Second
+
+// Mountpoints with a disk.Usage call still blocked after timing out. They are
+// skipped until it returns, so a hung mount holds at most one goroutine.
+var (
+	stuckMounts   = make(map[string]chan struct{}) // closed when the call returns
+	stuckMountsMu sync.Mutex
+)
+
+// topIOProcesses is how many of the busiest processes by disk IO are kept in
+// addition to the top ones by CPU and memory
+const topIOProcesses = 5
+
 // =============================================================================
 // Metrics Collection
 // =============================================================================
 
 // checkAndUpdateDelta atomically checks if metrics changed significantly and updates
 // the delta tracking state if so. Returns true if metrics should be sent.
+// The OTel reader exports the cache on its own schedule (otlp_export_interval), so
+// between significant changes it re-exports the last accepted snapshot; the 60s
+// forced refresh bounds how stale that snapshot can get.
 // Holding the write lock for the entire check-and-update prevents TOCTOU races
 // where two goroutines both see "should update" and both proceed to send.
 func checkAndUpdateDelta(current *AllMetrics) bool {
@@ -122,10 +167,24 @@ func absFloat64(x float64) float64 {
 	return x
 }
 
+// CollectorTiming records how long a single collector took
+type CollectorTiming struct {
+	Name     string
+	Duration time.Duration
+}
+
 // CollectAllMetrics collects all system metrics and updates the cache
 func CollectAllMetrics() (*AllMetrics, error) {
+	m, _, err := CollectAllMetricsWithTimings()
+	return m, err
+}
+
+// CollectAllMetricsWithTimings collects all system metrics and also returns
+// the duration of each parallel collector, slowest first
+func CollectAllMetricsWithTimings() (*AllMetrics, []CollectorTiming, error) {
 	// Clear per-cycle cache at start of each collection
 	clearCycleCache()
+	start := time.Now()
 
 	m := &AllMetrics{
 		Timestamp: time.Now().UTC(),
@@ -135,19 +194,33 @@ func CollectAllMetrics() (*AllMetrics, error) {
 	var wg sync.WaitGroup
 	var mu sync.Mutex
 	var errs []error
+	var timings []CollectorTiming
+
+	// track records how long a collector goroutine ran (use with defer)
+	track := func(name string, start time.Time) {
+		mu.Lock()
+		timings = append(timings, CollectorTiming{Name: name, Duration: time.Since(start)})
+		mu.Unlock()
+	}
+
+	// fail records the error of a collector, the others' metrics are still returned
+	fail := func(name string, err error) {
+		mu.Lock()
+		errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		mu.Unlock()
+	}
 
 	// System summary
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
+		defer track("summary", time.Now())
 		if summary, err := collectSystemSummary(); err == nil {
 			mu.Lock()
 			m.Summary = summary
 			mu.Unlock()
 		} else {
-			mu.Lock()
-			errs = append(errs, fmt.Errorf("summary: %w", err))
-			mu.Unlock()
+			fail("summary", err)
 		}
 	}()
 
@@ -155,10 +228,13 @@ func CollectAllMetrics() (*AllMetrics, error) {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
+		defer track("cpu_cores", time.Now())
 		if cores, err := collectCPUCores(); err == nil {
 			mu.Lock()
 			m.CPUCores = cores
 			mu.Unlock()
+		} else {
+			fail("cpu_cores", err)
 		}
 	}()
 
@@ -166,10 +242,13 @@ func CollectAllMetrics() (*AllMetrics, error) {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
+		defer track("memory", time.Now())
 		if memory, err := collectMemory(); err == nil {
 			mu.Lock()
 			m.Memory = memory
 			mu.Unlock()
+		} else {
+			fail("memory", err)
 		}
 	}()
 
@@ -177,10 +256,13 @@ func CollectAllMetrics() (*AllMetrics, error) {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
+		defer track("disks", time.Now())
 		if disks, err := collectDisks(); err == nil {
 			mu.Lock()
 			m.Disks = disks
 			mu.Unlock()
+		} else {
+			fail("disks", err)
 		}
 	}()
 
@@ -188,10 +270,13 @@ func CollectAllMetrics() (*AllMetrics, error) {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
+		defer track("networks", time.Now())
 		if networks, err := collectNetworks(); err == nil {
 			mu.Lock()
 			m.Networks = networks
 			mu.Unlock()
+		} else {
+			fail("networks", err)
 		}
 	}()
 
@@ -199,10 +284,13 @@ func CollectAllMetrics() (*AllMetrics, error) {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
+		defer track("processes", time.Now())
 		if processes, err := collectProcesses(30); err == nil {
 			mu.Lock()
 			m.Processes = processes
 			mu.Unlock()
+		} else {
+			fail("processes", err)
 		}
 	}()
 
@@ -210,10 +298,16 @@ func CollectAllMetrics() (*AllMetrics, error) {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
+		defer track("services", time.Now())
 		if services, err := GetServices(); err == nil {
+			if services == nil {
+				services = []ServiceInfo{} // nil is left for a failed listing
+			}
 			mu.Lock()
 			m.Services = services
 			mu.Unlock()
+		} else {
+			fail("services", err)
 		}
 	}()
 
@@ -221,10 +315,54 @@ func CollectAllMetrics() (*AllMetrics, error) {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
+		defer track("containers", time.Now())
 		if containers, err := collectContainers(); err == nil {
 			mu.Lock()
 			m.Containers = containers
 			mu.Unlock()
+		} else {
+			fail("containers", err)
+		}
+	}()
+
+	// Container runtime health (docker/podman daemon)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer track("runtimes", time.Now())
+		runtimes := GetContainerRuntimes()
+		mu.Lock()
+		m.Runtimes = runtimes
+		mu.Unlock()
+	}()
+
+	// systemd units (failed + tracked)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer track("units", time.Now())
+		if units, err := GetUnits(); err == nil {
+			mu.Lock()
+			m.Units = units
+			mu.Unlock()
+		} else {
+			fail("units", err)
+		}
+	}()
+
+	// Temperature sensors and CPU thermal throttling (absent on most VMs)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer track("thermal", time.Now())
+		temps, err := GetTemperatures()
+		throttle := GetThermalThrottle()
+		mu.Lock()
+		m.Temperatures = temps
+		m.Throttle = throttle
+		mu.Unlock()
+		if err != nil && len(temps) == 0 {
+			logger.Debug("Temperature sensors unavailable: %v", err)
 		}
 	}()
 
@@ -244,11 +382,13 @@ func CollectAllMetrics() (*AllMetrics, error) {
 		// Если кэш пустой (холодный старт) — возвращаем только что собранные метрики
 	}
 
-	if len(errs) > 0 {
-		return m, errs[0]
-	}
+	sort.Slice(timings, func(i, j int) bool {
+		return timings[i].Duration > timings[j].Duration
+	})
+	recordCycleDuration(time.Since(start))
 
-	return m, nil
+	// Partial failures still return the metrics that were collected
+	return m, timings, errors.Join(errs...)
 }
 
 // =============================================================================
@@ -310,6 +450,7 @@ func clearCycleCache() {
 	cycleProcesses = nil
 	cycleConnections = nil
 	cycleCacheMu.Unlock()
+	pruneContainerLabels()
 }
 
 // =============================================================================
@@ -367,7 +508,7 @@ func collectSystemSummary() (*SystemSummary, error) {
 			if shouldSkipPartition(p) {
 				continue
 			}
-			if usage, err := disk.Usage(p.Mountpoint); err == nil {
+			if usage, err := diskUsageWithTimeout(p.Mountpoint); err == nil {
 				s.DiskTotal += usage.Total
 				s.DiskUsed += usage.Used
 				s.DiskFree += usage.Free
@@ -399,17 +540,26 @@ func collectSystemSummary() (*SystemSummary, error) {
 		prevStatsMu.Unlock()
 	}
 
-	// Network - aggregate all interfaces
-	if netIO, err := net.IOCounters(false); err == nil && len(netIO) > 0 {
-		n := netIO[0]
-		s.NetBytesRecv = n.BytesRecv
-		s.NetBytesSent = n.BytesSent
-		s.NetPacketsRecv = n.PacketsRecv
-		s.NetPacketsSent = n.PacketsSent
-		s.NetErrorsIn = uint32(n.Errin)
-		s.NetErrorsOut = uint32(n.Errout)
-		s.NetDropsIn = uint32(n.Dropin)
-		s.NetDropsOut = uint32(n.Dropout)
+	// Network - sum of the interfaces reported per interface (loopback and veth
+	// excluded), or only the primary one with network_summary: primary
+	if netIO, err := net.IOCounters(true); err == nil {
+		primary := ""
+		if networkSummaryPrimary.Load() {
+			primary = primaryInterface()
+		}
+		for _, n := range netIO {
+			if isSkippedInterface(n.Name) || (primary != "" && n.Name != primary) {
+				continue
+			}
+			s.NetBytesRecv += n.BytesRecv
+			s.NetBytesSent += n.BytesSent
+			s.NetPacketsRecv += n.PacketsRecv
+			s.NetPacketsSent += n.PacketsSent
+			s.NetErrorsIn += uint32(n.Errin)
+			s.NetErrorsOut += uint32(n.Errout)
+			s.NetDropsIn += uint32(n.Dropin)
+			s.NetDropsOut += uint32(n.Dropout)
+		}
 	}
 
 	// Connections count and states (use cached)
@@ -544,7 +694,7 @@ func collectDisks() ([]DiskMetrics, error) {
 			continue
 		}
 
-		usage, err := disk.Usage(p.Mountpoint)
+		usage, err := diskUsageWithTimeout(p.Mountpoint)
 		if err != nil {
 			continue
 		}
@@ -568,7 +718,9 @@ func collectDisks() ([]DiskMetrics, error) {
 		if io, ok := ioCounters[deviceName]; ok {
 			prevStatsMu.RLock()
 			if prevDiskStats != nil && elapsed > 0 {
-				if prevIO, ok := prevDiskStats[deviceName]; ok {
+				// Skip devices whose counters were reset (re-attached), deltas would be bogus
+				if prevIO, ok := prevDiskStats[deviceName]; ok && io.ReadCount >= prevIO.ReadCount && io.WriteCount >= prevIO.WriteCount &&
+					io.ReadBytes >= prevIO.ReadBytes && io.WriteBytes >= prevIO.WriteBytes {
 					d.IOPSRead = uint32(float64(io.ReadCount-prevIO.ReadCount) / elapsed)
 					d.IOPSWrite = uint32(float64(io.WriteCount-prevIO.WriteCount) / elapsed)
 					d.ThroughputRead = uint64(float64(io.ReadBytes-prevIO.ReadBytes) / elapsed)
@@ -584,6 +736,30 @@ func collectDisks() ([]DiskMetrics, error) {
 	return disks, nil
 }
 
+// isSkippedInterface reports interfaces left out of network metrics: loopback,
+// whose traffic never leaves the host, and the veth ends of container networks,
+// which duplicate the traffic of their bridge
+func isSkippedInterface(name string) bool {
+	return strings.HasPrefix(name, "lo") || strings.HasPrefix(name, "veth")
+}
+
+// primaryInterface returns the interface holding PrimaryIP ("" when not found)
+func primaryInterface() string {
+	ip := PrimaryIP()
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return ""
+	}
+	for _, iface := range interfaces {
+		for _, addr := range iface.Addrs {
+			if strings.SplitN(addr.Addr, "/", 2)[0] == ip {
+				return iface.Name
+			}
+		}
+	}
+	return ""
+}
+
 func collectNetworks() ([]NetworkInterfaceMetrics, error) {
 	interfaces, err := net.Interfaces()
 	if err != nil {
@@ -607,8 +783,7 @@ func collectNetworks() ([]NetworkInterfaceMetrics, error) {
 	var networks []NetworkInterfaceMetrics
 
 	for _, iface := range interfaces {
-		// Skip loopback
-		if strings.HasPrefix(iface.Name, "lo") || strings.HasPrefix(iface.Name, "veth") {
+		if isSkippedInterface(iface.Name) {
 			continue
 		}
 
@@ -617,6 +792,7 @@ func collectNetworks() ([]NetworkInterfaceMetrics, error) {
 			MACAddress:  iface.HardwareAddr,
 			IPAddresses: make([]string, 0),
 			MTU:         uint16(iface.MTU),
+			SpeedMbps:   interfaceSpeedMbps(iface.Name),
 		}
 
 		// Get IP addresses
@@ -667,6 +843,20 @@ func collectNetworks() ([]NetworkInterfaceMetrics, error) {
 	return networks, nil
 }
 
+// interfaceSpeedMbps returns the negotiated link speed, or 0 when the OS doesn't
+// expose it (non-Linux, virtual interfaces, or a link that is down)
+func interfaceSpeedMbps(name string) uint32 {
+	data, err := os.ReadFile(filepath.Join("/sys/class/net", name, "speed"))
+	if err != nil {
+		return 0
+	}
+	speed, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil || speed <= 0 {
+		return 0 // -1 when unknown
+	}
+	return uint32(speed)
+}
+
 func collectProcesses(limit int) ([]ProcessInfo, error) {
 	procs, err := getCachedProcesses()
 	if err != nil {
@@ -677,6 +867,7 @@ func collectProcesses(limit int) ([]ProcessInfo, error) {
 	prevProcCPUMu.RLock()
 	prevTimes := prevProcCPUTimes
 	prevTime := prevProcCPUTime
+	prevIO := prevProcIO
 	prevProcCPUMu.RUnlock()
 
 	elapsed := time.Since(prevTime).Seconds()
@@ -684,25 +875,65 @@ func collectProcesses(limit int) ([]ProcessInfo, error) {
 
 	// Current CPU times map for next cycle
 	currentTimes := make(map[int32]float64)
+	currentIO := make(map[int32][2]uint64)
+
+	// Handles for the processes kept, used to fill FD counts for the top ones only
+	handles := make(map[int]*process.Process)
 
 	var processes []ProcessInfo
+	var forced []ProcessInfo // process_includes matches, kept even outside the top list
 
 	for _, p := range procs {
 		name, _ := p.Name()
 		if name == "catops" || strings.HasPrefix(name, "catops-") {
 			continue
 		}
+		if isProcessExcluded(name) {
+			continue
+		}
+		included := isProcessIncluded(name)
+
+		// Cumulative IO (one read of /proc/[pid]/io, not available on macOS). Read
+		// for every process, a disk hog often has a tiny RSS.
+		var ioRead, ioWrite, readRate, writeRate uint64
+		var io *process.IOCountersStat
+		if !skipProcessReads(p.Pid) {
+			io, _ = p.IOCounters()
+		}
+		if io != nil {
+			ioRead, ioWrite = io.ReadBytes, io.WriteBytes
+
+			// Rates count storage IO only where the kernel tells it apart (Linux
+			// read_bytes/write_bytes), rchar/wchar include pipes and sockets
+			diskRead, diskWrite := io.ReadBytes, io.WriteBytes
+			if runtime.GOOS == "linux" {
+				diskRead, diskWrite = io.DiskReadBytes, io.DiskWriteBytes
+			}
+			currentIO[p.Pid] = [2]uint64{diskRead, diskWrite}
+
+			if prev, ok := prevIO[p.Pid]; ok && elapsed > 0 {
+				// Counters only grow for a PID, a drop means it was reused
+				if diskRead >= prev[0] && diskWrite >= prev[1] {
+					readRate = uint64(float64(diskRead-prev[0]) / elapsed)
+					writeRate = uint64(float64(diskWrite-prev[1]) / elapsed)
+				}
+			}
+		}
 
 		memPercent, _ := p.MemoryPercent()
 
-		// Filter by memory (processes with < 0.1% memory are not interesting)
-		if memPercent < 0.1 {
+		// Filter by memory (processes with < 0.1% memory are not interesting unless doing IO)
+		if memPercent < 0.1 && !included && readRate+writeRate == 0 {
 			continue
 		}
 
 		pi := ProcessInfo{
-			PID:  int(p.Pid),
-			Name: name,
+			PID:          int(p.Pid),
+			Name:         name,
+			IOReadBytes:  ioRead,
+			IOWriteBytes: ioWrite,
+			IOReadRate:   readRate,
+			IOWriteRate:  writeRate,
 		}
 
 		pi.MemoryPercent = float64(memPercent)
@@ -747,12 +978,17 @@ func collectProcesses(limit int) ([]ProcessInfo, error) {
 		pi.MemoryUsage = pi.MemoryPercent
 		pi.MemoryKB = int64(pi.MemoryRSS / 1024)
 
+		handles[pi.PID] = p
 		processes = append(processes, pi)
+		if included {
+			forced = append(forced, pi)
+		}
 	}
 
 	// Save current times for next cycle
 	prevProcCPUMu.Lock()
 	prevProcCPUTimes = currentTimes
+	prevProcIO = currentIO
 	prevProcCPUTime = time.Now()
 	prevProcCPUMu.Unlock()
 
@@ -766,39 +1002,201 @@ func collectProcesses(limit int) ([]ProcessInfo, error) {
 	})
 
 	if len(processes) > limit {
-		return processes[:limit], nil
+		// The busiest processes by IO are kept too, a disk hog rarely tops CPU
+		byIO := make([]ProcessInfo, len(processes))
+		copy(byIO, processes)
+		sort.Slice(byIO, func(i, j int) bool {
+			return byIO[i].IOReadRate+byIO[i].IOWriteRate > byIO[j].IOReadRate+byIO[j].IOWriteRate
+		})
+		for _, pi := range byIO[:min(len(byIO), topIOProcesses)] {
+			if pi.IOReadRate+pi.IOWriteRate > 0 {
+				forced = append(forced, pi)
+			}
+		}
+
+		processes = processes[:limit]
+
+		kept := make(map[int]bool, len(processes))
+		for _, pi := range processes {
+			kept[pi.PID] = true
+		}
+		for _, pi := range forced {
+			if !kept[pi.PID] {
+				kept[pi.PID] = true
+				processes = append(processes, pi)
+			}
+		}
+	}
+
+	// Open FDs and the nofile limit need two more /proc reads, so only for the top processes
+	for i := range processes {
+		fillFDUsage(&processes[i], handles[processes[i].PID])
+	}
+
+	// Owner, parent and IO details cost several more reads each, only for the ones exported
+	for i := range processes[:min(len(processes), getProcessDetailLimit())] {
+		fillProcessDetails(&processes[i], handles[processes[i].PID])
 	}
 
 	return processes, nil
 }
 
+// fillFDUsage sets the open file descriptor count and soft RLIMIT_NOFILE of a process.
+// Both are only readable for our own processes unless running as root.
+func fillFDUsage(pi *ProcessInfo, p *process.Process) {
+	if p == nil || skipProcessReads(p.Pid) {
+		return
+	}
+	if fds, err := p.NumFDs(); err == nil && fds > 0 {
+		pi.NumFDs = uint32(fds)
+	}
+	if limits, err := p.Rlimit(); err == nil {
+		for _, l := range limits {
+			if l.Resource == process.RLIMIT_NOFILE && l.Soft != ^uint64(0) { // skip RLIM_INFINITY
+				pi.FDLimit = l.Soft
+				break
+			}
+		}
+	}
+}
+
+// fillProcessDetails sets executable path, owner, parent PID and thread count
+// of a process. Fields that can't be read (other users' processes
+// when not root, IO counters on macOS) are left empty.
+func fillProcessDetails(pi *ProcessInfo, p *process.Process) {
+	if p == nil {
+		return
+	}
+	if exe, err := p.Exe(); err == nil {
+		pi.Exe = exe
+	}
+	if user, err := p.Username(); err == nil {
+		pi.User = user
+	}
+	if uids, err := p.Uids(); err == nil && len(uids) > 1 {
+		pi.UID = int32(uids[1]) // effective
+	}
+	if gids, err := p.Gids(); err == nil && len(gids) > 1 {
+		pi.GID = int32(gids[1])
+	}
+	if ppid, err := p.Ppid(); err == nil {
+		pi.PPID = int(ppid)
+	}
+	if threads, err := p.NumThreads(); err == nil && threads > 0 {
+		pi.NumThreads = uint16(min(threads, math.MaxUint16))
+	}
+}
+
 // =============================================================================
 // Container Collection
 // =============================================================================
 
 func collectContainers() ([]ContainerMetrics, error) {
+	var errs []error
+
 	// Try docker first
 	containers, err := collectDockerContainers()
 	if err == nil && len(containers) > 0 {
 		return containers, nil
 	}
+	if err != nil && !runtimeUnavailable(err) {
+		errs = append(errs, fmt.Errorf("docker: %w", err))
+	}
 
 	// Try podman
 	containers, err = collectPodmanContainers()
 	if err == nil && len(containers) > 0 {
 		return containers, nil
 	}
+	if err != nil && !runtimeUnavailable(err) {
+		errs = append(errs, fmt.Errorf("podman: %w", err))
+	}
+
+	// No runtime installed or no running containers is not an error
+	return nil, errors.Join(errs...)
+}
 
-	return nil, nil
+// unavailableRuntimeErrors are CLI messages of a runtime whose daemon is
+// stopped or whose socket this user can't open
+var unavailableRuntimeErrors = []string{
+	"cannot connect to the docker daemon",
+	"is the docker daemon running",
+	"unable to connect to podman",
+	"permission denied",
+	"connection refused",
+}
+
+// runtimeUnavailable reports whether err means the runtime can't be used at all
+// (not installed, daemon down, socket not accessible, or backing off after a
+// timeout). That is "no containers" here, the runtimes collector already
+// reports the daemon as unresponsive, so it isn't an error on every cycle.
+func runtimeUnavailable(err error) bool {
+	if errors.Is(err, exec.ErrNotFound) || errors.Is(err, errContainerCLISkipped) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	for _, s := range unavailableRuntimeErrors {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	// containerCLITimeout bounds a single docker/podman call so a wedged daemon
+	// can't stall the whole collection cycle (CollectAllMetrics waits on all collectors)
+	containerCLITimeout = 5 * time.Second
+
+	// containerCLIBackoff is how long a runtime is skipped after it timed out
+	containerCLIBackoff = 5 * time.Minute
+)
+
+// errContainerCLISkipped is returned while a runtime is skipped after a timeout
+var errContainerCLISkipped = errors.New("skipped after a recent timeout")
+
+var (
+	// Runtimes (docker/podman) skipped until the given time after a hang
+	containerCLISkipUntil   = make(map[string]time.Time)
+	containerCLISkipUntilMu sync.Mutex
+)
+
+// runContainerCLI runs a docker/podman command with a timeout. After a timeout
+// the runtime is skipped for containerCLIBackoff instead of blocking every cycle.
+func runContainerCLI(timeout time.Duration, name string, args ...string) ([]byte, error) {
+	containerCLISkipUntilMu.Lock()
+	skipUntil := containerCLISkipUntil[name]
+	containerCLISkipUntilMu.Unlock()
+	if time.Now().Before(skipUntil) {
+		return nil, fmt.Errorf("%s %w", name, errContainerCLISkipped)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.WaitDelay = time.Second // don't wait on pipes held open by children of a killed CLI
+	output, err := cmd.Output()
+	if ctx.Err() == context.DeadlineExceeded {
+		containerCLISkipUntilMu.Lock()
+		containerCLISkipUntil[name] = time.Now().Add(containerCLIBackoff)
+		containerCLISkipUntilMu.Unlock()
+		logger.Warning("%s %s timed out after %v, skipping %s for %v", name, args[0], timeout, name, containerCLIBackoff)
+		return nil, fmt.Errorf("%s %s timed out after %v", name, args[0], timeout)
+	}
+	// Keep what the CLI said, "exit status 1" alone doesn't tell why
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(bytes.TrimSpace(exitErr.Stderr)) > 0 {
+		msg, _, _ := strings.Cut(strings.TrimSpace(string(exitErr.Stderr)), "\n")
+		return output, fmt.Errorf("%w: %s", err, msg)
+	}
+	return output, err
 }
 
 func collectDockerContainers() ([]ContainerMetrics, error) {
 	// Single call to docker stats - gets all running containers at once
 	// Skip "docker ps" check - if no containers, stats returns empty
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	cmd := exec.CommandContext(ctx, "docker", "stats", "--no-stream", "--format", "{{json .}}")
-	output, err := cmd.Output()
+	output, err := runContainerCLI(containerCLITimeout, "docker", "stats", "--no-stream", "--format", "{{json .}}")
 	if err != nil {
 		return nil, err
 	}
@@ -901,6 +1299,9 @@ func collectDockerContainers() ([]ContainerMetrics, error) {
 	// Enrich containers with image, health, ports, started_at via docker inspect
 	enrichDockerContainers(containers)
 
+	// Labels, service name and environment (cached per container)
+	applyContainerLabels("docker", containers)
+
 	// Collect logs for containers using global log collector (for deduplication)
 	logCollector := GetLogCollector()
 	for i := range containers {
@@ -923,14 +1324,10 @@ func enrichDockerContainers(containers []ContainerMetrics) {
 		ids[i] = c.ContainerID
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
 	args := append([]string{"inspect", "--format",
 		`{"id":"{{.Id}}","image":"{{.Config.Image}}","health":"{{if .State.Health}}{{.State.Health.Status}}{{end}}","started_at":"{{.State.StartedAt}}","ports":"{{range $p,$b := .NetworkSettings.Ports}}{{$p}},{{end}}"}`},
 		ids...)
-	cmd := exec.CommandContext(ctx, "docker", args...)
-	output, err := cmd.Output()
+	output, err := runContainerCLI(containerCLITimeout, "docker", args...)
 	if err != nil {
 		return
 	}
@@ -998,11 +1395,7 @@ func enrichDockerContainers(containers []ContainerMetrics) {
 }
 
 func collectPodmanContainers() ([]ContainerMetrics, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	cmd := exec.CommandContext(ctx, "podman", "ps", "-q")
-	output, err := cmd.Output()
+	output, err := runContainerCLI(containerCLITimeout, "podman", "ps", "-q")
 	if err != nil {
 		return nil, err
 	}
@@ -1012,8 +1405,7 @@ func collectPodmanContainers() ([]ContainerMetrics, error) {
 	}
 
 	// Similar to docker but with podman
-	cmd = exec.CommandContext(ctx, "podman", "stats", "--no-stream", "--format", "json")
-	output, err = cmd.Output()
+	output, err = runContainerCLI(containerCLITimeout, "podman", "stats", "--no-stream", "--format", "json")
 	if err != nil {
 		return nil, err
 	}
@@ -1057,6 +1449,8 @@ func collectPodmanContainers() ([]ContainerMetrics, error) {
 		}
 	}
 
+	applyContainerLabels("podman", containers)
+
 	return containers, nil
 }
 
@@ -1064,8 +1458,13 @@ func collectPodmanContainers() ([]ContainerMetrics, error) {
 // Helper Functions
 // =============================================================================
 
-// shouldSkipPartition returns true for pseudo filesystems that should be excluded from metrics
+// shouldSkipPartition returns true for pseudo filesystems that should be excluded from metrics.
+// With a disk_mounts allowlist only the listed mount points are kept, whatever their type.
 func shouldSkipPartition(p disk.PartitionStat) bool {
+	if allowed, ok := isAllowedMount(p.Mountpoint); ok {
+		return !allowed
+	}
+
 	// Linux pseudo filesystems
 	if strings.HasPrefix(p.Device, "/dev/loop") ||
 		p.Fstype == "squashfs" ||
@@ -1083,6 +1482,11 @@ func shouldSkipPartition(p disk.PartitionStat) bool {
 		return true
 	}
 
+	// Network/remote filesystems (configurable via skip_fstypes)
+	if isSkippedFstype(p.Fstype) {
+		return true
+	}
+
 	// Skip system volumes that aren't the main data partition
 	// On macOS, /System/Volumes/* except Data are system partitions
 	if strings.HasPrefix(p.Mountpoint, "/System/Volumes/") &&
@@ -1093,6 +1497,138 @@ func shouldSkipPartition(p disk.PartitionStat) bool {
 	return false
 }
 
+// SetProcessDetailLimit sets how many of the top processes get exe, user, PPID,
+// thread and IO details (process_detail_limit, 0 = none). Processes past the
+// limit are not exported, so enriching them would only cost syscalls.
+func SetProcessDetailLimit(n int) {
+	processDetailLimitMu.Lock()
+	defer processDetailLimitMu.Unlock()
+	processDetailLimit = max(n, 0)
+}
+
+// getProcessDetailLimit returns the configured process enrichment depth
+func getProcessDetailLimit() int {
+	processDetailLimitMu.RLock()
+	defer processDetailLimitMu.RUnlock()
+	return processDetailLimit
+}
+
+// SetNetworkSummary selects the interfaces summed into the summary network
+// totals: "primary" counts only the interface of the primary IP, anything else
+// all interfaces but loopback and veth (the ones reported per interface)
+func SetNetworkSummary(mode string) {
+	networkSummaryPrimary.Store(mode == "primary")
+}
+
+// SetDiskMounts restricts disk metrics to the given mount points (disk_mounts).
+// An empty list restores the default skip rules.
+func SetDiskMounts(mounts []string) {
+	diskMountsMu.Lock()
+	defer diskMountsMu.Unlock()
+	diskMounts = nil
+	for _, m := range mounts {
+		if m = normalizeMountpoint(m); m != "" {
+			if diskMounts == nil {
+				diskMounts = make(map[string]bool)
+			}
+			diskMounts[m] = true
+		}
+	}
+}
+
+// isAllowedMount reports whether mountpoint is in the disk_mounts allowlist;
+// ok is false when no allowlist is configured
+func isAllowedMount(mountpoint string) (allowed, ok bool) {
+	diskMountsMu.RLock()
+	defer diskMountsMu.RUnlock()
+	if diskMounts == nil {
+		return false, false
+	}
+	return diskMounts[normalizeMountpoint(mountpoint)], true
+}
+
+// normalizeMountpoint drops surrounding spaces and a trailing slash ("/data/" -> "/data")
+func normalizeMountpoint(m string) string {
+	m = strings.TrimSpace(m)
+	if len(m) > 1 {
+		m = strings.TrimRight(m, "/\\")
+		if m == "" {
+			m = "/"
+		}
+	}
+	return m
+}
+
+// SetSkippedFstypes overrides the list of network/remote filesystem types excluded from disk metrics.
+// Entries ending in ".*" match by prefix (e.g. "fuse.*" matches "fuse.sshfs").
+func SetSkippedFstypes(fstypes []string) {
+	skippedFstypesMu.Lock()
+	defer skippedFstypesMu.Unlock()
+	skippedFstypes = append([]string(nil), fstypes...)
+}
+
+// isSkippedFstype checks a filesystem type against the configured skip list
+func isSkippedFstype(fstype string) bool {
+	skippedFstypesMu.RLock()
+	defer skippedFstypesMu.RUnlock()
+
+	for _, t := range skippedFstypes {
+		if prefix, ok := strings.CutSuffix(t, "*"); ok {
+			if strings.HasPrefix(fstype, prefix) {
+				return true
+			}
+		} else if fstype == t {
+			return true
+		}
+	}
+	return false
+}
+
+// diskUsageWithTimeout wraps disk.Usage so a hung mount can't stall the collection cycle.
+// statfs can't be cancelled, so a stuck call is abandoned rather than interrupted,
+// and the mount is skipped until that call returns.
+func diskUsageWithTimeout(mountpoint string) (*disk.UsageStat, error) {
+	type result struct {
+		usage *disk.UsageStat
+		err   error
+	}
+
+	stuckMountsMu.Lock()
+	_, stuck := stuckMounts[mountpoint]
+	stuckMountsMu.Unlock()
+	if stuck {
+		return nil, fmt.Errorf("disk usage for %s skipped, the previous call is still blocked", mountpoint)
+	}
+
+	ch := make(chan result, 1)
+	done := make(chan struct{})
+	go func() {
+		usage, err := disk.Usage(mountpoint)
+		ch <- result{usage, err}
+
+		stuckMountsMu.Lock()
+		close(done)
+		if stuckMounts[mountpoint] == done {
+			delete(stuckMounts, mountpoint)
+		}
+		stuckMountsMu.Unlock()
+	}()
+
+	select {
+	case r := <-ch:
+		return r.usage, r.err
+	case <-time.After(diskUsageTimeout):
+		stuckMountsMu.Lock()
+		select {
+		case <-done: // returned just now
+		default:
+			stuckMounts[mountpoint] = done
+		}
+		stuckMountsMu.Unlock()
+		return nil, fmt.Errorf("disk usage for %s timed out after %v", mountpoint, diskUsageTimeout)
+	}
+}
+
 func truncateString(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
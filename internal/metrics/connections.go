@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shirou/gopsutil/v4/process"
+)
+
+// TCPStates are the connection states GetRemoteConnections filters by
+var TCPStates = []string{
+	"ESTABLISHED", "SYN_SENT", "SYN_RECV", "FIN_WAIT1", "FIN_WAIT2", "TIME_WAIT",
+	"CLOSE", "CLOSE_WAIT", "LAST_ACK", "CLOSING",
+}
+
+// GetRemoteConnections groups the TCP connections with a remote end by remote
+// IP, most connections first. state keeps only connections in that state (all
+// when empty); withProcesses names the local process owning each connection,
+// which needs root for other users' sockets.
+func GetRemoteConnections(state string, withProcesses bool) ([]RemoteConnections, error) {
+	conns, err := getCachedConnections()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[int32]string)
+	processName := func(pid int32) string {
+		if pid == 0 {
+			return "unknown"
+		}
+		if name, ok := names[pid]; ok {
+			return name
+		}
+		name := "unknown"
+		if p, err := process.NewProcess(pid); err == nil {
+			if n, err := p.Name(); err == nil && n != "" {
+				name = n
+			}
+		}
+		name = fmt.Sprintf("%s (%d)", name, pid)
+		names[pid] = name
+		return name
+	}
+
+	groups := make(map[string]*RemoteConnections)
+	for _, c := range conns {
+		if c.Raddr.IP == "" || c.Raddr.Port == 0 || c.Status == "LISTEN" {
+			continue
+		}
+		if state != "" && !strings.EqualFold(c.Status, state) {
+			continue
+		}
+
+		ip := strings.TrimPrefix(c.Raddr.IP, "::ffff:") // IPv4-mapped
+		g, ok := groups[ip]
+		if !ok {
+			g = &RemoteConnections{RemoteIP: ip, States: make(map[string]int)}
+			groups[ip] = g
+		}
+		g.Count++
+		g.States[c.Status]++
+		if withProcesses {
+			if g.Processes == nil {
+				g.Processes = make(map[string]int)
+			}
+			g.Processes[processName(c.Pid)]++
+		}
+	}
+
+	result := make([]RemoteConnections, 0, len(groups))
+	for _, g := range groups {
+		result = append(result, *g)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].RemoteIP < result[j].RemoteIP
+	})
+	return result, nil
+}
@@ -0,0 +1,162 @@
+package metrics
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+
+	constants "catops/config"
+)
+
+// composeServiceLabel is set by docker compose on every service container
+const composeServiceLabel = "com.docker.compose.service"
+
+var (
+	// Label keys that override the service name / supply the environment
+	// (container_service_label, container_env_label)
+	containerServiceLabel = constants.DEFAULT_CONTAINER_SERVICE_LABEL
+	containerEnvLabel     = constants.DEFAULT_CONTAINER_ENV_LABEL
+	containerLabelKeysMu  sync.RWMutex
+
+	// Labels are immutable for a container's lifetime, so they are fetched
+	// once per container (keyed by runtime and ID, docker and podman may both
+	// run) and dropped by pruneContainerLabels once the container is gone
+	containerLabels   = make(map[string]*cachedLabels)
+	containerLabelsMu sync.Mutex
+)
+
+// cachedLabels are the labels of one container, seen is set when a collection
+// reported the container since the last prune
+type cachedLabels struct {
+	labels map[string]string
+	seen   bool
+}
+
+// containerLabelKey keys the label cache
+func containerLabelKey(runtime, id string) string {
+	return runtime + "/" + id
+}
+
+// SetContainerLabelKeys sets the container label keys read as the service name
+// and environment. An empty key disables that override.
+func SetContainerLabelKeys(serviceKey, envKey string) {
+	containerLabelKeysMu.Lock()
+	defer containerLabelKeysMu.Unlock()
+	containerServiceLabel = strings.TrimSpace(serviceKey)
+	containerEnvLabel = strings.TrimSpace(envKey)
+}
+
+// getContainerLabelKeys returns the configured service and environment label keys
+func getContainerLabelKeys() (string, string) {
+	containerLabelKeysMu.RLock()
+	defer containerLabelKeysMu.RUnlock()
+	return containerServiceLabel, containerEnvLabel
+}
+
+// applyContainerLabels fills Labels, Service and Environment of containers
+// collected from runtime (docker/podman). Only containers not seen before are
+// inspected, in a single batched call.
+func applyContainerLabels(runtime string, containers []ContainerMetrics) {
+	containerLabelsMu.Lock()
+	defer containerLabelsMu.Unlock()
+
+	var missing []string
+	for _, c := range containers {
+		if cached, ok := containerLabels[containerLabelKey(runtime, c.ContainerID)]; ok {
+			cached.seen = true
+		} else {
+			missing = append(missing, c.ContainerID)
+		}
+	}
+
+	if len(missing) > 0 {
+		args := append([]string{"inspect", "--format", "{{.Id}} {{json .Config.Labels}}"}, missing...)
+		// A container that exited meanwhile fails the call but the others are still printed
+		output, _ := runContainerCLI(containerCLITimeout, runtime, args...)
+		for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+			fullID, labelsJSON, ok := strings.Cut(strings.TrimSpace(line), " ")
+			if !ok {
+				continue
+			}
+			var labels map[string]string
+			if err := json.Unmarshal([]byte(labelsJSON), &labels); err != nil {
+				continue
+			}
+			// Collectors report short IDs, inspect prints full ones
+			for _, id := range missing {
+				if strings.HasPrefix(fullID, id) {
+					containerLabels[containerLabelKey(runtime, id)] = &cachedLabels{labels: labels, seen: true}
+				}
+			}
+		}
+	}
+
+	serviceKey, envKey := getContainerLabelKeys()
+	for i := range containers {
+		c := &containers[i]
+		var labels map[string]string
+		if cached := containerLabels[containerLabelKey(runtime, c.ContainerID)]; cached != nil {
+			labels = cached.labels
+		}
+
+		c.Labels = formatLabels(labels)
+		c.Service = serviceFromLabels(labels, serviceKey, c.ContainerName)
+		if envKey != "" {
+			c.Environment = labels[envKey]
+		}
+	}
+}
+
+// serviceFromLabels picks a container's service: the configured service
+// label, else the compose service, else fallback
+func serviceFromLabels(labels map[string]string, serviceKey, fallback string) string {
+	if name := labels[serviceKey]; serviceKey != "" && name != "" {
+		return name
+	}
+	if name := labels[composeServiceLabel]; name != "" {
+		return name
+	}
+	return fallback
+}
+
+// labeledService returns the service set by the configured service label of a
+// container whose labels are cached, "" when there is none
+func labeledService(runtime, id string) string {
+	serviceKey, _ := getContainerLabelKeys()
+	if serviceKey == "" {
+		return ""
+	}
+	containerLabelsMu.Lock()
+	defer containerLabelsMu.Unlock()
+	if cached := containerLabels[containerLabelKey(runtime, id)]; cached != nil {
+		return cached.labels[serviceKey]
+	}
+	return ""
+}
+
+// pruneContainerLabels drops the labels of containers no collection reported
+// since the previous prune. Called once per collection cycle, so containers of
+// every runtime have been seen in between.
+func pruneContainerLabels() {
+	containerLabelsMu.Lock()
+	defer containerLabelsMu.Unlock()
+	for key, cached := range containerLabels {
+		if !cached.seen {
+			delete(containerLabels, key)
+			continue
+		}
+		cached.seen = false
+	}
+}
+
+// formatLabels renders labels as sorted "key=value" pairs joined by commas
+// (the docker ps --format {{.Labels}} layout)
+func formatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,362 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+
+	"catops/internal/logger"
+	"catops/pkg/utils"
+)
+
+const (
+	metricsBufferDir       = "/tmp/catops_metrics_buffer"
+	metricsBufferMaxBytes  = 50 * 1024 * 1024 // Total disk usage cap for failed batches
+	metricsReplayPerExport = 10               // Max buffered batches replayed after a successful export
+)
+
+// bufferingExporter wraps an OTLP exporter and keeps failed batches on disk.
+// Buffered batches are replayed with their original timestamps once the
+// backend is reachable again, so the dashboard backfills after an outage.
+type bufferingExporter struct {
+	sdkmetric.Exporter
+	endpoint string
+	dir      string
+	maxBytes int64
+	lastErr  error // result of the most recent export
+	mu       sync.Mutex
+}
+
+// newBufferingExporter creates a disk-backed wrapper around exporter.
+// Each endpoint gets its own buffer directory so outages are replayed independently.
+func newBufferingExporter(exporter sdkmetric.Exporter, endpoint, dir string) *bufferingExporter {
+	return &bufferingExporter{
+		Exporter: exporter,
+		endpoint: endpoint,
+		dir:      dir,
+		maxBytes: metricsBufferMaxBytes,
+	}
+}
+
+// LastError returns the error of the most recent export, nil if it succeeded
+func (e *bufferingExporter) LastError() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.lastErr
+}
+
+// Export sends metrics, buffering them to disk on failure and replaying
+// previously buffered batches on success
+func (e *bufferingExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	err := e.Exporter.Export(ctx, rm)
+
+	e.mu.Lock()
+	e.lastErr = err
+	e.mu.Unlock()
+
+	if err != nil {
+		if saveErr := e.saveToDisk(rm); saveErr != nil {
+			logger.Warning("[OTLP] Failed to buffer metrics for %s to disk: %v", e.endpoint, saveErr)
+		} else {
+			logger.Warning("[OTLP] Export to %s failed, metrics buffered to disk: %v", e.endpoint, err)
+		}
+		return err
+	}
+
+	recordExportSuccess()
+	e.replayFromDisk(ctx)
+	return nil
+}
+
+// saveToDisk serializes a batch into the buffer directory and enforces the size cap
+func (e *bufferingExporter) saveToDisk(rm *metricdata.ResourceMetrics) error {
+	batch := encodeMetricsBatch(rm)
+	if len(batch.Scopes) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(batch)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err := os.MkdirAll(e.dir, 0700); err != nil {
+		return err
+	}
+
+	name := filepath.Join(e.dir, fmt.Sprintf("%d.json", time.Now().UnixNano()))
+	if err := utils.EnsureFreeSpace(name, int64(len(data))); err != nil {
+		return err
+	}
+	tmpFile := name + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpFile, name); err != nil {
+		return err
+	}
+
+	e.enforceCap()
+	return nil
+}
+
+// enforceCap removes the oldest buffered batches until total size fits maxBytes.
+// Caller must hold e.mu.
+func (e *bufferingExporter) enforceCap() {
+	files := e.bufferedFiles()
+
+	var total int64
+	sizes := make([]int64, len(files))
+	for i, f := range files {
+		if info, err := os.Stat(f); err == nil {
+			sizes[i] = info.Size()
+			total += sizes[i]
+		}
+	}
+
+	for i := 0; i < len(files) && total > e.maxBytes; i++ {
+		if err := os.Remove(files[i]); err == nil {
+			total -= sizes[i]
+			logger.Warning("[OTLP] Metrics buffer full, dropped oldest batch: %s", filepath.Base(files[i]))
+		}
+	}
+}
+
+// replayFromDisk re-exports buffered batches oldest first, stopping on the first failure
+func (e *bufferingExporter) replayFromDisk(ctx context.Context) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	files := e.bufferedFiles()
+	if len(files) == 0 {
+		return
+	}
+
+	replayed := 0
+	for _, f := range files {
+		if replayed >= metricsReplayPerExport || ctx.Err() != nil {
+			break
+		}
+
+		data, err := os.ReadFile(f)
+		if err != nil {
+			continue
+		}
+
+		var batch metricsBatch
+		if err := json.Unmarshal(data, &batch); err != nil {
+			// Corrupted batch, nothing to replay
+			os.Remove(f)
+			continue
+		}
+
+		if err := e.Exporter.Export(ctx, batch.decode()); err != nil {
+			logger.Debug("[OTLP] Replay of buffered metrics failed, will retry later: %v", err)
+			break
+		}
+
+		os.Remove(f)
+		replayed++
+	}
+
+	if replayed > 0 {
+		logger.Info("[OTLP] Replayed %d buffered metric batches (%d remaining)", replayed, len(files)-replayed)
+	}
+}
+
+// bufferedFiles returns buffered batch files sorted oldest first.
+// Caller must hold e.mu.
+func (e *bufferingExporter) bufferedFiles() []string {
+	files, err := filepath.Glob(filepath.Join(e.dir, "*.json"))
+	if err != nil {
+		return nil
+	}
+	sort.Strings(files)
+	return files
+}
+
+// =============================================================================
+// Disk Format
+// =============================================================================
+
+// metricsBatch is the on-disk form of a ResourceMetrics export.
+// Only gauges are stored since all CatOps instruments are observable gauges.
+type metricsBatch struct {
+	SchemaURL string          `json:"schema_url"`
+	Resource  []bufferedAttr  `json:"resource"`
+	Scopes    []bufferedScope `json:"scopes"`
+}
+
+type bufferedScope struct {
+	Name    string           `json:"name"`
+	Version string           `json:"version"`
+	Metrics []bufferedMetric `json:"metrics"`
+}
+
+type bufferedMetric struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Unit        string          `json:"unit"`
+	Int64       []bufferedPoint `json:"int64,omitempty"`
+	Float64     []bufferedPoint `json:"float64,omitempty"`
+}
+
+type bufferedPoint struct {
+	Attributes []bufferedAttr `json:"attributes"`
+	StartTime  time.Time      `json:"start_time"`
+	Time       time.Time      `json:"time"`
+	Int64      int64          `json:"int64,omitempty"`
+	Float64    float64        `json:"float64,omitempty"`
+}
+
+type bufferedAttr struct {
+	Key   string      `json:"key"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// encodeMetricsBatch converts a ResourceMetrics into its on-disk form
+func encodeMetricsBatch(rm *metricdata.ResourceMetrics) metricsBatch {
+	batch := metricsBatch{}
+	if rm.Resource != nil {
+		batch.SchemaURL = rm.Resource.SchemaURL()
+		batch.Resource = encodeAttrs(rm.Resource.Iter())
+	}
+
+	for _, sm := range rm.ScopeMetrics {
+		scope := bufferedScope{Name: sm.Scope.Name, Version: sm.Scope.Version}
+		for _, m := range sm.Metrics {
+			bm := bufferedMetric{Name: m.Name, Description: m.Description, Unit: m.Unit}
+			switch data := m.Data.(type) {
+			case metricdata.Gauge[int64]:
+				for _, dp := range data.DataPoints {
+					bm.Int64 = append(bm.Int64, bufferedPoint{
+						Attributes: encodeAttrs(dp.Attributes.Iter()),
+						StartTime:  dp.StartTime,
+						Time:       dp.Time,
+						Int64:      dp.Value,
+					})
+				}
+			case metricdata.Gauge[float64]:
+				for _, dp := range data.DataPoints {
+					bm.Float64 = append(bm.Float64, bufferedPoint{
+						Attributes: encodeAttrs(dp.Attributes.Iter()),
+						StartTime:  dp.StartTime,
+						Time:       dp.Time,
+						Float64:    dp.Value,
+					})
+				}
+			default:
+				continue
+			}
+			scope.Metrics = append(scope.Metrics, bm)
+		}
+		if len(scope.Metrics) > 0 {
+			batch.Scopes = append(batch.Scopes, scope)
+		}
+	}
+
+	return batch
+}
+
+// decode converts a buffered batch back into ResourceMetrics
+func (b metricsBatch) decode() *metricdata.ResourceMetrics {
+	rm := &metricdata.ResourceMetrics{
+		Resource: resource.NewWithAttributes(b.SchemaURL, decodeAttrs(b.Resource)...),
+	}
+
+	for _, scope := range b.Scopes {
+		sm := metricdata.ScopeMetrics{
+			Scope: instrumentation.Scope{Name: scope.Name, Version: scope.Version},
+		}
+		for _, bm := range scope.Metrics {
+			m := metricdata.Metrics{Name: bm.Name, Description: bm.Description, Unit: bm.Unit}
+			if len(bm.Float64) > 0 {
+				gauge := metricdata.Gauge[float64]{}
+				for _, p := range bm.Float64 {
+					gauge.DataPoints = append(gauge.DataPoints, metricdata.DataPoint[float64]{
+						Attributes: attribute.NewSet(decodeAttrs(p.Attributes)...),
+						StartTime:  p.StartTime,
+						Time:       p.Time,
+						Value:      p.Float64,
+					})
+				}
+				m.Data = gauge
+			} else {
+				gauge := metricdata.Gauge[int64]{}
+				for _, p := range bm.Int64 {
+					gauge.DataPoints = append(gauge.DataPoints, metricdata.DataPoint[int64]{
+						Attributes: attribute.NewSet(decodeAttrs(p.Attributes)...),
+						StartTime:  p.StartTime,
+						Time:       p.Time,
+						Value:      p.Int64,
+					})
+				}
+				m.Data = gauge
+			}
+			sm.Metrics = append(sm.Metrics, m)
+		}
+		rm.ScopeMetrics = append(rm.ScopeMetrics, sm)
+	}
+
+	return rm
+}
+
+// encodeAttrs converts OTel attributes into their on-disk form
+func encodeAttrs(iter attribute.Iterator) []bufferedAttr {
+	var attrs []bufferedAttr
+	for iter.Next() {
+		kv := iter.Attribute()
+		attr := bufferedAttr{Key: string(kv.Key), Type: kv.Value.Type().String()}
+		switch kv.Value.Type() {
+		case attribute.BOOL:
+			attr.Value = kv.Value.AsBool()
+		case attribute.INT64:
+			attr.Value = kv.Value.AsInt64()
+		case attribute.FLOAT64:
+			attr.Value = kv.Value.AsFloat64()
+		default:
+			attr.Type = attribute.STRING.String()
+			attr.Value = kv.Value.Emit()
+		}
+		attrs = append(attrs, attr)
+	}
+	return attrs
+}
+
+// decodeAttrs converts on-disk attributes back into OTel attributes
+func decodeAttrs(attrs []bufferedAttr) []attribute.KeyValue {
+	kvs := make([]attribute.KeyValue, 0, len(attrs))
+	for _, a := range attrs {
+		switch a.Type {
+		case attribute.BOOL.String():
+			v, _ := a.Value.(bool)
+			kvs = append(kvs, attribute.Bool(a.Key, v))
+		case attribute.INT64.String():
+			// JSON numbers decode as float64
+			v, _ := a.Value.(float64)
+			kvs = append(kvs, attribute.Int64(a.Key, int64(v)))
+		case attribute.FLOAT64.String():
+			v, _ := a.Value.(float64)
+			kvs = append(kvs, attribute.Float64(a.Key, v))
+		default:
+			v, _ := a.Value.(string)
+			kvs = append(kvs, attribute.String(a.Key, v))
+		}
+	}
+	return kvs
+}
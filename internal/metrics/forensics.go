@@ -0,0 +1,122 @@
+package metrics
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/process"
+
+	"catops/pkg/utils"
+)
+
+// secretName matches flag and variable names whose values are masked
+var secretName = regexp.MustCompile(`(?i)(secret|token|passw|pwd|key|credential|auth)`)
+
+// envAllowlist are the environment variables reported with their value, all
+// others are redacted
+var envAllowlist = map[string]bool{
+	"PATH": true, "LANG": true, "LC_ALL": true, "LC_CTYPE": true, "TZ": true,
+	"HOME": true, "USER": true, "SHELL": true, "PWD": true, "TERM": true,
+}
+
+// ProcessForensics reads the full command line, working directory, owner and
+// executable of one process, and its environment when withEnv is set. Secrets
+// are scrubbed here, before the details reach any alert channel: secret-looking
+// flags and URL credentials in the command line are masked, and environment
+// values are redacted except for envAllowlist. It is meant for a single flagged
+// process, not the regular collection. Unreadable fields are left out.
+func ProcessForensics(pid int, withEnv bool) map[string]string {
+	p, err := process.NewProcess(int32(pid))
+	if err != nil {
+		return nil
+	}
+
+	details := make(map[string]string)
+	if name, err := p.Name(); err == nil {
+		details["process_name"] = name
+	}
+	if args, err := p.CmdlineSlice(); err == nil && len(args) > 0 {
+		details["process_cmdline"] = strings.Join(scrubArgs(args), " ")
+	}
+	if exe, err := p.Exe(); err == nil {
+		details["process_exe"] = exe
+	}
+	if cwd, err := p.Cwd(); err == nil {
+		details["process_cwd"] = cwd
+	}
+	if user, err := p.Username(); err == nil {
+		details["process_user"] = user
+	}
+	if created, err := p.CreateTime(); err == nil {
+		details["process_started"] = time.UnixMilli(created).UTC().Format(time.RFC3339)
+	}
+	if ppid, err := p.Ppid(); err == nil {
+		details["process_ppid"] = fmt.Sprintf("%d", ppid)
+	}
+
+	if withEnv {
+		if environ, err := p.Environ(); err == nil && len(environ) > 0 {
+			vars := make([]string, 0, len(environ))
+			for _, kv := range environ {
+				key, _, ok := strings.Cut(kv, "=")
+				if !ok || key == "" {
+					continue
+				}
+				if !envAllowlist[key] {
+					kv = key + "=<redacted>"
+				}
+				vars = append(vars, kv)
+			}
+			sort.Strings(vars)
+			details["process_environ"] = strings.Join(vars, "\n")
+		}
+	}
+
+	return details
+}
+
+// scrubArgs masks secrets in a command line: the value of secret-looking flags
+// (--password=x, --token x) and NAME=value arguments, and the password of URLs
+// with credentials
+func scrubArgs(args []string) []string {
+	scrubbed := make([]string, len(args))
+	maskNext := false
+	for i, arg := range args {
+		switch {
+		case maskNext && !strings.HasPrefix(arg, "-"):
+			arg = utils.MaskSecret(arg, 4)
+		case strings.HasPrefix(arg, "-"):
+			name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+			if secretName.MatchString(name) {
+				if hasValue {
+					arg = arg[:len(arg)-len(value)] + utils.MaskSecret(value, 4)
+				} else {
+					maskNext = true
+					scrubbed[i] = arg
+					continue
+				}
+			}
+		default:
+			if name, value, ok := strings.Cut(arg, "="); ok && !strings.Contains(name, "/") && secretName.MatchString(name) {
+				arg = name + "=" + utils.MaskSecret(value, 4)
+			}
+		}
+		maskNext = false
+		scrubbed[i] = scrubURLCredentials(arg)
+	}
+	return scrubbed
+}
+
+// urlCredentials matches the user:password@ part of a URL
+var urlCredentials = regexp.MustCompile(`(://[^/\s:@]*:)([^/\s@]+)@`)
+
+// scrubURLCredentials masks the password of URLs in s
+func scrubURLCredentials(s string) string {
+	return urlCredentials.ReplaceAllStringFunc(s, func(m string) string {
+		parts := urlCredentials.FindStringSubmatch(m)
+		return parts[1] + utils.MaskSecret(parts[2], 4) + "@"
+	})
+}
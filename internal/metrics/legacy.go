@@ -3,10 +3,15 @@ package metrics
 
 import (
 	"fmt"
+	stdnet "net"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
+	constants "catops/config"
+	"catops/internal/logger"
+
 	"github.com/shirou/gopsutil/v4/disk"
 	"github.com/shirou/gopsutil/v4/host"
 	"github.com/shirou/gopsutil/v4/mem"
@@ -17,6 +22,21 @@ import (
 // Legacy API (for backward compatibility with UI)
 // =============================================================================
 
+// primaryIPProbe is the address a UDP socket is "connected" to for PrimaryIP.
+// Connecting a UDP socket only selects a route, no packet is sent.
+const primaryIPProbe = "8.8.8.8:80"
+
+var (
+	// Ports counted as HTTPS connections and whether local (inbound) ports are matched
+	httpsPorts   = map[uint32]bool{}
+	httpsInbound bool
+	httpsPortsMu sync.RWMutex
+)
+
+func init() {
+	SetHTTPSPorts(constants.DEFAULT_HTTPS_PORTS, false)
+}
+
 // Metrics contains system metrics for UI display
 type Metrics struct {
 	CPUUsage      float64 `json:"cpu_usage"`
@@ -48,12 +68,24 @@ type ResourceUsage struct {
 	Usage     float64 `json:"usage_percent"`
 }
 
-// GetMetrics returns metrics in legacy format for UI
+// GetMetrics returns metrics in legacy format for UI. Failed collectors other
+// than the system summary only leave their part empty.
 func GetMetrics() (*Metrics, error) {
-	all, err := CollectAllMetrics()
-	if err != nil {
+	return LegacyMetrics(CollectAllMetrics())
+}
+
+// LegacyMetrics converts the result of a collection to the legacy format. It
+// only fails when the system summary is missing.
+func LegacyMetrics(all *AllMetrics, err error) (*Metrics, error) {
+	if all == nil || all.Summary == nil {
+		if err == nil {
+			err = fmt.Errorf("no system summary collected")
+		}
 		return nil, err
 	}
+	if err != nil {
+		logger.Debug("Metrics collected with errors: %v", err)
+	}
 
 	m := &Metrics{
 		Timestamp: time.Now().UTC().Format("2006-01-02 15:04:05"),
@@ -67,14 +99,7 @@ func GetMetrics() (*Metrics, error) {
 		m.IOWait = s.CPUIOWait
 		m.IOPS = int64(s.DiskIOPSRead + s.DiskIOPSWrite)
 
-		// Calculate HTTPS connections
-		if conns, err := net.Connections("tcp"); err == nil {
-			for _, c := range conns {
-				if c.Raddr.Port == 443 {
-					m.HTTPSRequests++
-				}
-			}
-		}
+		m.HTTPSRequests = countHTTPSConnections()
 
 		m.CPUDetails = ResourceUsage{
 			Total: int64(s.CPUCores),
@@ -104,40 +129,18 @@ func GetMetrics() (*Metrics, error) {
 		m.OSName = "Linux"
 	case "darwin":
 		m.OSName = "macOS"
+	case "windows":
+		m.OSName = "Windows"
 	default:
 		m.OSName = runtime.GOOS
 	}
 
 	// IP Address
-	if interfaces, err := net.Interfaces(); err == nil {
-		for _, iface := range interfaces {
-			for _, addr := range iface.Addrs {
-				if strings.Contains(addr.Addr, ".") && !strings.Contains(addr.Addr, "127.0.0.1") {
-					m.IPAddress = strings.Split(addr.Addr, "/")[0]
-					break
-				}
-			}
-			if m.IPAddress != "" {
-				break
-			}
-		}
-	}
-	if m.IPAddress == "" {
-		m.IPAddress = "unknown"
-	}
+	m.IPAddress = PrimaryIP()
 
 	// Uptime
 	if uptime, err := host.Uptime(); err == nil {
-		days := uptime / (24 * 3600)
-		hours := (uptime % (24 * 3600)) / 3600
-		minutes := (uptime % 3600) / 60
-		if days > 0 {
-			m.Uptime = fmt.Sprintf("%d days", days)
-		} else if hours > 0 {
-			m.Uptime = fmt.Sprintf("%d hours", hours)
-		} else {
-			m.Uptime = fmt.Sprintf("%d minutes", minutes)
-		}
+		m.Uptime = formatUptime(uptime)
 	} else {
 		m.Uptime = "unknown"
 	}
@@ -153,6 +156,90 @@ func GetMetrics() (*Metrics, error) {
 	return m, nil
 }
 
+// SetHTTPSPorts sets the ports counted as HTTPS connections (https_ports). With
+// inbound set, connections to these local ports (clients of our own services)
+// are counted instead of outbound connections to remote servers on these ports.
+func SetHTTPSPorts(ports []int, inbound bool) {
+	httpsPortsMu.Lock()
+	defer httpsPortsMu.Unlock()
+	httpsPorts = make(map[uint32]bool, len(ports))
+	for _, p := range ports {
+		if p > 0 && p <= 65535 {
+			httpsPorts[uint32(p)] = true
+		}
+	}
+	httpsInbound = inbound
+}
+
+// HTTPSInbound reports whether HTTPS connections are counted on local ports
+func HTTPSInbound() bool {
+	httpsPortsMu.RLock()
+	defer httpsPortsMu.RUnlock()
+	return httpsInbound
+}
+
+// countHTTPSConnections counts TCP connections on the configured HTTPS ports
+func countHTTPSConnections() int64 {
+	conns, err := getCachedConnections()
+	if err != nil {
+		return 0
+	}
+
+	httpsPortsMu.RLock()
+	defer httpsPortsMu.RUnlock()
+
+	var count int64
+	for _, c := range conns {
+		if httpsInbound {
+			// Listening sockets have no peer, only count accepted connections
+			if httpsPorts[c.Laddr.Port] && c.Raddr.Port != 0 && c.Status != "LISTEN" {
+				count++
+			}
+		} else if httpsPorts[c.Raddr.Port] {
+			count++
+		}
+	}
+	return count
+}
+
+// PrimaryIP returns the local IPv4 address the OS routes outbound traffic
+// through, which on multi-homed hosts is the one that matters. Without a
+// default route it falls back to the first non-loopback interface address,
+// and "unknown" when there is none.
+func PrimaryIP() string {
+	if conn, err := stdnet.Dial("udp4", primaryIPProbe); err == nil {
+		addr, ok := conn.LocalAddr().(*stdnet.UDPAddr)
+		conn.Close()
+		if ok && !addr.IP.IsLoopback() && !addr.IP.IsUnspecified() {
+			return addr.IP.String()
+		}
+	}
+
+	if interfaces, err := net.Interfaces(); err == nil {
+		for _, iface := range interfaces {
+			for _, addr := range iface.Addrs {
+				if strings.Contains(addr.Addr, ".") && !strings.Contains(addr.Addr, "127.0.0.1") {
+					return strings.Split(addr.Addr, "/")[0]
+				}
+			}
+		}
+	}
+	return "unknown"
+}
+
+// formatUptime renders uptime seconds in its largest whole unit
+func formatUptime(uptime uint64) string {
+	days := uptime / (24 * 3600)
+	hours := (uptime % (24 * 3600)) / 3600
+	minutes := (uptime % 3600) / 60
+	if days > 0 {
+		return fmt.Sprintf("%d days", days)
+	} else if hours > 0 {
+		return fmt.Sprintf("%d hours", hours)
+	}
+	return fmt.Sprintf("%d minutes", minutes)
+}
+
 func convertToLegacyNetworkMetrics(networks []NetworkInterfaceMetrics) *NetworkMetrics {
 	nm := &NetworkMetrics{
 		Interfaces: make([]InterfaceInfo, 0, len(networks)),
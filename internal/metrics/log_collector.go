@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"bufio"
+	"compress/gzip"
 	"context"
 	"crypto/md5"
 	"encoding/hex"
@@ -11,6 +12,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -29,6 +31,111 @@ var (
 	globalLogCollectorOnce sync.Once
 )
 
+// How long a sent log line is remembered to avoid resending it (log_dedup_window,
+// 0 = while it is still in the log tail)
+var (
+	logDedupWindow   = 10 * time.Minute
+	logDedupWindowMu sync.RWMutex
+)
+
+// SetLogDedupWindow sets how long sent log lines are remembered. A line is a
+// duplicate only if it is byte-for-byte identical, including the timestamp
+// docker prefixes, so within the window the same line is not resent when the
+// log tail is re-read on the next cycle. 0 has no time limit: a line is not
+// resent while it is still in the tail of its source, and forgotten once the
+// log moved past it.
+func SetLogDedupWindow(window time.Duration) {
+	logDedupWindowMu.Lock()
+	defer logDedupWindowMu.Unlock()
+	logDedupWindow = max(window, 0)
+}
+
+// getLogDedupWindow returns the configured deduplication window
+func getLogDedupWindow() time.Duration {
+	logDedupWindowMu.RLock()
+	defer logDedupWindowMu.RUnlock()
+	return logDedupWindow
+}
+
+// Lines a single container or service may send per collection (log_rate_limit, 0 = unlimited)
+var (
+	logRateLimit   int
+	logRateLimitMu sync.RWMutex
+)
+
+// SetLogRateLimit caps the log lines one container or service sends per
+// collection, so a single noisy source can't crowd out the others during a
+// log storm. The newest lines are kept and a marker reports the dropped ones.
+// 0 disables the cap.
+func SetLogRateLimit(lines int) {
+	logRateLimitMu.Lock()
+	defer logRateLimitMu.Unlock()
+	logRateLimit = max(lines, 0)
+}
+
+// getLogRateLimit returns the configured per-source line cap
+func getLogRateLimit() int {
+	logRateLimitMu.RLock()
+	defer logRateLimitMu.RUnlock()
+	return logRateLimit
+}
+
+// Log sources the collector skips (log_sources_disabled): container names or
+// IDs, service names or log file paths
+var (
+	disabledLogSources   map[string]bool
+	disabledLogSourcesMu sync.RWMutex
+)
+
+// SetDisabledLogSources sets the log sources that are never read. A source is
+// matched by container name or ID, pm2 service name or log file path.
+func SetDisabledLogSources(sources []string) {
+	disabledLogSourcesMu.Lock()
+	defer disabledLogSourcesMu.Unlock()
+	disabledLogSources = make(map[string]bool, len(sources))
+	for _, source := range sources {
+		disabledLogSources[source] = true
+	}
+}
+
+// isLogSourceDisabled reports whether any of the identifiers of a source is disabled
+func isLogSourceDisabled(ids ...string) bool {
+	disabledLogSourcesMu.RLock()
+	defer disabledLogSourcesMu.RUnlock()
+	for _, id := range ids {
+		if id != "" && disabledLogSources[id] {
+			return true
+		}
+	}
+	return false
+}
+
+// logSourceStaleAfter drops sources from the stats that were not seen recently
+// (a removed container or stopped service)
+const logSourceStaleAfter = 15 * time.Minute
+
+// LogSource is a container or service log the collector reads
+type LogSource struct {
+	Type     string    `json:"type"`              // docker, pm2
+	Name     string    `json:"name"`              // container or service name
+	Path     string    `json:"path,omitempty"`    // container ID or log file
+	Service  string    `json:"service,omitempty"` // compose service, if any
+	Disabled bool      `json:"disabled,omitempty"`
+	LastSeen time.Time `json:"last_seen"`
+}
+
+// LogStats summarizes log deduplication since the collector started
+type LogStats struct {
+	Window       time.Duration     `json:"window"`            // dedup window, 0 = while in the log tail
+	Sent         uint64            `json:"sent"`              // lines passed on for export
+	Deduplicated uint64            `json:"deduplicated"`      // lines dropped as already sent
+	Tracked      int               `json:"tracked_lines"`     // hashes currently remembered
+	RateLimit    int               `json:"rate_limit"`        // log_rate_limit, 0 = unlimited
+	Dropped      map[string]uint64 `json:"dropped,omitempty"` // lines over the rate limit per source
+	Sources      []LogSource       `json:"sources,omitempty"` // sources seen recently
+	UpdatedAt    time.Time         `json:"updated_at"`
+}
+
 // DockerContainer represents a running docker container
 type DockerContainer struct {
 	ID      string `json:"Id"`
@@ -52,6 +159,18 @@ type LogCollector struct {
 	// Deduplication: track sent log hashes to avoid sending same logs twice
 	sentLogHashes   map[string]time.Time // hash -> when it was sent
 	sentLogHashesMu sync.Mutex
+	sentLines       uint64                     // guarded by sentLogHashesMu
+	dedupedLines    uint64                     // guarded by sentLogHashesMu
+	droppedLines    map[string]uint64          // source -> lines over log_rate_limit, guarded by sentLogHashesMu
+	tailHashes      map[string]map[string]bool // source -> hashes of its last read, for a 0 window, guarded by sentLogHashesMu
+
+	// Last seen state of tailed log files, to detect rotation between reads
+	logFiles   map[string]os.FileInfo
+	logFilesMu sync.Mutex
+
+	// Sources seen by the collector (type:name -> source), for 'catops logs sources'
+	sources   map[string]LogSource
+	sourcesMu sync.Mutex
 }
 
 // NewLogCollector creates a new LogCollector
@@ -66,6 +185,10 @@ func NewLogCollector() *LogCollector {
 		},
 		dockerContainers: make(map[string]DockerContainer),
 		sentLogHashes:    make(map[string]time.Time),
+		droppedLines:     make(map[string]uint64),
+		tailHashes:       make(map[string]map[string]bool),
+		logFiles:         make(map[string]os.FileInfo),
+		sources:          make(map[string]LogSource),
 	}
 	// Pre-load docker containers
 	lc.loadDockerContainers()
@@ -83,7 +206,7 @@ func GetLogCollector() *LogCollector {
 			defer ticker.Stop()
 			for range ticker.C {
 				globalLogCollector.sentLogHashesMu.Lock()
-				cutoff := time.Now().Add(-10 * time.Minute)
+				cutoff := time.Now().Add(-getLogDedupWindow())
 				for hash, sentAt := range globalLogCollector.sentLogHashes {
 					if sentAt.Before(cutoff) {
 						delete(globalLogCollector.sentLogHashes, hash)
@@ -104,13 +227,9 @@ func (lc *LogCollector) loadDockerContainers() {
 	lc.dockerContainers = make(map[string]DockerContainer)
 	lc.dockerLoaded = false
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(logTimeout)*time.Second)
-	defer cancel()
-
 	// Get all running containers with their PIDs
 	// Format: ID|Names|Image|State|Pid
-	cmd := exec.CommandContext(ctx, "docker", "ps", "--format", "{{.ID}}|{{.Names}}|{{.Image}}|{{.State}}")
-	output, err := cmd.Output()
+	output, err := runContainerCLI(time.Duration(logTimeout)*time.Second, "docker", "ps", "--format", "{{.ID}}|{{.Names}}|{{.Image}}|{{.State}}")
 	if err != nil {
 		// Docker not available or no containers
 		return
@@ -158,11 +277,7 @@ func (lc *LogCollector) loadDockerContainers() {
 
 // getContainerPID gets the main PID of a container
 func (lc *LogCollector) getContainerPID(containerID string) int {
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-	defer cancel()
-
-	cmd := exec.CommandContext(ctx, "docker", "inspect", "--format", "{{.State.Pid}}", containerID)
-	output, err := cmd.Output()
+	output, err := runContainerCLI(2*time.Second, "docker", "inspect", "--format", "{{.State.Pid}}", containerID)
 	if err != nil {
 		return 0
 	}
@@ -239,7 +354,7 @@ func (lc *LogCollector) CollectServiceLogs(service *ServiceInfo) ([]string, stri
 	if service.ServiceType == ServiceTypeNodeApp {
 		logs := lc.collectPM2Logs(service.PID)
 		if len(logs) > 0 {
-			return logs, "pm2"
+			return lc.limitLogRate(service.ServiceName, lc.deduplicateLogs(service.ServiceName, logs)), "pm2"
 		}
 	}
 
@@ -249,19 +364,37 @@ func (lc *LogCollector) CollectServiceLogs(service *ServiceInfo) ([]string, stri
 // CollectContainerLogs collects logs directly from a container by ID
 // This is the simple approach like self-hosted - just get docker logs
 func (lc *LogCollector) CollectContainerLogs(containerID string) ([]string, error) {
+	source := LogSource{Type: "docker", Name: containerID, Path: containerID}
+	if c, ok := lc.dockerContainers[containerID]; ok {
+		source.Path = c.ID
+		if c.Name != "" {
+			source.Name = c.Name
+		}
+		source.Service = c.Compose
+		if service := labeledService("docker", c.ID); service != "" {
+			source.Service = service
+		}
+	}
+	source.Disabled = isLogSourceDisabled(containerID, source.Name, source.Path)
+	lc.noteSource(source)
+	if source.Disabled {
+		return nil, nil
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(logTimeout)*time.Second)
 	defer cancel()
 
 	// Get last N lines of logs with timestamps
 	cmd := exec.CommandContext(ctx, "docker", "logs", "--tail", fmt.Sprintf("%d", maxLogLines), "--timestamps", containerID)
+	cmd.WaitDelay = time.Second
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return nil, err
 	}
 
-	// Filter for error/warning lines, then deduplicate
+	// Filter for error/warning lines, then deduplicate and cap
 	filtered := lc.filterLogLines(string(output))
-	return lc.deduplicateLogs(filtered), nil
+	return lc.limitLogRate(source.Name, lc.deduplicateLogs(source.Name, filtered)), nil
 }
 
 // collectDockerLogs collects recent logs from a Docker container (legacy method for services)
@@ -275,13 +408,34 @@ func (lc *LogCollector) hashLogLine(line string) string {
 	return hex.EncodeToString(hash[:])
 }
 
-// deduplicateLogs filters out logs that have already been sent
-func (lc *LogCollector) deduplicateLogs(logs []string) []string {
+// deduplicateLogs filters out the logs of source that have already been sent
+func (lc *LogCollector) deduplicateLogs(source string, logs []string) []string {
 	lc.sentLogHashesMu.Lock()
 	defer lc.sentLogHashesMu.Unlock()
 
-	// Clean up old hashes (older than 10 minutes) to prevent memory growth
-	cutoff := time.Now().Add(-10 * time.Minute)
+	window := getLogDedupWindow()
+	if window <= 0 {
+		// No time limit: drop what the previous read of this source already
+		// had, remember only what this read has
+		lc.sentLogHashes = make(map[string]time.Time)
+		previous := lc.tailHashes[source]
+		current := make(map[string]bool, len(logs))
+		var newLogs []string
+		for _, log := range logs {
+			hash := lc.hashLogLine(log)
+			if !previous[hash] && !current[hash] {
+				newLogs = append(newLogs, log)
+			}
+			current[hash] = true
+		}
+		lc.tailHashes[source] = current
+		lc.dedupedLines += uint64(len(logs) - len(newLogs))
+		return newLogs
+	}
+	clear(lc.tailHashes)
+
+	// Clean up hashes older than the window to prevent memory growth
+	cutoff := time.Now().Add(-window)
 	for hash, sentAt := range lc.sentLogHashes {
 		if sentAt.Before(cutoff) {
 			delete(lc.sentLogHashes, hash)
@@ -300,9 +454,87 @@ func (lc *LogCollector) deduplicateLogs(logs []string) []string {
 		}
 	}
 
+	lc.dedupedLines += uint64(len(logs) - len(newLogs))
 	return newLogs
 }
 
+// limitLogRate keeps the newest log_rate_limit lines of one source, replacing
+// the rest with a single marker line counted per source, and counts what is
+// passed on for export
+func (lc *LogCollector) limitLogRate(source string, logs []string) []string {
+	lc.sentLogHashesMu.Lock()
+	defer lc.sentLogHashesMu.Unlock()
+
+	limit := getLogRateLimit()
+	if limit <= 0 || len(logs) <= limit {
+		lc.sentLines += uint64(len(logs))
+		return logs
+	}
+
+	dropped := len(logs) - limit
+	lc.droppedLines[source] += uint64(dropped)
+	lc.sentLines += uint64(limit)
+
+	marker := fmt.Sprintf("[catops] warning: dropped %d lines from %s (log_rate_limit %d per collection)", dropped, source, limit)
+	return append([]string{marker}, logs[dropped:]...)
+}
+
+// GetLogStats returns deduplication counters of the global log collector
+// (zero before the first collection)
+func GetLogStats() LogStats {
+	if globalLogCollector == nil {
+		return LogStats{Window: getLogDedupWindow(), RateLimit: getLogRateLimit(), UpdatedAt: time.Now()}
+	}
+	return globalLogCollector.Stats()
+}
+
+// Stats returns deduplication counters since the collector started
+func (lc *LogCollector) Stats() LogStats {
+	lc.sentLogHashesMu.Lock()
+	defer lc.sentLogHashesMu.Unlock()
+
+	stats := LogStats{
+		Window:       getLogDedupWindow(),
+		Sent:         lc.sentLines,
+		Deduplicated: lc.dedupedLines,
+		Tracked:      len(lc.sentLogHashes),
+		RateLimit:    getLogRateLimit(),
+		UpdatedAt:    time.Now(),
+	}
+	if len(lc.droppedLines) > 0 {
+		stats.Dropped = make(map[string]uint64, len(lc.droppedLines))
+		for source, n := range lc.droppedLines {
+			stats.Dropped[source] = n
+		}
+	}
+
+	lc.sourcesMu.Lock()
+	defer lc.sourcesMu.Unlock()
+	cutoff := time.Now().Add(-logSourceStaleAfter)
+	for key, source := range lc.sources {
+		if source.LastSeen.Before(cutoff) {
+			delete(lc.sources, key)
+			continue
+		}
+		stats.Sources = append(stats.Sources, source)
+	}
+	sort.Slice(stats.Sources, func(i, j int) bool {
+		if stats.Sources[i].Type != stats.Sources[j].Type {
+			return stats.Sources[i].Type < stats.Sources[j].Type
+		}
+		return stats.Sources[i].Name < stats.Sources[j].Name
+	})
+	return stats
+}
+
+// noteSource records that a log source was seen in this collection
+func (lc *LogCollector) noteSource(source LogSource) {
+	source.LastSeen = time.Now()
+	lc.sourcesMu.Lock()
+	defer lc.sourcesMu.Unlock()
+	lc.sources[source.Type+":"+source.Name] = source
+}
+
 // pm2Process represents a pm2 process from jlist output
 type pm2Process struct {
 	Name   string `json:"name"`
@@ -321,6 +553,13 @@ func (lc *LogCollector) collectPM2Logs(pid int) []string {
 		return nil
 	}
 
+	source := LogSource{Type: "pm2", Name: proc.Name, Path: proc.PM2Env.ErrLogPath, Service: proc.Name}
+	source.Disabled = isLogSourceDisabled(proc.Name, proc.PM2Env.ErrLogPath, proc.PM2Env.OutLogPath)
+	lc.noteSource(source)
+	if source.Disabled {
+		return nil
+	}
+
 	var allLogs []string
 
 	// Use exact log paths from pm2_env if available
@@ -362,6 +601,18 @@ func (lc *LogCollector) collectPM2Logs(pid int) []string {
 
 // findPM2AppByPID finds pm2 process by its PID using pm2 jlist
 func (lc *LogCollector) findPM2AppByPID(pid int) *pm2Process {
+	processes := lc.listPM2Apps()
+	for i := range processes {
+		if processes[i].PID == pid {
+			return &processes[i]
+		}
+	}
+
+	return nil
+}
+
+// listPM2Apps returns the apps pm2 manages, nil when pm2 is not available
+func (lc *LogCollector) listPM2Apps() []pm2Process {
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
@@ -383,18 +634,66 @@ func (lc *LogCollector) findPM2AppByPID(pid int) *pm2Process {
 	if err := json.Unmarshal([]byte(raw), &processes); err != nil {
 		return nil
 	}
+	return processes
+}
+
+// readLastLines reads the last N lines from a file. If the file was rotated
+// since the previous read (replaced by another file, or truncated), the tail of
+// the rotated file (<path>.1 or <path>.1.gz) is read first, so lines written
+// just before the rotation are not lost.
+func (lc *LogCollector) readLastLines(filePath string, n int) []string {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil
+	}
 
-	for i := range processes {
-		if processes[i].PID == pid {
-			return &processes[i]
+	lc.logFilesMu.Lock()
+	prev, seen := lc.logFiles[filePath]
+	lc.logFiles[filePath] = info
+	lc.logFilesMu.Unlock()
+
+	lines := lc.tailFile(filePath, n)
+	if seen && (!os.SameFile(prev, info) || info.Size() < prev.Size()) {
+		if rotated := lc.readRotatedTail(filePath, n); len(rotated) > 0 {
+			lines = append(rotated, lines...)
 		}
 	}
+	return lines
+}
 
-	return nil
+// readRotatedTail reads the last N lines of the file filePath was rotated to
+func (lc *LogCollector) readRotatedTail(filePath string, n int) []string {
+	if lines := lc.tailFile(filePath+".1", n); len(lines) > 0 {
+		return lines
+	}
+
+	file, err := os.Open(filePath + ".1.gz")
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return nil
+	}
+	defer gz.Close()
+
+	// A gzip stream can't be read backwards, keep a window of the last n lines
+	var lines []string
+	scanner := bufio.NewScanner(gz)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+		if len(lines) > n {
+			lines = lines[1:]
+		}
+	}
+	return lines
 }
 
-// readLastLines reads the last N lines from a file
-func (lc *LogCollector) readLastLines(filePath string, n int) []string {
+// tailFile reads the last N lines of a plain file
+func (lc *LogCollector) tailFile(filePath string, n int) []string {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return nil
@@ -0,0 +1,242 @@
+package metrics
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// WindowLogs are the lines one log source wrote in a time window
+type WindowLogs struct {
+	Source LogSource `json:"source"`
+	Lines  []string  `json:"lines"`
+	// Note explains lines that could not be read or placed in the window
+	Note string `json:"note,omitempty"`
+}
+
+// lineTimePattern matches a timestamp at the start of a log line, as written
+// by pm2 --time, most frameworks and docker --timestamps:
+// 2024-05-01T10:00:00.123Z, [2024-05-01 10:00:00 +02:00] ...
+var lineTimePattern = regexp.MustCompile(`^\[?(\d{4}-\d{2}-\d{2})[T ](\d{2}:\d{2}:\d{2})(\.\d+)?\s?(Z|[+-]\d{2}:?\d{2})?`)
+
+// parseLineTime returns the timestamp a log line starts with. Lines without a
+// zone are in local time.
+func parseLineTime(line string) (time.Time, bool) {
+	m := lineTimePattern.FindStringSubmatch(line)
+	if m == nil {
+		return time.Time{}, false
+	}
+	value := m[1] + "T" + m[2] + m[3]
+	layout := "2006-01-02T15:04:05.999999999"
+	loc := time.Local
+	switch zone := m[4]; {
+	case zone == "Z":
+		loc = time.UTC
+	case zone != "":
+		value += strings.Replace(zone, ":", "", 1)
+		layout += "-0700"
+	}
+	t, err := time.ParseInLocation(layout, value, loc)
+	return t, err == nil
+}
+
+// ReadLogWindow re-reads the container and pm2 log sources for lines written
+// between since and until (zero until = now). Only error and warning lines are
+// kept unless all is set, and at most limit lines per source, the newest.
+// Disabled sources are skipped.
+func (lc *LogCollector) ReadLogWindow(since, until time.Time, all bool, limit int) []WindowLogs {
+	if until.IsZero() {
+		until = time.Now()
+	}
+
+	var result []WindowLogs
+	keep := func(w WindowLogs) {
+		if !all {
+			var filtered []string
+			for _, line := range w.Lines {
+				if lc.isInterestingLine(line) {
+					filtered = append(filtered, line)
+				}
+			}
+			w.Lines = filtered
+		}
+		for i, line := range w.Lines {
+			if len(line) > maxLogLineLen {
+				w.Lines[i] = line[:maxLogLineLen-3] + "..."
+			}
+		}
+		if limit > 0 && len(w.Lines) > limit {
+			w.Lines = w.Lines[len(w.Lines)-limit:]
+		}
+		result = append(result, w)
+	}
+
+	// Containers: docker filters by time itself
+	lc.loadDockerContainers()
+	seen := make(map[string]bool)
+	for _, c := range lc.dockerContainers {
+		if seen[c.ID] {
+			continue
+		}
+		seen[c.ID] = true
+		if isLogSourceDisabled(c.ID, c.Name) {
+			continue
+		}
+
+		w := WindowLogs{Source: LogSource{Type: "docker", Name: c.Name, Path: c.ID, Service: c.Compose}}
+		if service := labeledService("docker", c.ID); service != "" {
+			w.Source.Service = service
+		}
+		lines, err := dockerLogWindow(c.ID, since, until)
+		if err != nil {
+			w.Note = err.Error()
+		}
+		w.Lines = lines
+		keep(w)
+	}
+
+	// pm2 apps: their log files, the rotated ones first
+	for _, app := range lc.listPM2Apps() {
+		if isLogSourceDisabled(app.Name, app.PM2Env.ErrLogPath, app.PM2Env.OutLogPath) {
+			continue
+		}
+		w := WindowLogs{Source: LogSource{Type: "pm2", Name: app.Name, Path: app.PM2Env.ErrLogPath, Service: app.Name}}
+		var unstamped []string
+		for _, path := range []string{app.PM2Env.ErrLogPath, app.PM2Env.OutLogPath} {
+			if path == "" {
+				continue
+			}
+			for _, file := range []string{path + ".1.gz", path + ".1", path} {
+				lines, stamped, err := readFileWindow(file, since, until)
+				switch {
+				case os.IsNotExist(err):
+				case err != nil:
+					w.Note = err.Error()
+				case !stamped:
+					unstamped = append(unstamped, filepath.Base(file))
+				default:
+					w.Lines = append(w.Lines, lines...)
+				}
+			}
+		}
+		if len(unstamped) > 0 {
+			w.Note = fmt.Sprintf("%s: lines carry no timestamps, start pm2 with --time to read them by window",
+				strings.Join(unstamped, ", "))
+		}
+		keep(w)
+	}
+
+	return result
+}
+
+// dockerLogWindow reads a container's log lines between since and until
+func dockerLogWindow(containerID string, since, until time.Time) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(logTimeout)*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "docker", "logs", "--timestamps",
+		"--since", since.Format(time.RFC3339), "--until", until.Format(time.RFC3339), containerID)
+	cmd.WaitDelay = time.Second
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("docker logs: %v", err)
+	}
+	if len(output) == 0 {
+		return nil, nil
+	}
+	return strings.Split(strings.TrimRight(string(output), "\n"), "\n"), nil
+}
+
+// readFileWindow returns the lines of a log file written between since and
+// until, judged by the timestamp lines start with; lines without one (stack
+// traces) belong to the line before. stamped is false when no line carries a
+// timestamp. Plain files are entered by binary search on the timestamps.
+func readFileWindow(path string, since, until time.Time) (lines []string, stamped bool, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, false, err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, false, err
+		}
+		defer gz.Close()
+		r = gz
+	} else if info, err := f.Stat(); err == nil {
+		offset := seekLogTime(f, info.Size(), since)
+		if _, err := f.Seek(offset, io.SeekStart); err != nil {
+			return nil, false, err
+		}
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	inWindow := false
+	for scanner.Scan() {
+		line := scanner.Text()
+		if t, ok := parseLineTime(line); ok {
+			stamped = true
+			if t.After(until) {
+				break
+			}
+			inWindow = !t.Before(since)
+		}
+		if inWindow {
+			lines = append(lines, line)
+		}
+	}
+	return lines, stamped, scanner.Err()
+}
+
+// seekLogTime finds an offset in a log file at or shortly before the first
+// line stamped since, 0 when the lines carry no timestamps
+func seekLogTime(f *os.File, size int64, since time.Time) int64 {
+	const probe = 4096
+	lo, hi := int64(0), size
+	buf := make([]byte, probe)
+	for hi-lo > 64*1024 {
+		mid := lo + (hi-lo)/2
+		n, _ := f.ReadAt(buf, mid)
+
+		// The first complete stamped line after mid
+		var t time.Time
+		found := false
+		chunk := string(buf[:n])
+		if i := strings.IndexByte(chunk, '\n'); i >= 0 {
+			for _, line := range strings.Split(chunk[i+1:], "\n") {
+				if t, found = parseLineTime(line); found {
+					break
+				}
+			}
+		}
+		if !found {
+			break
+		}
+		if t.Before(since) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	// Back to the start of a line
+	if lo > 0 {
+		n, _ := f.ReadAt(buf, lo)
+		if i := strings.IndexByte(string(buf[:n]), '\n'); i >= 0 {
+			return lo + int64(i) + 1
+		}
+	}
+	return lo
+}
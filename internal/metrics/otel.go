@@ -4,8 +4,12 @@ package metrics
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"os"
+	"path/filepath"
 	"runtime"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -18,6 +22,8 @@ import (
 	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
 
 	constants "catops/config"
+	"catops/internal/logger"
+	"catops/pkg/utils"
 )
 
 // =============================================================================
@@ -33,6 +39,9 @@ var (
 	// Current OTel config for health checks
 	currentOTelConfig *OTelConfig
 
+	// Per-endpoint exporters of the running collector
+	activeExporters []*bufferingExporter
+
 	// Cached metrics for OTel callbacks
 	cachedMetrics *AllMetrics
 	cacheMu       sync.RWMutex
@@ -51,30 +60,27 @@ func StartOTelCollector(cfg *OTelConfig) error {
 		return nil
 	}
 
-	if cfg.Endpoint == "" || cfg.AuthToken == "" || cfg.ServerID == "" {
+	if len(cfg.Endpoints) == 0 || cfg.AuthToken == "" || cfg.ServerID == "" {
 		return fmt.Errorf("OTLP config incomplete: endpoint, auth_token, and server_id required")
 	}
 
 	ctx := context.Background()
 
-	exporter, err := otlpmetrichttp.New(ctx,
-		otlpmetrichttp.WithEndpoint(cfg.Endpoint),
-		otlpmetrichttp.WithURLPath(constants.OTLP_PATH),
-		otlpmetrichttp.WithHeaders(map[string]string{
-			"Authorization":      "Bearer " + cfg.AuthToken,
-			"X-CatOps-Server-ID": cfg.ServerID,
-		}),
-		// Retry configuration for resilience
-		otlpmetrichttp.WithRetry(otlpmetrichttp.RetryConfig{
-			Enabled:         true,
-			InitialInterval: 5 * time.Second,
-			MaxInterval:     30 * time.Second,
-			MaxElapsedTime:  2 * time.Minute,
-		}),
-		otlpmetrichttp.WithTimeout(30*time.Second),
-	)
-	if err != nil {
-		return fmt.Errorf("failed to create OTLP exporter: %w", err)
+	// One exporter per endpoint, each behind its own periodic reader, so an
+	// unreachable endpoint doesn't hold back the others
+	exporters := make([]*bufferingExporter, 0, len(cfg.Endpoints))
+	for i, endpoint := range cfg.Endpoints {
+		exporter, err := newOTLPExporter(ctx, cfg, endpoint)
+		if err != nil {
+			return fmt.Errorf("failed to create OTLP exporter for %s: %w", endpoint, err)
+		}
+
+		// The first endpoint keeps the original buffer location
+		dir := metricsBufferDir
+		if i > 0 {
+			dir = filepath.Join(metricsBufferDir, bufferDirName(endpoint))
+		}
+		exporters = append(exporters, newBufferingExporter(exporter, endpoint, dir))
 	}
 
 	// Store config for health checks
@@ -87,28 +93,42 @@ func StartOTelCollector(cfg *OTelConfig) error {
 
 	// Create resource without merging with Default() to avoid schema URL conflicts
 	// (resource.Default() uses schema v1.26.0, semconv uses v1.24.0)
-	res := resource.NewWithAttributes(
-		semconv.SchemaURL,
+	attrs := []attribute.KeyValue{
 		semconv.ServiceName("catops-cli"),
 		semconv.ServiceVersion("1.0.0"),
 		semconv.HostName(hostname),
 		attribute.String("catops.server.id", cfg.ServerID),
 		attribute.String("os.type", runtime.GOOS),
-	)
+	}
+	if system, _ := os.Hostname(); system != "" && system != hostname {
+		attrs = append(attrs, attribute.String("catops.system.hostname", system))
+	}
+	if cfg.Environment != "" {
+		attrs = append(attrs, semconv.DeploymentEnvironment(cfg.Environment))
+	}
+	if cfg.Region != "" {
+		attrs = append(attrs, semconv.CloudRegion(cfg.Region))
+	}
+	for k, v := range cfg.Labels {
+		attrs = append(attrs, attribute.String("catops.label."+k, v))
+	}
+	res := resource.NewWithAttributes(semconv.SchemaURL, attrs...)
 
 	interval := cfg.CollectionInterval
 	if interval == 0 {
 		interval = 30 * time.Second
 	}
 
-	meterProvider = sdkmetric.NewMeterProvider(
-		sdkmetric.WithResource(res),
-		sdkmetric.WithReader(
+	opts := []sdkmetric.Option{sdkmetric.WithResource(res)}
+	for _, exporter := range exporters {
+		opts = append(opts, sdkmetric.WithReader(
 			sdkmetric.NewPeriodicReader(exporter,
 				sdkmetric.WithInterval(interval),
 			),
-		),
-	)
+		))
+	}
+	meterProvider = sdkmetric.NewMeterProvider(opts...)
+	activeExporters = exporters
 
 	otel.SetMeterProvider(meterProvider)
 
@@ -116,7 +136,7 @@ func StartOTelCollector(cfg *OTelConfig) error {
 		metric.WithInstrumentationVersion("1.0.0"),
 	)
 
-	if err := registerAllMetrics(); err != nil {
+	if err := registerAllMetrics(cfg.MetricGroups); err != nil {
 		return fmt.Errorf("failed to register metrics: %w", err)
 	}
 
@@ -140,6 +160,7 @@ func StopOTelCollector() error {
 	otelStarted = false
 	meterProvider = nil
 	meter = nil
+	activeExporters = nil
 
 	return err
 }
@@ -197,7 +218,128 @@ func CheckOTelHealth() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 	defer cancel()
 
-	return meterProvider.ForceFlush(ctx)
+	err := meterProvider.ForceFlush(ctx)
+	if err == nil || len(activeExporters) < 2 {
+		return err
+	}
+
+	// With several endpoints, healthy as long as one of them accepts data;
+	// failed endpoints keep buffering and catch up when they recover
+	for _, e := range activeExporters {
+		if e.LastError() == nil {
+			logger.Warning("[OTLP] Some endpoints failed: %v", err)
+			return nil
+		}
+	}
+	return err
+}
+
+// OTLPTarget is an OTLP endpoint split into the parts the HTTP exporter needs
+type OTLPTarget struct {
+	Host     string // host[:port], lower case
+	Path     string // URL path, constants.OTLP_PATH when none was given
+	Insecure bool   // plaintext http://
+}
+
+// ParseOTLPEndpoint validates and normalizes an otlp_endpoint value. Accepted
+// forms are "host[:port][/path]" and the same with an https:// or http://
+// (plaintext) scheme; anything else returns an error naming the problem.
+func ParseOTLPEndpoint(endpoint string) (OTLPTarget, error) {
+	raw := strings.TrimSpace(endpoint)
+	if raw == "" {
+		return OTLPTarget{}, fmt.Errorf("empty OTLP endpoint")
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return OTLPTarget{}, fmt.Errorf("invalid OTLP endpoint %q: %v", endpoint, err)
+	}
+
+	target := OTLPTarget{Host: strings.ToLower(strings.TrimSuffix(u.Host, ":")), Path: u.Path}
+	switch strings.ToLower(u.Scheme) {
+	case "https":
+	case "http":
+		target.Insecure = true
+	default:
+		return OTLPTarget{}, fmt.Errorf("invalid OTLP endpoint %q: unsupported scheme %s:// (use https:// or http://, OTLP/gRPC is not supported)", endpoint, u.Scheme)
+	}
+
+	switch {
+	case u.Hostname() == "":
+		return OTLPTarget{}, fmt.Errorf("invalid OTLP endpoint %q: missing host", endpoint)
+	case u.User != nil:
+		return OTLPTarget{}, fmt.Errorf("invalid OTLP endpoint %q: credentials in the URL are not supported", u.Redacted())
+	case u.RawQuery != "" || u.Fragment != "":
+		return OTLPTarget{}, fmt.Errorf("invalid OTLP endpoint %q: query strings and fragments are not supported", endpoint)
+	}
+	if port := u.Port(); port != "" {
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return OTLPTarget{}, fmt.Errorf("invalid OTLP endpoint %q: port %s out of range", endpoint, port)
+		}
+	}
+
+	if target.Path == "" || target.Path == "/" {
+		target.Path = constants.OTLP_PATH
+	}
+	return target, nil
+}
+
+// newOTLPExporter creates an OTLP HTTP exporter for endpoint (see ParseOTLPEndpoint).
+// CatOps credentials are only sent to the CatOps backend, and only over TLS.
+func newOTLPExporter(ctx context.Context, cfg *OTelConfig, endpoint string) (sdkmetric.Exporter, error) {
+	target, err := ParseOTLPEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var extra []otlpmetrichttp.Option
+	if target.Insecure {
+		extra = append(extra, otlpmetrichttp.WithInsecure())
+	}
+	host, path := target.Host, target.Path
+
+	scheme := "https"
+	if target.Insecure {
+		scheme = "http"
+	}
+	headers := utils.RequestHeaders(&url.URL{Scheme: scheme, Host: host, Path: path})
+	headers["X-CatOps-Server-ID"] = cfg.ServerID
+	if hostname, _, _ := strings.Cut(host, ":"); hostname == constants.OTLP_ENDPOINT && !target.Insecure {
+		headers["Authorization"] = "Bearer " + cfg.AuthToken
+	}
+
+	timeout := cfg.ExportTimeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+
+	opts := []otlpmetrichttp.Option{
+		otlpmetrichttp.WithEndpoint(host),
+		otlpmetrichttp.WithURLPath(path),
+		otlpmetrichttp.WithHeaders(headers),
+		// Retry configuration for resilience
+		otlpmetrichttp.WithRetry(otlpmetrichttp.RetryConfig{
+			Enabled:         true,
+			InitialInterval: 5 * time.Second,
+			MaxInterval:     30 * time.Second,
+			MaxElapsedTime:  2 * time.Minute,
+		}),
+		otlpmetrichttp.WithTimeout(timeout),
+	}
+	return otlpmetrichttp.New(ctx, append(opts, extra...)...)
+}
+
+// bufferDirName turns an endpoint into a safe directory name
+func bufferDirName(endpoint string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '-' {
+			return r
+		}
+		return '_'
+	}, endpoint)
 }
 
 // IsOTelStarted returns true if OTel collector is running
@@ -8,58 +8,63 @@ import (
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
+
+	"catops/internal/logger"
 )
 
 // =============================================================================
 // OTel Metrics Registration
 // =============================================================================
 
-func registerAllMetrics() error {
-	// System Summary Metrics
-	if err := registerSystemSummaryMetrics(); err != nil {
-		return err
-	}
-
-	// Per-core CPU Metrics
-	if err := registerCPUCoreMetrics(); err != nil {
-		return err
-	}
-
-	// Memory Metrics
-	if err := registerMemoryMetrics(); err != nil {
-		return err
-	}
-
-	// Per-mount Disk Metrics
-	if err := registerDiskMetrics(); err != nil {
-		return err
-	}
-
-	// Per-interface Network Metrics
-	if err := registerNetworkMetrics(); err != nil {
-		return err
-	}
+// metricGroups are the instrument groups enabled_metric_groups selects from, in registration order
+var metricGroups = []struct {
+	name     string
+	register func() error
+}{
+	{"system", registerSystemSummaryMetrics}, // catops.system.* summary
+	{"cpu_cores", registerCPUCoreMetrics},    // per-core CPU
+	{"memory", registerMemoryMetrics},
+	{"disk", registerDiskMetrics},       // per-mount
+	{"network", registerNetworkMetrics}, // per-interface
+	{"process", registerProcessMetrics},
+	{"service", registerServiceMetrics},
+	{"container", registerContainerMetrics},
+	{"runtime", registerRuntimeMetrics}, // container runtime health
+	{"unit", registerUnitMetrics},       // systemd units
+	{"thermal", registerThermalMetrics}, // temperature sensors, CPU throttling
+	{"log", registerLogMetrics},
+	{"agent", registerAgentMetrics}, // catops's own resource usage
+}
 
-	// Process Metrics
-	if err := registerProcessMetrics(); err != nil {
-		return err
+// MetricGroupNames returns the names accepted in enabled_metric_groups
+func MetricGroupNames() []string {
+	names := make([]string, len(metricGroups))
+	for i, g := range metricGroups {
+		names[i] = g.name
 	}
+	return names
+}
 
-	// Service Metrics
-	if err := registerServiceMetrics(); err != nil {
-		return err
+// registerAllMetrics registers the instruments of the enabled groups (all when enabled is empty)
+func registerAllMetrics(enabled []string) error {
+	selected := make(map[string]bool, len(enabled))
+	for _, name := range enabled {
+		selected[strings.ToLower(strings.TrimSpace(name))] = true
 	}
 
-	// Container Metrics
-	if err := registerContainerMetrics(); err != nil {
-		return err
+	for _, g := range metricGroups {
+		if len(selected) > 0 && !selected[g.name] {
+			continue
+		}
+		delete(selected, g.name)
+		if err := g.register(); err != nil {
+			return err
+		}
 	}
 
-	// Log Metrics
-	if err := registerLogMetrics(); err != nil {
-		return err
+	for name := range selected {
+		logger.Warning("Unknown metric group %q in enabled_metric_groups (known: %s)", name, strings.Join(MetricGroupNames(), ", "))
 	}
-
 	return nil
 }
 
@@ -569,11 +574,14 @@ func registerProcessMetrics() error {
 					attribute.String("status", p.Status),
 					attribute.Int("num_threads", int(p.NumThreads)),
 					attribute.Int("num_fds", int(p.NumFDs)),
+					attribute.Int64("fd_limit", int64(p.FDLimit)),
 					attribute.Int64("memory_rss", int64(p.MemoryRSS)),
 					attribute.Int64("memory_vms", int64(p.MemoryVMS)),
 					attribute.Int64("memory_shared", int64(p.MemoryShared)),
 					attribute.Int64("io_read_bytes", int64(p.IOReadBytes)),
 					attribute.Int64("io_write_bytes", int64(p.IOWriteBytes)),
+					attribute.Int64("io_read_rate", int64(p.IOReadRate)),
+					attribute.Int64("io_write_rate", int64(p.IOWriteRate)),
 					attribute.Int64("create_time", p.CreateTime),
 					attribute.Float64("cpu_time_user", p.CPUTimeUser),
 					attribute.Float64("cpu_time_system", p.CPUTimeSystem),
@@ -671,6 +679,8 @@ func registerContainerMetrics() error {
 					attribute.Int("pids_limit", int(c.PIDsLimit)),
 					attribute.String("ports", c.Ports),
 					attribute.String("labels", c.Labels),
+					attribute.String("service", c.Service),
+					attribute.String("environment", c.Environment),
 					attribute.String("recent_logs", string(logsJSON)),
 				}
 				o.Observe(c.CPUPercent, metric.WithAttributes(append(attrs, attribute.String("metric", "cpu"))...))
@@ -682,6 +692,111 @@ func registerContainerMetrics() error {
 	return err
 }
 
+func registerRuntimeMetrics() error {
+	// catops.container.runtime - 1 while the docker/podman daemon answers, 0 otherwise
+	_, err := meter.Int64ObservableGauge(
+		"catops.container.runtime",
+		metric.WithDescription("Container runtime daemon health (1 = responsive)"),
+		metric.WithInt64Callback(func(ctx context.Context, o metric.Int64Observer) error {
+			m := GetCachedMetrics()
+			if m == nil {
+				return nil
+			}
+
+			for _, r := range m.Runtimes {
+				var up int64
+				if r.Responsive {
+					up = 1
+				}
+				o.Observe(up, metric.WithAttributes(
+					attribute.String("runtime", r.Name),
+					attribute.String("version", r.Version),
+				))
+			}
+			return nil
+		}),
+	)
+	return err
+}
+
+func registerUnitMetrics() error {
+	// catops.system.units - 1 while a unit is active, 0 otherwise
+	_, err := meter.Int64ObservableGauge(
+		"catops.system.units",
+		metric.WithDescription("systemd unit state (1 = active)"),
+		metric.WithInt64Callback(func(ctx context.Context, o metric.Int64Observer) error {
+			m := GetCachedMetrics()
+			if m == nil {
+				return nil
+			}
+
+			for _, u := range m.Units {
+				var active int64
+				if u.ActiveState == "active" {
+					active = 1
+				}
+				o.Observe(active, metric.WithAttributes(
+					attribute.String("unit", u.Name),
+					attribute.String("description", u.Description),
+					attribute.String("load_state", u.LoadState),
+					attribute.String("active_state", u.ActiveState),
+					attribute.String("sub_state", u.SubState),
+					attribute.Bool("tracked", u.Tracked),
+				))
+			}
+			return nil
+		}),
+	)
+	return err
+}
+
+func registerThermalMetrics() error {
+	// catops.system.temperature - Hardware sensor readings
+	_, err := meter.Float64ObservableGauge(
+		"catops.system.temperature",
+		metric.WithDescription("Hardware temperature sensor readings"),
+		metric.WithUnit("Cel"),
+		metric.WithFloat64Callback(func(ctx context.Context, o metric.Float64Observer) error {
+			m := GetCachedMetrics()
+			if m == nil {
+				return nil
+			}
+
+			for _, t := range m.Temperatures {
+				o.Observe(t.Celsius, metric.WithAttributes(
+					attribute.String("sensor", t.Sensor),
+					attribute.Float64("high", t.High),
+					attribute.Float64("critical", t.Critical),
+				))
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		return err
+	}
+
+	// catops.system.thermal_throttle - 1 while the CPU is throttled to cool off
+	_, err = meter.Int64ObservableGauge(
+		"catops.system.thermal_throttle",
+		metric.WithDescription("CPU thermal throttling (1 = throttled since the previous collection)"),
+		metric.WithInt64Callback(func(ctx context.Context, o metric.Int64Observer) error {
+			m := GetCachedMetrics()
+			if m == nil || m.Throttle == nil {
+				return nil
+			}
+
+			var active int64
+			if m.Throttle.Active {
+				active = 1
+			}
+			o.Observe(active)
+			return nil
+		}),
+	)
+	return err
+}
+
 func registerLogMetrics() error {
 	// catops.log - Log entries from containers and services
 	// Value is always 1 (presence indicator); uniqueness guaranteed by message_hash attribute.
@@ -708,7 +823,8 @@ func registerLogMetrics() error {
 						attribute.String("source_path", c.ContainerName),
 						attribute.String("level", level),
 						attribute.String("message", truncateString(logLine, 500)),
-						attribute.String("service", c.ContainerName),
+						attribute.String("service", c.Service),
+						attribute.String("environment", c.Environment),
 						attribute.String("container_id", c.ContainerID),
 						attribute.String("message_hash", msgHash),
 						attribute.Int("pid", 0),
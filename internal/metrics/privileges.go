@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"os"
+	"runtime"
+	"sync/atomic"
+)
+
+// skipPrivileged skips per-process reads that fail for other users' processes
+// when not running as root (skip_privileged_metrics)
+var skipPrivileged atomic.Bool
+
+// SetSkipPrivileged enables skipping IO counters and open file descriptors of
+// processes owned by other users when the agent isn't root, instead of
+// attempting reads the kernel refuses every collection
+func SetSkipPrivileged(skip bool) {
+	skipPrivileged.Store(skip)
+}
+
+// MissingPrivileges probes /proc of PID 1 and returns the collected data that
+// is unavailable for other users' processes because the agent lacks the
+// privileges (root or CAP_SYS_PTRACE/CAP_DAC_READ_SEARCH). Empty when running
+// with full access or outside Linux.
+func MissingPrivileges() []string {
+	if runtime.GOOS != "linux" || os.Geteuid() == 0 {
+		return nil
+	}
+
+	var missing []string
+	if _, err := os.ReadFile("/proc/1/io"); err != nil {
+		missing = append(missing, "per-process disk IO")
+	}
+	if _, err := os.ReadDir("/proc/1/fd"); err != nil {
+		missing = append(missing, "open file descriptors")
+	}
+	if _, err := os.Readlink("/proc/1/exe"); err != nil {
+		missing = append(missing, "executable paths")
+	}
+	return missing
+}
+
+// skipProcessReads reports whether IO and fd reads of pid are skipped because
+// it belongs to another user and skip_privileged_metrics is set. Root reads
+// everything, the setting only applies when not running as root.
+func skipProcessReads(pid int32) bool {
+	if !skipPrivileged.Load() || os.Geteuid() == 0 {
+		return false
+	}
+	owner, ok := processOwner(pid)
+	return ok && owner != os.Geteuid()
+}
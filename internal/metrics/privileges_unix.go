@@ -0,0 +1,23 @@
+//go:build !windows
+// +build !windows
+
+package metrics
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// processOwner returns the UID owning /proc/<pid> (Linux only)
+func processOwner(pid int32) (int, bool) {
+	info, err := os.Stat(fmt.Sprintf("/proc/%d", pid))
+	if err != nil {
+		return 0, false
+	}
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, false
+	}
+	return int(st.Uid), true
+}
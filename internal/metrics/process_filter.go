@@ -0,0 +1,92 @@
+package metrics
+
+import (
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+
+	"catops/internal/logger"
+)
+
+// processPattern matches process names by glob, or by regex when written as /expr/
+type processPattern struct {
+	glob string
+	re   *regexp.Regexp
+}
+
+var (
+	// Configured process filters (process_excludes / process_includes)
+	processExcludes  []processPattern
+	processIncludes  []processPattern
+	processFiltersMu sync.RWMutex
+)
+
+// SetProcessFilters sets name patterns for processes hidden from the top list
+// (excludes) and processes always tracked regardless of the memory floor (includes).
+// Patterns are globs ("chrome*") or regexes wrapped in slashes ("/^php-fpm: pool/").
+func SetProcessFilters(excludes, includes []string) {
+	ex := compileProcessPatterns(excludes)
+	in := compileProcessPatterns(includes)
+
+	processFiltersMu.Lock()
+	defer processFiltersMu.Unlock()
+	processExcludes = ex
+	processIncludes = in
+}
+
+// compileProcessPatterns parses patterns, skipping invalid ones
+func compileProcessPatterns(patterns []string) []processPattern {
+	var compiled []processPattern
+	for _, p := range patterns {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+
+		if len(p) > 2 && strings.HasPrefix(p, "/") && strings.HasSuffix(p, "/") {
+			re, err := regexp.Compile(p[1 : len(p)-1])
+			if err != nil {
+				logger.Warning("Ignoring invalid process pattern %s: %v", p, err)
+				continue
+			}
+			compiled = append(compiled, processPattern{re: re})
+			continue
+		}
+
+		if _, err := path.Match(p, ""); err != nil {
+			logger.Warning("Ignoring invalid process pattern %s: %v", p, err)
+			continue
+		}
+		compiled = append(compiled, processPattern{glob: p})
+	}
+	return compiled
+}
+
+// matchesAny reports whether name matches one of the patterns
+func matchesAny(patterns []processPattern, name string) bool {
+	for _, p := range patterns {
+		if p.re != nil {
+			if p.re.MatchString(name) {
+				return true
+			}
+		} else if ok, _ := path.Match(p.glob, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isProcessExcluded reports whether a process is hidden by process_excludes
+func isProcessExcluded(name string) bool {
+	processFiltersMu.RLock()
+	defer processFiltersMu.RUnlock()
+	return matchesAny(processExcludes, name)
+}
+
+// isProcessIncluded reports whether a process is force-tracked by process_includes
+func isProcessIncluded(name string) bool {
+	processFiltersMu.RLock()
+	defer processFiltersMu.RUnlock()
+	return matchesAny(processIncludes, name)
+}
@@ -0,0 +1,240 @@
+package metrics
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// remoteTimeout bounds the whole SSH round trip
+const remoteTimeout = 30 * time.Second
+
+// remoteScript gathers a minimal metric set on a Linux host with only a POSIX
+// shell and coreutils. Each section starts with a "==name==" marker line.
+const remoteScript = `
+echo "==hostname=="; hostname
+echo "==os=="; . /etc/os-release 2>/dev/null && echo "$PRETTY_NAME" || uname -sr
+echo "==ip=="; hostname -I 2>/dev/null | awk '{print $1}'
+echo "==uptime=="; cat /proc/uptime
+echo "==loadavg=="; cat /proc/loadavg
+echo "==nproc=="; nproc 2>/dev/null || grep -c ^processor /proc/cpuinfo
+echo "==stat=="; head -1 /proc/stat; sleep 1; head -1 /proc/stat
+echo "==meminfo=="; cat /proc/meminfo
+echo "==df=="; df -Pk / | tail -1
+`
+
+// RemoteMetrics are metrics gathered from a host without catops installed
+type RemoteMetrics struct {
+	Metrics
+	Hostname string
+	Load     [3]float64 // 1, 5 and 15 minute load averages
+}
+
+// remoteName is a user or host name in a --remote target. It may not start
+// with "-", which ssh would parse as an option.
+var remoteName = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9._-]*$`)
+
+// parseRemoteTarget splits [user@]host[:port] (IPv6 hosts in brackets) into
+// the ssh destination and port, "" when none is given
+func parseRemoteTarget(target string) (dest, port string, err error) {
+	user, host, hasUser := strings.Cut(target, "@")
+	if !hasUser {
+		user, host = "", target
+	}
+
+	switch {
+	case strings.HasPrefix(host, "["):
+		addr, rest, ok := strings.Cut(host[1:], "]")
+		if !ok || net.ParseIP(addr) == nil || (rest != "" && !strings.HasPrefix(rest, ":")) {
+			return "", "", fmt.Errorf("invalid remote target %q: bad IPv6 address", target)
+		}
+		host, port = addr, strings.TrimPrefix(rest, ":")
+	case strings.Count(host, ":") == 1:
+		host, port, _ = strings.Cut(host, ":")
+	}
+
+	if hasUser && !remoteName.MatchString(user) {
+		return "", "", fmt.Errorf("invalid remote target %q: bad user name, use [user@]host[:port]", target)
+	}
+	if !remoteName.MatchString(host) && net.ParseIP(host) == nil {
+		return "", "", fmt.Errorf("invalid remote target %q: bad host, use [user@]host[:port]", target)
+	}
+	if port != "" {
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return "", "", fmt.Errorf("invalid remote target %q: port %s out of range", target, port)
+		}
+	}
+
+	dest = host
+	if hasUser {
+		dest = user + "@" + host
+	}
+	return dest, port, nil
+}
+
+// GetRemoteMetrics collects CPU, memory, disk, load and uptime from a Linux
+// host over SSH (target is [user@]host[:port], host may be an ssh config
+// alias). Key-based auth is required: ssh runs in batch mode and never prompts.
+func GetRemoteMetrics(target string) (*RemoteMetrics, error) {
+	dest, port, err := parseRemoteTarget(target)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), remoteTimeout)
+	defer cancel()
+
+	args := []string{"-o", "BatchMode=yes", "-o", "ConnectTimeout=10"}
+	if port != "" {
+		args = append(args, "-p", port)
+	}
+	// "--" ends the options, dest can't be taken for one
+	args = append(args, "--", dest, "sh", "-s")
+	cmd := exec.CommandContext(ctx, "ssh", args...)
+	cmd.Stdin = strings.NewReader(remoteScript)
+	cmd.WaitDelay = time.Second
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if ctx.Err() == context.DeadlineExceeded {
+		return nil, fmt.Errorf("ssh %s timed out after %v", target, remoteTimeout)
+	}
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("ssh %s: %s", target, msg)
+		}
+		return nil, fmt.Errorf("ssh %s: %w", target, err)
+	}
+
+	return parseRemoteOutput(string(output))
+}
+
+// parseRemoteOutput converts remoteScript output into RemoteMetrics
+func parseRemoteOutput(output string) (*RemoteMetrics, error) {
+	sections := make(map[string][]string)
+	current := ""
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "==") && strings.HasSuffix(line, "==") && len(line) > 4 {
+			current = line[2 : len(line)-2]
+			continue
+		}
+		if current != "" && line != "" {
+			sections[current] = append(sections[current], line)
+		}
+	}
+	if len(sections["stat"]) < 2 || len(sections["meminfo"]) == 0 {
+		return nil, fmt.Errorf("unexpected output, the remote host must be Linux")
+	}
+
+	first := func(name string) string {
+		if lines := sections[name]; len(lines) > 0 {
+			return lines[0]
+		}
+		return ""
+	}
+
+	m := &RemoteMetrics{Hostname: first("hostname")}
+	m.Timestamp = time.Now().UTC().Format("2006-01-02 15:04:05")
+	m.OSName = first("os")
+	m.IPAddress = first("ip")
+	if m.IPAddress == "" {
+		m.IPAddress = "unknown"
+	}
+
+	m.Uptime = "unknown"
+	if fields := strings.Fields(first("uptime")); len(fields) > 0 {
+		if seconds, err := strconv.ParseFloat(fields[0], 64); err == nil {
+			m.Uptime = formatUptime(uint64(seconds))
+		}
+	}
+
+	if fields := strings.Fields(first("loadavg")); len(fields) >= 3 {
+		for i := range m.Load {
+			m.Load[i], _ = strconv.ParseFloat(fields[i], 64)
+		}
+	}
+
+	// CPU: delta between two /proc/stat samples taken 1s apart
+	cores, _ := strconv.ParseInt(first("nproc"), 10, 64)
+	before, after := parseStatLine(sections["stat"][0]), parseStatLine(sections["stat"][1])
+	if len(before) >= 5 && len(after) == len(before) {
+		// user nice system idle iowait irq softirq steal (guest time is already in user)
+		var total, idle, iowait float64
+		for i := 0; i < len(after) && i < 8; i++ {
+			total += after[i] - before[i]
+		}
+		idle = (after[3] - before[3]) + (after[4] - before[4])
+		iowait = after[4] - before[4]
+		if total > 0 {
+			m.CPUUsage = (total - idle) / total * 100
+			m.IOWait = iowait / total * 100
+		}
+	}
+	m.CPUDetails = ResourceUsage{Total: cores, Usage: m.CPUUsage}
+
+	// Memory (kB)
+	mem := make(map[string]int64)
+	for _, line := range sections["meminfo"] {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 {
+			mem[strings.TrimSuffix(fields[0], ":")], _ = strconv.ParseInt(fields[1], 10, 64)
+		}
+	}
+	if total := mem["MemTotal"]; total > 0 {
+		available := mem["MemAvailable"]
+		if available == 0 {
+			available = mem["MemFree"] + mem["Buffers"] + mem["Cached"]
+		}
+		m.MemoryUsage = float64(total-available) / float64(total) * 100
+		m.MemoryDetails = ResourceUsage{
+			Total:     total,
+			Used:      total - available,
+			Free:      mem["MemFree"],
+			Available: available,
+			Usage:     m.MemoryUsage,
+		}
+	}
+
+	// Root filesystem (df -Pk: device, 1K-blocks, used, available, capacity, mount)
+	if fields := strings.Fields(first("df")); len(fields) >= 4 {
+		total, _ := strconv.ParseInt(fields[1], 10, 64)
+		used, _ := strconv.ParseInt(fields[2], 10, 64)
+		available, _ := strconv.ParseInt(fields[3], 10, 64)
+		if used+available > 0 {
+			m.DiskUsage = float64(used) / float64(used+available) * 100
+		}
+		m.DiskDetails = ResourceUsage{
+			Total:     total,
+			Used:      used,
+			Free:      available,
+			Available: available,
+			Usage:     m.DiskUsage,
+		}
+	}
+
+	return m, nil
+}
+
+// parseStatLine returns the jiffy counters of the aggregate "cpu" line in /proc/stat
+func parseStatLine(line string) []float64 {
+	fields := strings.Fields(line)
+	if len(fields) < 2 || fields[0] != "cpu" {
+		return nil
+	}
+	values := make([]float64, 0, len(fields)-1)
+	for _, f := range fields[1:] {
+		v, _ := strconv.ParseFloat(f, 64)
+		values = append(values, v)
+	}
+	return values
+}
@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// runtimeVersionTTL is how long a runtime version is reused before asking again
+	runtimeVersionTTL = time.Hour
+
+	// runtimeProbeTimeout bounds the responsiveness check of a runtime daemon
+	runtimeProbeTimeout = 3 * time.Second
+)
+
+// containerRuntimes are checked in this order; only installed ones are reported
+var containerRuntimes = []struct {
+	name          string
+	versionFormat string
+}{
+	{"docker", "{{.Server.Version}}"},
+	{"podman", "{{.Client.Version}}"}, // podman is daemonless, the client is the engine
+}
+
+var (
+	// Cached runtime versions, they rarely change
+	runtimeVersions   = make(map[string]runtimeVersion)
+	runtimeVersionsMu sync.Mutex
+)
+
+type runtimeVersion struct {
+	version   string
+	fetchedAt time.Time
+}
+
+// GetContainerRuntimes reports each installed container runtime, its version and
+// whether its daemon answers. The version is cached for runtimeVersionTTL and
+// refetched sooner only after the runtime was unresponsive.
+func GetContainerRuntimes() []ContainerRuntimeInfo {
+	var runtimes []ContainerRuntimeInfo
+	for _, rt := range containerRuntimes {
+		if _, err := exec.LookPath(rt.name); err != nil {
+			continue
+		}
+
+		info := ContainerRuntimeInfo{Name: rt.name}
+		if _, err := runContainerCLI(runtimeProbeTimeout, rt.name, "ps", "--quiet", "--latest"); err != nil {
+			info.Error = cliError(err)
+			runtimeVersionsMu.Lock()
+			delete(runtimeVersions, rt.name)
+			runtimeVersionsMu.Unlock()
+			runtimes = append(runtimes, info)
+			continue
+		}
+		info.Responsive = true
+
+		runtimeVersionsMu.Lock()
+		cached, ok := runtimeVersions[rt.name]
+		runtimeVersionsMu.Unlock()
+		if ok && time.Since(cached.fetchedAt) < runtimeVersionTTL {
+			info.Version = cached.version
+		} else if output, err := runContainerCLI(runtimeProbeTimeout, rt.name, "version", "--format", rt.versionFormat); err == nil {
+			info.Version = strings.TrimSpace(string(output))
+			runtimeVersionsMu.Lock()
+			runtimeVersions[rt.name] = runtimeVersion{version: info.Version, fetchedAt: time.Now()}
+			runtimeVersionsMu.Unlock()
+		}
+
+		runtimes = append(runtimes, info)
+	}
+	return runtimes
+}
+
+// cliError returns the stderr of a failed CLI call when available, the error otherwise
+func cliError(err error) string {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+		return strings.TrimSpace(string(exitErr.Stderr))
+	}
+	return err.Error()
+}
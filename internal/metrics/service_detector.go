@@ -122,6 +122,9 @@ func (d *ServiceDetector) DetectServices() ([]ServiceInfo, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to get processes: %w", err)
 	}
+	if len(allProcesses) == 0 {
+		return nil, fmt.Errorf("failed to get processes: empty process list")
+	}
 
 	var services []ServiceInfo
 
@@ -204,7 +207,7 @@ func (d *ServiceDetector) DetectServices() ([]ServiceInfo, error) {
 			IsContainer:   isContainer,
 			ContainerID:   containerID,
 			ContainerName: "",
-			HealthStatus:  "",
+			HealthStatus:  serviceHealth(statusChar),
 		}
 
 		services = append(services, service)
@@ -213,6 +216,18 @@ func (d *ServiceDetector) DetectServices() ([]ServiceInfo, error) {
 	return services, nil
 }
 
+// serviceHealth derives a health status from the process state
+func serviceHealth(statusChar string) string {
+	switch statusChar {
+	case "Z":
+		return "unhealthy" // zombie
+	case "D", "T":
+		return "degraded" // stuck in IO or stopped
+	default:
+		return "healthy"
+	}
+}
+
 // collectListeningPorts collects all listening ports on the system
 func (d *ServiceDetector) collectListeningPorts() error {
 	connections, err := getCachedConnections()
@@ -544,3 +559,24 @@ func GetServices() ([]ServiceInfo, error) {
 
 	return services, nil
 }
+
+// MissingServices returns the entries of required (required_services) that match
+// no detected service. An entry matches a service by name, type or container
+// name, case-insensitively.
+func MissingServices(services []ServiceInfo, required []string) []string {
+	var missing []string
+	for _, want := range required {
+		found := false
+		for _, svc := range services {
+			if strings.EqualFold(svc.ServiceName, want) || strings.EqualFold(string(svc.ServiceType), want) ||
+				(svc.ContainerName != "" && strings.EqualFold(svc.ContainerName, want)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, want)
+		}
+	}
+	return missing
+}
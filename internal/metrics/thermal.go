@@ -0,0 +1,124 @@
+package metrics
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/sensors"
+)
+
+// Linux exposes thermal throttle counters per CPU (x86 with the therm_throt
+// driver); package counters repeat on every CPU of the package
+const cpuSysfsDir = "/sys/devices/system/cpu"
+
+// piThrottledPath is the Raspberry Pi firmware's throttle state (vcgencmd get_throttled)
+const piThrottledPath = "/sys/devices/platform/soc/soc:firmware/get_throttled"
+
+// Raspberry Pi get_throttled bits that are set while throttling is in effect
+const piThrottledNow = 0x2 | 0x4 | 0x8 // frequency capped, throttled, soft temperature limit
+
+var (
+	// Throttle events seen at the previous collection, for the per-interval count
+	lastThrottleTotal uint64
+	lastThrottleSeen  bool
+	throttleMu        sync.Mutex
+)
+
+// GetTemperatures reads the hardware temperature sensors. Most VMs have none
+// and return an empty slice. Sensors that fail to read are skipped.
+func GetTemperatures() ([]TemperatureInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stats, err := sensors.TemperaturesWithContext(ctx)
+	if len(stats) == 0 {
+		return nil, err
+	}
+
+	temps := make([]TemperatureInfo, 0, len(stats))
+	for _, s := range stats {
+		if s.Temperature <= 0 {
+			continue // unconnected inputs report 0
+		}
+		temps = append(temps, TemperatureInfo{
+			Sensor:   s.SensorKey,
+			Celsius:  s.Temperature,
+			High:     s.High,
+			Critical: s.Critical,
+		})
+	}
+	sort.Slice(temps, func(i, j int) bool { return temps[i].Sensor < temps[j].Sensor })
+	return temps, nil
+}
+
+// GetThermalThrottle reports CPU thermal throttling since the previous call,
+// nil where the host exposes no throttle state (non-Linux, most VMs)
+func GetThermalThrottle() *ThermalThrottleInfo {
+	if runtime.GOOS != "linux" {
+		return nil
+	}
+
+	if data, err := os.ReadFile(piThrottledPath); err == nil {
+		value, err := strconv.ParseUint(strings.TrimPrefix(strings.TrimSpace(string(data)), "0x"), 16, 64)
+		if err != nil {
+			return nil
+		}
+		return &ThermalThrottleInfo{Active: value&piThrottledNow != 0}
+	}
+
+	total, ok := readThrottleCounters()
+	if !ok {
+		return nil
+	}
+
+	throttleMu.Lock()
+	defer throttleMu.Unlock()
+
+	info := &ThermalThrottleInfo{Total: total}
+	if lastThrottleSeen && total >= lastThrottleTotal {
+		info.Events = total - lastThrottleTotal
+		info.Active = info.Events > 0
+	}
+	lastThrottleTotal, lastThrottleSeen = total, true
+	return info
+}
+
+// readThrottleCounters sums the core throttle counters of every CPU and the
+// package counter once per physical package
+func readThrottleCounters() (uint64, bool) {
+	dirs, _ := filepath.Glob(filepath.Join(cpuSysfsDir, "cpu[0-9]*", "thermal_throttle"))
+	if len(dirs) == 0 {
+		return 0, false
+	}
+
+	readCount := func(path string) uint64 {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return 0
+		}
+		n, _ := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+		return n
+	}
+
+	var total uint64
+	packages := make(map[string]bool)
+	for _, dir := range dirs {
+		total += readCount(filepath.Join(dir, "core_throttle_count"))
+
+		pkg, err := os.ReadFile(filepath.Join(filepath.Dir(dir), "topology", "physical_package_id"))
+		id := strings.TrimSpace(string(pkg))
+		if err != nil || packages[id] {
+			continue
+		}
+		packages[id] = true
+		total += readCount(filepath.Join(dir, "package_throttle_count"))
+	}
+	return total, true
+}
@@ -139,8 +139,11 @@ type ProcessInfo struct {
 	Status        string  `json:"status"`
 	NumThreads    uint16  `json:"num_threads"`
 	NumFDs        uint32  `json:"num_fds"`
+	FDLimit       uint64  `json:"fd_limit"` // soft RLIMIT_NOFILE, 0 if unknown
 	IOReadBytes   uint64  `json:"io_read_bytes"`
 	IOWriteBytes  uint64  `json:"io_write_bytes"`
+	IOReadRate    uint64  `json:"io_read_rate"`  // bytes/s since the previous collection
+	IOWriteRate   uint64  `json:"io_write_rate"` // bytes/s since the previous collection
 	CreateTime    int64   `json:"create_time"`
 	CPUTimeUser   float64 `json:"cpu_time_user"`
 	CPUTimeSystem float64 `json:"cpu_time_system"`
@@ -210,10 +213,65 @@ type ContainerMetrics struct {
 	PIDsCurrent      uint32   `json:"pids_current"`
 	PIDsLimit        uint32   `json:"pids_limit"`
 	Ports            string   `json:"ports"`
-	Labels           string   `json:"labels"`
+	Labels           string   `json:"labels"`      // "key=value" pairs, sorted
+	Service          string   `json:"service"`     // container_service_label, compose service or container name
+	Environment      string   `json:"environment"` // container_env_label value
 	RecentLogs       []string `json:"recent_logs"` // Container logs (errors/warnings)
 }
 
+// ContainerRuntimeInfo describes an installed container runtime (docker/podman)
+type ContainerRuntimeInfo struct {
+	Name       string `json:"name"`
+	Version    string `json:"version"`    // engine version, empty while unresponsive
+	Responsive bool   `json:"responsive"` // the daemon answered a cheap API call
+	Error      string `json:"error"`      // why it is unresponsive
+}
+
+// =============================================================================
+// systemd Units
+// =============================================================================
+
+// UnitInfo contains the state of a systemd unit
+type UnitInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	LoadState   string `json:"load_state"`   // loaded, not-found, masked, ...
+	ActiveState string `json:"active_state"` // active, inactive, failed, activating, ...
+	SubState    string `json:"sub_state"`    // running, exited, dead, ...
+	Tracked     bool   `json:"tracked"`      // listed in systemd_units
+}
+
+// =============================================================================
+// Connections
+// =============================================================================
+
+// RemoteConnections counts the TCP connections of one remote address
+type RemoteConnections struct {
+	RemoteIP  string         `json:"remote_ip"`
+	Count     int            `json:"count"`
+	States    map[string]int `json:"states"`              // connections per TCP state
+	Processes map[string]int `json:"processes,omitempty"` // connections per local "name (pid)"
+}
+
+// =============================================================================
+// Thermal
+// =============================================================================
+
+// TemperatureInfo is the reading of one hardware temperature sensor
+type TemperatureInfo struct {
+	Sensor   string  `json:"sensor"`             // e.g. coretemp_package_id_0, cpu_thermal
+	Celsius  float64 `json:"celsius"`            // current reading
+	High     float64 `json:"high,omitempty"`     // the sensor's own high mark, 0 when unknown
+	Critical float64 `json:"critical,omitempty"` // the sensor's own critical mark, 0 when unknown
+}
+
+// ThermalThrottleInfo reports whether the CPU was slowed down to cool off
+type ThermalThrottleInfo struct {
+	Active bool   `json:"active"` // throttled since the previous collection
+	Events uint64 `json:"events"` // throttle events since the previous collection (x86 counters)
+	Total  uint64 `json:"total"`  // throttle events since boot (x86 counters)
+}
+
 // =============================================================================
 // System Summary
 // =============================================================================
@@ -306,6 +364,11 @@ type AllMetrics struct {
 	Processes  []ProcessInfo             `json:"processes"`
 	Services   []ServiceInfo             `json:"services"`
 	Containers []ContainerMetrics        `json:"containers"`
+	Runtimes   []ContainerRuntimeInfo    `json:"container_runtimes"`
+	Units      []UnitInfo                `json:"units"`
+
+	Temperatures []TemperatureInfo    `json:"temperatures"`
+	Throttle     *ThermalThrottleInfo `json:"thermal_throttle,omitempty"` // nil where not exposed
 }
 
 // =============================================================================
@@ -314,11 +377,16 @@ type AllMetrics struct {
 
 // OTelConfig holds configuration for OpenTelemetry exporter
 type OTelConfig struct {
-	Endpoint           string
+	Endpoints          []string // OTLP endpoints, each exported to independently
 	AuthToken          string
 	ServerID           string
 	Hostname           string
-	CollectionInterval time.Duration
+	CollectionInterval time.Duration     // OTel periodic reader (export) interval
+	Labels             map[string]string // exported as catops.label.<key> resource attributes
+	Environment        string            // exported as deployment.environment
+	Region             string            // exported as cloud.region
+	ExportTimeout      time.Duration     // per-export request timeout (default 30s)
+	MetricGroups       []string          // instrument groups to export (empty = all)
 }
 
 // Note: Legacy types (Metrics, ResourceUsage, NetworkMetrics, InterfaceInfo)
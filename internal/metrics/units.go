@@ -0,0 +1,143 @@
+package metrics
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	// Units listed in systemd_units, reported even while healthy
+	trackedUnits   []string
+	trackedUnitsMu sync.RWMutex
+)
+
+// SetTrackedUnits sets the systemd units whose state is always collected.
+// Names without a type suffix are treated as services ("nginx" -> "nginx.service").
+func SetTrackedUnits(units []string) {
+	var normalized []string
+	for _, u := range units {
+		u = strings.TrimSpace(u)
+		if u == "" {
+			continue
+		}
+		if !strings.Contains(u, ".") {
+			u += ".service"
+		}
+		normalized = append(normalized, u)
+	}
+
+	trackedUnitsMu.Lock()
+	defer trackedUnitsMu.Unlock()
+	trackedUnits = normalized
+}
+
+// IsSystemd reports whether the host was booted with systemd
+func IsSystemd() bool {
+	if runtime.GOOS != "linux" {
+		return false
+	}
+	if _, err := os.Stat("/run/systemd/system"); err != nil {
+		return false
+	}
+	_, err := exec.LookPath("systemctl")
+	return err == nil
+}
+
+// GetUnits returns failed units plus the state of every tracked unit.
+// Non-systemd hosts return nothing.
+func GetUnits() ([]UnitInfo, error) {
+	if !IsSystemd() {
+		return nil, nil
+	}
+
+	trackedUnitsMu.RLock()
+	tracked := trackedUnits
+	trackedUnitsMu.RUnlock()
+
+	units := make(map[string]UnitInfo)
+
+	output, err := runContainerCLI(containerCLITimeout, "systemctl", "--failed", "--no-legend", "--plain", "--no-pager")
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		// UNIT LOAD ACTIVE SUB DESCRIPTION...
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+		units[fields[0]] = UnitInfo{
+			Name:        fields[0],
+			LoadState:   fields[1],
+			ActiveState: fields[2],
+			SubState:    fields[3],
+			Description: strings.Join(fields[4:], " "),
+		}
+	}
+
+	if len(tracked) > 0 {
+		args := append([]string{"show", "--no-pager", "--property=Id,Description,LoadState,ActiveState,SubState"}, tracked...)
+		output, err := runContainerCLI(containerCLITimeout, "systemctl", args...)
+		if err != nil {
+			return nil, err
+		}
+		for i, u := range parseUnitShow(string(output)) {
+			// systemctl show keeps argument order, Id is empty for unknown units
+			if i < len(tracked) {
+				u.Name = tracked[i]
+			}
+			u.Tracked = true
+			units[u.Name] = u
+		}
+	}
+
+	result := make([]UnitInfo, 0, len(units))
+	for _, u := range units {
+		result = append(result, u)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// parseUnitShow parses "systemctl show" output: Key=Value blocks separated by blank lines
+func parseUnitShow(output string) []UnitInfo {
+	var units []UnitInfo
+	var current *UnitInfo
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			current = nil
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		if current == nil {
+			units = append(units, UnitInfo{})
+			current = &units[len(units)-1]
+		}
+		switch key {
+		case "Id":
+			current.Name = value
+		case "Description":
+			current.Description = value
+		case "LoadState":
+			current.LoadState = value
+		case "ActiveState":
+			current.ActiveState = value
+		case "SubState":
+			current.SubState = value
+		}
+	}
+	return units
+}
@@ -5,9 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"net/http"
 	"os"
 	"runtime"
+	"strings"
 	"time"
 
 	constants "catops/config"
@@ -58,13 +58,17 @@ func RegisterServer(userToken, currentVersion string, cfg *config.Config) bool {
 	}
 
 	serverData := map[string]interface{}{
-		"platform":     platform, // remove "-" + arch
-		"architecture": arch,
-		"type":         "install",
-		"timestamp":    fmt.Sprintf("%d", time.Now().Unix()), // string with Unix timestamp like in install.sh
-		"user_token":   userToken,
+		"platform":      platform, // remove "-" + arch
+		"architecture":  arch,
+		"type":          "install",
+		"timestamp":     fmt.Sprintf("%d", time.Now().Unix()), // string with Unix timestamp like in install.sh
+		"user_token":    userToken,
+		"server_labels": cfg.ServerLabels,
 		"server_info": map[string]string{
 			"hostname":       hostname,
+			"display_name":   cfg.DisplayHostname(),
+			"environment":    cfg.Environment,
+			"region":         cfg.Region,
 			"os_type":        osName,
 			"os_version":     runtime.GOOS + "/" + runtime.GOARCH, // Add OS version info
 			"catops_version": currentVersion,
@@ -77,24 +81,17 @@ func RegisterServer(userToken, currentVersion string, cfg *config.Config) bool {
 
 	jsonData, _ := json.Marshal(serverData)
 
-	// Debug: Log what we're sending
-	logger.Debug("JSON data: %s", string(jsonData))
+	// Debug: Log what we're sending (tokens redacted, the log may be world-readable)
+	logger.Debug("JSON data: %s", redactedJSON(serverData, false))
 
 	// Debug: Log pretty JSON for better readability
-	prettyJSON, _ := json.MarshalIndent(serverData, "", "  ")
-	logger.Debug("Pretty JSON:\n%s", string(prettyJSON))
+	logger.Debug("Pretty JSON:\n%s", redactedJSON(serverData, true))
 
 	// Debug: Log HTTP request details
 	logger.Debug("Sending to URL: %s", constants.INSTALL_URL)
 	logger.Debug("Request method: POST")
 
-	req, err := utils.CreateCLIRequest("POST", constants.INSTALL_URL, bytes.NewBuffer(jsonData), currentVersion)
-	if err != nil {
-		return false
-	}
-
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Do(req)
+	resp, err := utils.PostJSON(utils.NewHTTPClient(), constants.INSTALL_URL, jsonData, currentVersion)
 	if err != nil {
 		return false
 	}
@@ -137,6 +134,35 @@ func RegisterServer(userToken, currentVersion string, cfg *config.Config) bool {
 	return false
 }
 
+// redactedJSON renders a request body for the debug log with every *token
+// field masked, in nested objects too
+func redactedJSON(data map[string]interface{}, pretty bool) string {
+	var redact func(map[string]interface{}) map[string]interface{}
+	redact = func(m map[string]interface{}) map[string]interface{} {
+		out := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			switch value := v.(type) {
+			case string:
+				if strings.Contains(strings.ToLower(k), "token") {
+					v = utils.MaskSecret(value, 4)
+				}
+			case map[string]interface{}:
+				v = redact(value)
+			}
+			out[k] = v
+		}
+		return out
+	}
+
+	var out []byte
+	if pretty {
+		out, _ = json.MarshalIndent(redact(data), "", "  ")
+	} else {
+		out, _ = json.Marshal(redact(data))
+	}
+	return string(out)
+}
+
 // SendUninstallNotification sends uninstall notification to backend
 func SendUninstallNotification(authToken, serverID, currentVersion string) bool {
 	// Get hostname for better server identification
@@ -156,7 +182,7 @@ func SendUninstallNotification(authToken, serverID, currentVersion string) bool
 	jsonData, _ := json.Marshal(uninstallData)
 
 	// Debug logging
-	logger.Debug("Uninstall request data: %s", string(jsonData))
+	logger.Debug("Uninstall request data: %s", redactedJSON(uninstallData, false))
 	logger.Debug("Uninstall URL: %s", constants.UNINSTALL_URL)
 
 	// create request
@@ -167,7 +193,7 @@ func SendUninstallNotification(authToken, serverID, currentVersion string) bool
 		return false
 	}
 
-	client := &http.Client{Timeout: 10 * time.Second}
+	client := utils.NewHTTPClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		// Debug logging for HTTP error
@@ -202,7 +228,7 @@ func TransferServerOwnership(oldToken, newToken, serverID, currentVersion string
 		return false
 	}
 
-	client := &http.Client{Timeout: 10 * time.Second}
+	client := utils.NewHTTPClient()
 	resp, err := client.Do(req)
 
 	if err != nil {
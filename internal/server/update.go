@@ -0,0 +1,198 @@
+package server
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	constants "catops/config"
+	"catops/internal/analytics"
+	"catops/internal/config"
+	"catops/internal/logger"
+	"catops/internal/ui"
+	"catops/pkg/utils"
+)
+
+// maxUpdateBackups matches the backups kept by 'catops cleanup' (catops.backup.1-2)
+const maxUpdateBackups = 2
+
+// FetchLatestVersion returns the latest published CLI version
+func FetchLatestVersion(currentVersion string) (string, error) {
+	req, err := utils.CreateCLIRequest("GET", constants.VERSIONS_URL, nil, currentVersion)
+	if err != nil {
+		return "", err
+	}
+
+	client := utils.NewHTTPClient()
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var result map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to parse version manifest: %w", err)
+	}
+
+	latestVersion, _ := result["version"].(string)
+	if latestVersion == "" {
+		return "", fmt.Errorf("version manifest has no version")
+	}
+	return strings.TrimPrefix(latestVersion, "v"), nil
+}
+
+// InstallUpdate downloads the release binary for this platform, verifies its
+// SHA256 against the published checksums and atomically replaces the running executable
+func InstallUpdate(currentVersion, latestVersion string) error {
+	latestVersion = strings.TrimPrefix(latestVersion, "v")
+	asset := fmt.Sprintf("catops-%s-%s", runtime.GOOS, runtime.GOARCH)
+	baseURL := fmt.Sprintf("%s/v%s", constants.RELEASES_URL, latestVersion)
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("could not determine binary location: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(executable); err == nil {
+		executable = resolved
+	}
+
+	info, err := os.Stat(executable)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{Timeout: 5 * time.Minute}
+
+	ui.PrintStatus("info", "Fetching published checksums...")
+	expected, err := fetchChecksum(client, baseURL+"/checksums.txt", asset)
+	if err != nil {
+		return err
+	}
+
+	// Download next to the executable so the final rename stays on one filesystem
+	ui.PrintStatus("info", fmt.Sprintf("Downloading %s v%s...", asset, latestVersion))
+	tmpFile, actual, err := downloadToTemp(client, baseURL+"/"+asset, filepath.Dir(executable))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile) // no-op after a successful rename
+
+	if actual != expected {
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", asset, expected, actual)
+	}
+	ui.PrintStatus("success", "Checksum verified")
+
+	if err := os.Chmod(tmpFile, info.Mode().Perm()); err != nil {
+		return err
+	}
+
+	backup, err := backupExecutable(executable)
+	if err != nil {
+		return fmt.Errorf("failed to back up current binary: %w", err)
+	}
+
+	if err := os.Rename(tmpFile, executable); err != nil {
+		return fmt.Errorf("failed to replace binary: %w", err)
+	}
+
+	logger.Info("Updated binary %s to v%s (backup: %s)", executable, latestVersion, backup)
+	ui.PrintStatus("success", fmt.Sprintf("Updated to v%s (backup: %s)", latestVersion, filepath.Base(backup)))
+
+	// Migrate service file after update (fix for duplicate path bug)
+	MigrateServiceFile()
+
+	cfg, err := config.LoadConfig()
+	if err == nil && cfg.IsCloudMode() {
+		analytics.NewSender(cfg, currentVersion).SendEventSync("update_installed")
+	}
+	return nil
+}
+
+// fetchChecksum reads a sha256sum-style checksums file and returns the digest for asset
+func fetchChecksum(client *http.Client, url, asset string) (string, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch checksums: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch checksums: HTTP %d", resp.StatusCode)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == asset {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("no published checksum for %s", asset)
+}
+
+// downloadToTemp downloads url into a temp file in dir and returns its path and SHA256
+func downloadToTemp(client *http.Client, url, dir string) (string, string, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return "", "", fmt.Errorf("download failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("download failed: HTTP %d", resp.StatusCode)
+	}
+
+	f, err := os.CreateTemp(dir, ".catops-update-*")
+	if err != nil {
+		return "", "", err
+	}
+
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(f, hash), resp.Body)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return "", "", fmt.Errorf("download failed: %w", err)
+	}
+
+	return f.Name(), hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// backupExecutable copies the current binary to catops.backup.1, shifting older backups up
+func backupExecutable(executable string) (string, error) {
+	dir := filepath.Dir(executable)
+	backupPath := func(n int) string {
+		return filepath.Join(dir, fmt.Sprintf("catops.backup.%d", n))
+	}
+
+	os.Remove(backupPath(maxUpdateBackups))
+	for n := maxUpdateBackups - 1; n >= 1; n-- {
+		if _, err := os.Stat(backupPath(n)); err == nil {
+			os.Rename(backupPath(n), backupPath(n+1))
+		}
+	}
+
+	backup := backupPath(1)
+	if err := utils.CopyFile(executable, backup); err != nil {
+		return "", err
+	}
+	if info, err := os.Stat(executable); err == nil {
+		os.Chmod(backup, info.Mode().Perm())
+	}
+	return backup, nil
+}
@@ -1,11 +1,9 @@
 package server
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
-	"net/http"
 	"os"
 	"os/exec"
 	"runtime"
@@ -29,7 +27,7 @@ func CheckServerVersion(authToken, currentVersion string) (string, string, bool,
 		return "", "", false, err
 	}
 
-	client := &http.Client{Timeout: 10 * time.Second}
+	client := utils.NewHTTPClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return "", "", false, err
@@ -61,68 +59,58 @@ func CheckServerVersion(authToken, currentVersion string) (string, string, bool,
 }
 
 // CheckBasicUpdate performs basic update check without server version
-func CheckBasicUpdate(currentVersion string) {
+// and installs the latest version if one is available
+func CheckBasicUpdate(currentVersion string, useScript bool) {
 	ui.PrintStatus("info", "Checking for latest version...")
 
 	// Get current version
 	ui.PrintStatus("info", fmt.Sprintf("Current version: %s", currentVersion))
 
 	// Check API for latest version
-	req, err := utils.CreateCLIRequest("GET", constants.VERSIONS_URL, nil, currentVersion)
+	latestVersion, err := FetchLatestVersion(currentVersion)
 	if err != nil {
 		ui.PrintStatus("warning", fmt.Sprintf("Failed to check latest version: %v", err))
+		if !useScript {
+			ui.PrintStatus("info", "Run 'catops update --script' to use the install script instead")
+			ui.PrintSectionEnd()
+			return
+		}
 		ui.PrintStatus("info", "Continuing with update script...")
 		ExecuteUpdateScript(currentVersion)
 		return
 	}
 
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Do(req)
-	if err != nil {
-		ui.PrintStatus("warning", fmt.Sprintf("Failed to check latest version: %v", err))
-		ui.PrintStatus("info", "Continuing with update script...")
-		ExecuteUpdateScript(currentVersion)
-		return
-	}
-	defer resp.Body.Close()
+	ui.PrintStatus("info", fmt.Sprintf("Latest version: %s", latestVersion))
 
-	// Read response body
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		ui.PrintStatus("warning", fmt.Sprintf("Failed to read response: %v", err))
-		ui.PrintStatus("info", "Continuing with update script...")
-		ExecuteUpdateScript(currentVersion)
+	if strings.TrimPrefix(currentVersion, "v") == latestVersion {
+		ui.PrintStatus("success", "Already up to date!")
+		ui.PrintSectionEnd()
 		return
 	}
 
-	var result map[string]interface{}
-	if err := json.Unmarshal(bodyBytes, &result); err != nil {
-		ui.PrintStatus("warning", fmt.Sprintf("Failed to parse response: %v", err))
-		ui.PrintStatus("info", "Continuing with update script...")
-		ExecuteUpdateScript(currentVersion)
-		return
-	}
+	ui.PrintStatus("info", "Update available! Installing...")
+	RunUpdate(currentVersion, latestVersion, useScript)
+}
 
-	// Extract latest version
-	latestVersion, ok := result["version"].(string)
-	if !ok || latestVersion == "" {
-		ui.PrintStatus("warning", "Could not determine latest version")
-		ui.PrintStatus("info", "Continuing with update script...")
+// RunUpdate installs latestVersion natively (checksum verified) or via the update script
+func RunUpdate(currentVersion, latestVersion string, useScript bool) {
+	if useScript {
+		ui.PrintSectionEnd()
 		ExecuteUpdateScript(currentVersion)
 		return
 	}
 
-	ui.PrintStatus("info", fmt.Sprintf("Latest version: %s", latestVersion))
-
-	if currentVersion == latestVersion {
-		ui.PrintStatus("success", "Already up to date!")
+	if err := InstallUpdate(currentVersion, latestVersion); err != nil {
+		logger.Error("Update failed: %v", err)
+		ui.PrintStatus("error", fmt.Sprintf("Update failed: %v", err))
+		ui.PrintStatus("info", "The current binary was not changed")
+		ui.PrintStatus("info", "Run 'catops update --script' to use the install script instead")
 		ui.PrintSectionEnd()
 		return
 	}
 
-	ui.PrintStatus("info", "Update available! Installing...")
+	ui.PrintStatus("info", "Run 'catops restart' to apply the update to the running service")
 	ui.PrintSectionEnd()
-	ExecuteUpdateScript(currentVersion)
 }
 
 // ExecuteUpdateScript runs the update script
@@ -181,14 +169,18 @@ func UpdateServerVersion(userToken, currentVersion string, cfg *config.Config) b
 	}
 
 	serverData := map[string]interface{}{
-		"platform":     platform,
-		"architecture": arch,
-		"type":         "update",
-		"timestamp":    fmt.Sprintf("%d", time.Now().Unix()),
-		"user_token":   userToken,
-		"server_id":    cfg.ServerID, // Include server_id for exact server match during update
+		"platform":      platform,
+		"architecture":  arch,
+		"type":          "update",
+		"timestamp":     fmt.Sprintf("%d", time.Now().Unix()),
+		"user_token":    userToken,
+		"server_labels": cfg.ServerLabels,
+		"server_id":     cfg.ServerID, // Include server_id for exact server match during update
 		"server_info": map[string]string{
 			"hostname":       hostname,
+			"display_name":   cfg.DisplayHostname(),
+			"environment":    cfg.Environment,
+			"region":         cfg.Region,
 			"os_type":        osName,
 			"os_version":     runtime.GOOS + "/" + runtime.GOARCH,
 			"catops_version": currentVersion,
@@ -200,13 +192,7 @@ func UpdateServerVersion(userToken, currentVersion string, cfg *config.Config) b
 
 	jsonData, _ := json.Marshal(serverData)
 
-	req, err := utils.CreateCLIRequest("POST", constants.INSTALL_URL, bytes.NewBuffer(jsonData), currentVersion)
-	if err != nil {
-		return false
-	}
-
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Do(req)
+	resp, err := utils.PostJSON(utils.NewHTTPClient(), constants.INSTALL_URL, jsonData, currentVersion)
 	if err != nil {
 		return false
 	}
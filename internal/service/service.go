@@ -4,9 +4,11 @@
 package service
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
 	"strings"
 
@@ -19,8 +21,14 @@ import (
 const (
 	serviceName        = "catops"
 	serviceDescription = "CatOps System Monitor - Lightweight server monitoring"
+
+	// systemdUnitPath is where the system-wide unit is installed
+	systemdUnitPath = "/etc/systemd/system/catops.service"
 )
 
+// ErrNotInstalled is returned by Repair when the service was never installed
+var ErrNotInstalled = errors.New("service is not installed")
+
 // Service wraps takama/daemon for cross-platform service management
 type Service struct {
 	daemon daemon.Daemon
@@ -57,6 +65,81 @@ func (s *Service) Install() (string, error) {
 	return status, nil
 }
 
+// Repair regenerates the service definition for the current binary location,
+// reloads and re-enables it, and restarts the service if it was running.
+// Only an installed service is repaired; otherwise ErrNotInstalled is returned.
+func (s *Service) Repair() (string, error) {
+	if _, err := s.daemon.Status(); errors.Is(err, daemon.ErrNotInstalled) {
+		return "", ErrNotInstalled
+	}
+
+	wasRunning := s.IsRunning()
+	if wasRunning {
+		s.daemon.Stop()
+	}
+
+	if _, err := s.daemon.Remove(); err != nil {
+		return "", fmt.Errorf("failed to remove old service definition: %w", err)
+	}
+	if _, err := s.daemon.Install("daemon"); err != nil {
+		return "", fmt.Errorf("failed to reinstall service: %w", err)
+	}
+	if err := pinExecStart(); err != nil {
+		logger.Warning("Failed to update ExecStart: %v", err)
+	}
+
+	status := "Service definition regenerated and enabled"
+	if wasRunning {
+		if _, err := s.daemon.Start(); err != nil {
+			return "", fmt.Errorf("service repaired but failed to start: %w", err)
+		}
+		status += ", service restarted"
+	}
+
+	logger.Info("Service repaired: %s", status)
+	return status, nil
+}
+
+// pinExecStart points the systemd unit at the running binary. The service
+// library prefers a "catops" found in PATH, which may be a different (stale)
+// copy than the one that was just updated.
+func pinExecStart() error {
+	if runtime.GOOS != "linux" {
+		return nil
+	}
+
+	content, err := os.ReadFile(systemdUnitPath)
+	if err != nil {
+		return nil // not a systemd install
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	if resolved, err := filepath.EvalSymlinks(executable); err == nil {
+		executable = resolved
+	}
+
+	want := "ExecStart=" + executable + " daemon"
+	lines := strings.Split(string(content), "\n")
+	changed := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "ExecStart=") && strings.TrimSpace(line) != want {
+			lines[i] = want
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	if err := os.WriteFile(systemdUnitPath, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+		return err
+	}
+	return exec.Command("systemctl", "daemon-reload").Run()
+}
+
 // Remove removes the service
 func (s *Service) Remove() (string, error) {
 	status, err := s.daemon.Remove()
@@ -142,7 +225,7 @@ func MigrateServiceFile() {
 		return
 	}
 
-	servicePath := "/etc/systemd/system/catops.service"
+	servicePath := systemdUnitPath
 
 	// Read current service file
 	content, err := os.ReadFile(servicePath)
@@ -0,0 +1,155 @@
+//go:build windows
+// +build windows
+
+package service
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"catops/internal/logger"
+)
+
+// taskName is the Scheduled Task that runs the daemon on Windows
+const taskName = "CatOps"
+
+// ErrNotInstalled is returned by Repair when the service was never installed
+var ErrNotInstalled = errors.New("service is not installed")
+
+// Service manages the daemon as a Windows Scheduled Task
+type Service struct{}
+
+// New creates a new Service instance
+func New() (*Service, error) {
+	if _, err := exec.LookPath("schtasks"); err != nil {
+		return nil, fmt.Errorf("schtasks not available: %w", err)
+	}
+	return &Service{}, nil
+}
+
+// Install registers a Scheduled Task that starts the daemon at boot (as SYSTEM,
+// requires an elevated prompt) or, without admin rights, at logon of the current user
+func (s *Service) Install() (string, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("could not determine binary location: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(executable); err == nil {
+		executable = resolved
+	}
+	command := fmt.Sprintf(`"%s" daemon`, executable)
+
+	if _, err := schtasks("/Create", "/TN", taskName, "/TR", command, "/SC", "ONSTART", "/RU", "SYSTEM", "/RL", "HIGHEST", "/F"); err == nil {
+		logger.Info("Service installed: scheduled task %s (at startup)", taskName)
+		return "Scheduled task installed (runs at startup)", nil
+	}
+
+	if out, err := schtasks("/Create", "/TN", taskName, "/TR", command, "/SC", "ONLOGON", "/F"); err != nil {
+		return "", fmt.Errorf("failed to create scheduled task: %s", out)
+	}
+	logger.Info("Service installed: scheduled task %s (at logon)", taskName)
+	return "Scheduled task installed (runs at logon)", nil
+}
+
+// Repair recreates the Scheduled Task for the current binary location
+// (only when the task exists) and restarts it if it was running
+func (s *Service) Repair() (string, error) {
+	if _, err := schtasks("/Query", "/TN", taskName); err != nil {
+		return "", ErrNotInstalled
+	}
+
+	wasRunning := s.IsRunning()
+	if wasRunning {
+		s.Stop()
+	}
+
+	// /Create /F replaces the existing task definition
+	if _, err := s.Install(); err != nil {
+		return "", err
+	}
+
+	status := "Scheduled task regenerated"
+	if wasRunning {
+		if _, err := s.Start(); err != nil {
+			return "", fmt.Errorf("task repaired but failed to start: %w", err)
+		}
+		status += ", service restarted"
+	}
+
+	logger.Info("Service repaired: %s", status)
+	return status, nil
+}
+
+// Remove deletes the Scheduled Task
+func (s *Service) Remove() (string, error) {
+	if out, err := schtasks("/Delete", "/TN", taskName, "/F"); err != nil {
+		return "", fmt.Errorf("failed to remove scheduled task: %s", out)
+	}
+	logger.Info("Service removed: scheduled task %s", taskName)
+	return "Scheduled task removed", nil
+}
+
+// Start runs the Scheduled Task
+func (s *Service) Start() (string, error) {
+	if out, err := schtasks("/Run", "/TN", taskName); err != nil {
+		return "", fmt.Errorf("failed to start scheduled task: %s", out)
+	}
+	logger.Info("Service started: scheduled task %s", taskName)
+	return "Service started", nil
+}
+
+// Stop ends the running Scheduled Task
+func (s *Service) Stop() (string, error) {
+	if out, err := schtasks("/End", "/TN", taskName); err != nil {
+		return "", fmt.Errorf("failed to stop scheduled task: %s", out)
+	}
+	logger.Info("Service stopped: scheduled task %s", taskName)
+	return "Service stopped", nil
+}
+
+// Status returns the service status; an error means the task is missing or not running
+func (s *Service) Status() (string, error) {
+	out, err := schtasks("/Query", "/TN", taskName, "/FO", "LIST")
+	if err != nil {
+		return "", fmt.Errorf("scheduled task %s is not installed", taskName)
+	}
+
+	for _, line := range strings.Split(out, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if ok && strings.TrimSpace(key) == "Status" && strings.TrimSpace(value) == "Running" {
+			return "Service is running", nil
+		}
+	}
+	return "", fmt.Errorf("service is stopped")
+}
+
+// IsRunning checks if the service is currently running
+func (s *Service) IsRunning() bool {
+	status, err := s.Status()
+	return err == nil && status != ""
+}
+
+// schtasks runs schtasks.exe and returns its trimmed combined output
+func schtasks(args ...string) (string, error) {
+	out, err := exec.Command("schtasks", args...).CombinedOutput()
+	return strings.TrimSpace(string(out)), err
+}
+
+// NotifyReady is a no-op on Windows (systemd only)
+func NotifyReady() {}
+
+// NotifyStopping is a no-op on Windows (systemd only)
+func NotifyStopping() {}
+
+// NotifyWatchdog is a no-op on Windows (systemd only)
+func NotifyWatchdog() {}
+
+// NotifyStatus is a no-op on Windows (systemd only)
+func NotifyStatus(status string) {}
+
+// MigrateServiceFile is a no-op on Windows (fixes a systemd unit bug)
+func MigrateServiceFile() {}
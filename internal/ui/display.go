@@ -8,6 +8,7 @@ import (
 	"catops/internal/metrics"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
 // Legacy color constants - kept for backward compatibility
@@ -25,24 +26,63 @@ const (
 	NC      = "\033[0m"
 )
 
+// quiet suppresses decorative output for automation (set by the global --quiet flag)
+var quiet bool
+
+// SetQuiet enables or disables quiet output
+func SetQuiet(enabled bool) {
+	quiet = enabled
+}
+
+// IsQuiet reports whether quiet output is enabled
+func IsQuiet() bool {
+	return quiet
+}
+
+// SetNoColor forces plain output without ANSI sequences (set by the global
+// --no-color flag). Without it colors are already off when stdout is not a
+// terminal, NO_COLOR is set or TERM=dumb.
+func SetNoColor(enabled bool) {
+	if enabled {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+}
+
+// IsPlain reports whether output is written without ANSI sequences
+func IsPlain() bool {
+	return lipgloss.ColorProfile() == termenv.Ascii
+}
+
 // PrintHeader prints the application header using lipgloss
 func PrintHeader() {
+	if quiet {
+		return
+	}
 	fmt.Println(RenderBanner())
 	fmt.Println(RenderSubtitle())
 }
 
 // PrintSection prints a section header using lipgloss
 func PrintSection(title string) {
+	if quiet {
+		return
+	}
 	fmt.Println(RenderSectionStart(title))
 }
 
 // PrintSectionEnd prints a section footer using lipgloss
 func PrintSectionEnd() {
+	if quiet {
+		return
+	}
 	fmt.Println(RenderSectionEnd())
 }
 
 // PrintTableSectionEnd prints a section footer for tables
 func PrintTableSectionEnd() {
+	if quiet {
+		return
+	}
 	fmt.Println(RenderTableSectionEnd())
 }
 
@@ -52,11 +92,19 @@ func PrintStatus(status, message string) {
 	if status == "debug" {
 		return
 	}
+	if quiet {
+		fmt.Printf("%s: %s\n", status, message)
+		return
+	}
 	fmt.Println(RenderStatus(status, message))
 }
 
 // PrintErrorWithSupport prints an error message with support contact
 func PrintErrorWithSupport(message string) {
+	if quiet {
+		fmt.Printf("error: %s\n", message)
+		return
+	}
 	fmt.Println(RenderStatus("error", message))
 	supportMsg := GrayStyle.Render("Need help? Telegram: @mfhonley")
 	fmt.Println("  " + InfoStyle.Render("💬") + " " + supportMsg)
@@ -157,6 +205,13 @@ func GetAlertEmoji(usage float64) string {
 
 // CreateProcessTable creates a formatted table for processes
 func CreateProcessTable(processes []metrics.ProcessInfo) string {
+	return CreateProcessTableSortedBy(processes, "", false)
+}
+
+// CreateProcessTableSortedBy creates the process table with the sortColumn
+// header (PID, CPU%, MEM%, MEMORY or COMMAND) marked as the sort order,
+// descending unless ascending is set. An empty sortColumn marks nothing.
+func CreateProcessTableSortedBy(processes []metrics.ProcessInfo, sortColumn string, ascending bool) string {
 	var result strings.Builder
 
 	if len(processes) == 0 {
@@ -164,23 +219,38 @@ func CreateProcessTable(processes []metrics.ProcessInfo) string {
 		return result.String()
 	}
 
-	// Calculate total CPU usage
-	var totalCPU float64
+	// Calculate total CPU and memory usage
+	var totalCPU, totalMemory float64
 	for _, proc := range processes {
 		totalCPU += proc.CPUUsage
+		totalMemory += proc.MemoryUsage
 	}
 
 	// Header with summary
 	summaryStyle := lipgloss.NewStyle().Foreground(SubtextColor)
-	result.WriteString("  " + summaryStyle.Render(fmt.Sprintf("Top %d processes using %.1f%% of total system CPU", len(processes), totalCPU)) + "\n")
+	summary := fmt.Sprintf("Top %d processes using %.1f%% of total system CPU", len(processes), totalCPU)
+	if sortColumn != "" {
+		summary = fmt.Sprintf("%d processes using %.1f%% CPU and %.1f%% memory", len(processes), totalCPU, totalMemory)
+	}
+	result.WriteString("  " + summaryStyle.Render(summary) + "\n")
 
 	// Separator
 	result.WriteString("  " + BorderStyle.Render(repeatChar(BoxHorizontal, TableWidth)) + "\n")
 
-	// Column headers
+	// Column headers, the sort column carries an arrow
+	headers := []string{"PID", "USER", "CPU%", "MEM%", "MEMORY", "STATUS", "TTY", "COMMAND"}
+	for i, header := range headers {
+		if header == sortColumn {
+			if ascending {
+				headers[i] = "▲" + header
+			} else {
+				headers[i] = "▼" + header
+			}
+		}
+	}
 	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(TextColor)
 	result.WriteString("  " + headerStyle.Render(fmt.Sprintf("%6s %15s %8s %8s %12s %8s %8s %s",
-		"PID", "USER", "CPU%", "MEM%", "MEMORY", "STATUS", "TTY", "COMMAND")) + "\n")
+		headers[0], headers[1], headers[2], headers[3], headers[4], headers[5], headers[6], headers[7])) + "\n")
 
 	// Separator
 	result.WriteString("  " + BorderStyle.Render(repeatChar(BoxHorizontal, TableWidth)) + "\n")
@@ -218,6 +288,112 @@ func CreateProcessTable(processes []metrics.ProcessInfo) string {
 	return result.String()
 }
 
+// CreateServiceTable creates a formatted table for detected services
+func CreateServiceTable(services []metrics.ServiceInfo) string {
+	var result strings.Builder
+
+	if len(services) == 0 {
+		result.WriteString("  " + GrayStyle.Render("No services detected") + "\n")
+		return result.String()
+	}
+
+	// Separator
+	result.WriteString("  " + BorderStyle.Render(repeatChar(BoxHorizontal, TableWidth)) + "\n")
+
+	// Column headers
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(TextColor)
+	result.WriteString("  " + headerStyle.Render(fmt.Sprintf("%-24s %-11s %-12s %-12s %6s %6s %10s  %s",
+		"SERVICE", "TYPE", "PIDS", "PORTS", "CPU%", "MEM%", "MEMORY", "HEALTH")) + "\n")
+
+	// Separator
+	result.WriteString("  " + BorderStyle.Render(repeatChar(BoxHorizontal, TableWidth)) + "\n")
+
+	for _, svc := range services {
+		pids := make([]string, len(svc.PIDs))
+		for i, pid := range svc.PIDs {
+			pids[i] = fmt.Sprintf("%d", pid)
+		}
+		ports := make([]string, len(svc.Ports))
+		for i, port := range svc.Ports {
+			ports[i] = fmt.Sprintf("%d", port)
+		}
+		portList := strings.Join(ports, ",")
+		if portList == "" {
+			portList = "-"
+		}
+
+		var healthStyle lipgloss.Style
+		switch svc.HealthStatus {
+		case "healthy":
+			healthStyle = SuccessStyle
+		case "degraded":
+			healthStyle = WarningStyle
+		case "unhealthy":
+			healthStyle = ErrorStyle
+		default:
+			healthStyle = MutedStyle
+		}
+		health := svc.HealthStatus
+		if health == "" {
+			health = "unknown"
+		}
+
+		name := svc.ServiceName
+		if svc.IsContainer {
+			name += " (container)"
+		}
+
+		row := fmt.Sprintf("%-24s %-11s %-12s %-12s %6.1f %6.1f %10s  ",
+			truncateString(name, 24),
+			truncateString(string(svc.ServiceType), 11),
+			truncateString(strings.Join(pids, ","), 12),
+			truncateString(portList, 12),
+			svc.CPUPercent,
+			svc.MemoryPercent,
+			formatKB(int64(svc.MemoryBytes/1024)))
+
+		result.WriteString("  " + row + healthStyle.Render(health) + "\n")
+	}
+
+	return result.String()
+}
+
+// CreateConnectionTable creates a formatted table of TCP connections grouped by
+// remote address, with the owning local processes when withProcesses is set
+func CreateConnectionTable(groups []metrics.RemoteConnections, withProcesses bool) string {
+	var result strings.Builder
+
+	if len(groups) == 0 {
+		result.WriteString("  " + GrayStyle.Render("No matching connections") + "\n")
+		return result.String()
+	}
+
+	// Separator
+	result.WriteString("  " + BorderStyle.Render(repeatChar(BoxHorizontal, TableWidth)) + "\n")
+
+	// Column headers
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(TextColor)
+	last := "STATES"
+	if withProcesses {
+		last = "STATES / PROCESSES"
+	}
+	result.WriteString("  " + headerStyle.Render(fmt.Sprintf("%-39s %6s  %s", "REMOTE", "CONNS", last)) + "\n")
+
+	// Separator
+	result.WriteString("  " + BorderStyle.Render(repeatChar(BoxHorizontal, TableWidth)) + "\n")
+
+	for _, g := range groups {
+		result.WriteString("  " + fmt.Sprintf("%-39s %6d  %s", truncateString(g.RemoteIP, 39), g.Count,
+			truncateString(formatCounts(g.States), TableWidth-48)) + "\n")
+		if withProcesses {
+			result.WriteString("  " + fmt.Sprintf("%-39s %6s  ", "", "") +
+				MutedStyle.Render(truncateString(formatCounts(g.Processes), TableWidth-48)) + "\n")
+		}
+	}
+
+	return result.String()
+}
+
 // CreateProcessTableByMemory creates a formatted table for processes sorted by memory
 func CreateProcessTableByMemory(processes []metrics.ProcessInfo) string {
 	var result strings.Builder
@@ -281,6 +457,69 @@ func CreateProcessTableByMemory(processes []metrics.ProcessInfo) string {
 	return result.String()
 }
 
+// CreateProcessTableByIO creates a formatted table for processes sorted by disk IO rate
+func CreateProcessTableByIO(processes []metrics.ProcessInfo) string {
+	var result strings.Builder
+
+	if len(processes) == 0 {
+		result.WriteString("  " + GrayStyle.Render("No processes found") + "\n")
+		return result.String()
+	}
+
+	// Calculate total IO rate
+	var totalRead, totalWrite uint64
+	for _, proc := range processes {
+		totalRead += proc.IOReadRate
+		totalWrite += proc.IOWriteRate
+	}
+
+	// Header with summary
+	summaryStyle := lipgloss.NewStyle().Foreground(SubtextColor)
+	result.WriteString("  " + summaryStyle.Render(fmt.Sprintf("Top %d processes reading %s/s and writing %s/s",
+		len(processes), formatKB(int64(totalRead/1024)), formatKB(int64(totalWrite/1024)))) + "\n")
+
+	// Separator
+	result.WriteString("  " + BorderStyle.Render(repeatChar(BoxHorizontal, TableWidth)) + "\n")
+
+	// Column headers
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(TextColor)
+	result.WriteString("  " + headerStyle.Render(fmt.Sprintf("%6s %15s %12s %12s %8s %8s %s",
+		"PID", "USER", "READ/s", "WRITE/s", "CPU%", "STATUS", "COMMAND")) + "\n")
+
+	// Separator
+	result.WriteString("  " + BorderStyle.Render(repeatChar(BoxHorizontal, TableWidth)) + "\n")
+
+	// Process rows
+	for _, proc := range processes {
+		var statusStyle lipgloss.Style
+		switch proc.Status {
+		case "R":
+			statusStyle = SuccessStyle
+		case "S":
+			statusStyle = WarningStyle
+		case "Z":
+			statusStyle = ErrorStyle
+		case "D":
+			statusStyle = InfoStyle
+		default:
+			statusStyle = MutedStyle
+		}
+
+		row := fmt.Sprintf("%6d %15s %12s %12s %8.1f ",
+			proc.PID,
+			truncateString(proc.User, 15),
+			formatKB(int64(proc.IOReadRate/1024)),
+			formatKB(int64(proc.IOWriteRate/1024)),
+			proc.CPUUsage)
+
+		result.WriteString("  " + row)
+		result.WriteString(statusStyle.Render(fmt.Sprintf("%8s", proc.Status)))
+		result.WriteString(fmt.Sprintf(" %s\n", truncateString(proc.Command, 25)))
+	}
+
+	return result.String()
+}
+
 // CreateDetailedResourceTable creates a detailed resource usage table
 func CreateDetailedResourceTable(title string, usage metrics.ResourceUsage, formatFunc func(float64, int64, int64) string) string {
 	var result strings.Builder
@@ -324,6 +563,26 @@ func truncateString(s string, maxLen int) string {
 	return s[:maxLen-3] + "..."
 }
 
+// formatCounts renders counts as "key n, key n", largest first
+func formatCounts(counts map[string]int) string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%s %d", k, counts[k])
+	}
+	return strings.Join(parts, ", ")
+}
+
 func formatKB(kb int64) string {
 	switch {
 	case kb < 1024:
@@ -87,8 +87,14 @@ func NewSimpleSpinner(message string) *SimpleSpinner {
 	}
 }
 
-// Start starts the spinner animation
+// Start starts the spinner animation. In plain output (redirected, NO_COLOR,
+// --no-color) the message is printed once instead of animated.
 func (s *SimpleSpinner) Start() {
+	if IsPlain() {
+		fmt.Printf("  %s\n", s.message)
+		go func() { <-s.done }()
+		return
+	}
 	go func() {
 		style := lipgloss.NewStyle().Foreground(PrimaryColor)
 		for {
@@ -107,27 +113,34 @@ func (s *SimpleSpinner) Start() {
 // Stop stops the spinner and clears the line
 func (s *SimpleSpinner) Stop() {
 	s.done <- true
-	fmt.Print("\r\033[K") // Clear line
+	s.clearLine()
+}
+
+// clearLine erases the animated spinner line
+func (s *SimpleSpinner) clearLine() {
+	if !IsPlain() {
+		fmt.Print("\r\033[K")
+	}
 }
 
 // StopWithSuccess stops the spinner and shows a success message
 func (s *SimpleSpinner) StopWithSuccess(message string) {
 	s.done <- true
-	fmt.Print("\r\033[K") // Clear line
+	s.clearLine()
 	fmt.Println(RenderStatus("success", message))
 }
 
 // StopWithError stops the spinner and shows an error message
 func (s *SimpleSpinner) StopWithError(message string) {
 	s.done <- true
-	fmt.Print("\r\033[K") // Clear line
+	s.clearLine()
 	fmt.Println(RenderStatus("error", message))
 }
 
 // StopWithWarning stops the spinner and shows a warning message
 func (s *SimpleSpinner) StopWithWarning(message string) {
 	s.done <- true
-	fmt.Print("\r\033[K") // Clear line
+	s.clearLine()
 	fmt.Println(RenderStatus("warning", message))
 }
 
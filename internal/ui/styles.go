@@ -1,6 +1,8 @@
 package ui
 
 import (
+	"strings"
+
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -259,6 +261,54 @@ func RenderProgressBar(percent float64, width int) string {
 	return bar
 }
 
+// sparkTicks are the block characters used by RenderSparkline, lowest to highest
+var sparkTicks = []rune("▁▂▃▄▅▆▇█")
+
+// RenderSparkline returns an unstyled sparkline of values resampled to width points
+func RenderSparkline(values []float64, width int) string {
+	if len(values) == 0 {
+		return ""
+	}
+	if width <= 0 || width > len(values) {
+		width = len(values)
+	}
+
+	// Average values into width buckets
+	points := make([]float64, width)
+	for i := range points {
+		start := i * len(values) / width
+		end := (i + 1) * len(values) / width
+		if end <= start {
+			end = start + 1
+		}
+		sum := 0.0
+		for _, v := range values[start:end] {
+			sum += v
+		}
+		points[i] = sum / float64(end-start)
+	}
+
+	min, max := points[0], points[0]
+	for _, p := range points {
+		if p < min {
+			min = p
+		}
+		if p > max {
+			max = p
+		}
+	}
+
+	var b strings.Builder
+	for _, p := range points {
+		idx := 0
+		if max > min {
+			idx = int((p - min) / (max - min) * float64(len(sparkTicks)-1))
+		}
+		b.WriteRune(sparkTicks[idx])
+	}
+	return b.String()
+}
+
 // Helper function to repeat a character
 func repeatChar(char string, count int) string {
 	if count <= 0 {
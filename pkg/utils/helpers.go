@@ -1,18 +1,99 @@
 package utils
 
 import (
+	"bytes"
+	"compress/gzip"
+	"errors"
 	"fmt"
 	"io"
 	"math"
 	"net/http"
+	"net/url"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/disk"
 
 	constants "catops/config"
 )
 
+// Timeout for backend API clients created by NewHTTPClient (http_timeout)
+var httpTimeout = constants.DEFAULT_HTTP_TIMEOUT * time.Second
+
+// User-Agent sent with every outbound request (user_agent), and extra headers
+// for proxies and WAFs (http_headers), sent only to headerHosts and only over
+// TLS unless headersInsecure is set
+var (
+	userAgent       = constants.HEADER_USER_AGENT
+	extraHeaders    map[string]string
+	headerHosts     []string
+	headersInsecure bool
+)
+
+// JSON bodies at least this large are gzipped by PostJSON
+const gzipMinSize = 1024
+
+// Endpoints (URL -> true) that rejected a gzipped body but took it plain,
+// later bodies to them are sent plain
+var gzipUnsupported sync.Map
+
+// Free space catops leaves on a filesystem when writing its own files (min_free_disk)
+var minFreeDisk atomic.Uint64
+
+func init() {
+	minFreeDisk.Store(constants.DEFAULT_MIN_FREE_DISK_MB * 1024 * 1024)
+}
+
+// ErrLowDiskSpace is returned by EnsureFreeSpace when a write would eat into min_free_disk
+var ErrLowDiskSpace = errors.New("not enough free disk space")
+
+// SetMinFreeDisk sets the free space floor for EnsureFreeSpace in bytes (0 disables the check)
+func SetMinFreeDisk(bytes uint64) {
+	minFreeDisk.Store(bytes)
+}
+
+// EnsureFreeSpace checks that writing size bytes to path leaves at least
+// min_free_disk free on its filesystem, so catops' own buffers, history and
+// snapshots never help fill a disk. The write should be dropped on error.
+// Filesystems whose usage can't be read are not checked.
+func EnsureFreeSpace(path string, size int64) error {
+	floor := minFreeDisk.Load()
+	if floor == 0 {
+		return nil
+	}
+
+	// The file, and maybe its directory, may not exist yet
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	dir := filepath.Dir(path)
+	for {
+		if _, err := os.Stat(dir); err == nil || filepath.Dir(dir) == dir {
+			break
+		}
+		dir = filepath.Dir(dir)
+	}
+
+	usage, err := disk.Usage(dir)
+	if err != nil {
+		return nil
+	}
+	if size < 0 {
+		size = 0
+	}
+	if usage.Free < floor+uint64(size) {
+		return fmt.Errorf("%w on %s: %s free, min_free_disk is %s", ErrLowDiskSpace,
+			usage.Path, FormatBytes(int64(usage.Free)), FormatBytes(int64(floor)))
+	}
+	return nil
+}
+
 // FormatPercentage formats a float as percentage
 func FormatPercentage(value float64) string {
 	return fmt.Sprintf("%.1f%%", value)
@@ -28,6 +109,41 @@ func ParseFloat(s string) (float64, error) {
 	return strconv.ParseFloat(strings.TrimSpace(s), 64)
 }
 
+// ParsePercentage parses a number with an optional trailing "%" (e.g. "80%")
+func ParsePercentage(s string) (float64, error) {
+	return ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "%"))
+}
+
+// ParseSize parses a size with an optional unit suffix into bytes.
+// Units are binary like FormatBytes: B, KB, MB, GB, TB (also K/M/G/T and KiB/MiB/...).
+// A bare number is bytes.
+func ParseSize(s string) (float64, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	i := strings.IndexFunc(s, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+	if i == -1 {
+		return ParseFloat(s)
+	}
+
+	value, err := ParseFloat(s[:i])
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+
+	unit := strings.TrimSuffix(strings.TrimSuffix(strings.TrimSpace(s[i:]), "B"), "I")
+	multipliers := map[string]float64{
+		"":  1,
+		"K": 1 << 10,
+		"M": 1 << 20,
+		"G": 1 << 30,
+		"T": 1 << 40,
+	}
+	multiplier, ok := multipliers[unit]
+	if !ok {
+		return 0, fmt.Errorf("invalid size unit in %q", s)
+	}
+	return value * multiplier, nil
+}
+
 // ParseInt parses a string to int64 with error handling
 func ParseInt(s string) (int64, error) {
 	return strconv.ParseInt(strings.TrimSpace(s), 10, 64)
@@ -47,6 +163,18 @@ func TruncateString(s string, maxLen int) string {
 	return s[:maxLen-3] + "..."
 }
 
+// MaskSecret shows only the first and last visible characters of a secret.
+// Secrets too short to hide at least half of them are fully masked.
+func MaskSecret(s string, visible int) string {
+	if s == "" {
+		return ""
+	}
+	if visible <= 0 || len(s) < visible*4 {
+		return "****"
+	}
+	return s[:visible] + "..." + s[len(s)-visible:]
+}
+
 // IsValidPercentage checks if a value is a valid percentage (0-100)
 func IsValidPercentage(value float64) bool {
 	return value >= 0 && value <= 100
@@ -126,10 +254,85 @@ func AddCLIHeaders(req *http.Request, version string) {
 	}
 
 	// Add required headers for new backend
-	req.Header.Set("User-Agent", constants.HEADER_USER_AGENT)
 	req.Header.Set(constants.HEADER_PLATFORM, runtime.GOOS)
 	req.Header.Set(constants.HEADER_VERSION, version)
 	req.Header.Set("Content-Type", "application/json")
+	ApplyRequestHeaders(req)
+}
+
+// SetRequestHeaders sets the User-Agent (empty keeps the default) and the
+// extra headers, with the hosts they are sent to (empty = the CatOps backend)
+// and whether plain http:// endpoints get them too
+func SetRequestHeaders(agent string, headers map[string]string, hosts []string, insecure bool) {
+	if agent = strings.TrimSpace(agent); agent != "" {
+		userAgent = agent
+	}
+	extraHeaders = headers
+	headerHosts = hosts
+	headersInsecure = insecure
+}
+
+// RequestHeaders returns the headers for a request to target as one map, for
+// clients that take headers as a map (OTLP exports, CBOR requests): the
+// User-Agent, plus the extra headers when target is in their scope
+func RequestHeaders(target *url.URL) map[string]string {
+	headers := map[string]string{"User-Agent": userAgent}
+	if !extraHeadersAllowed(target) {
+		return headers
+	}
+	for k, v := range extraHeaders {
+		headers[http.CanonicalHeaderKey(k)] = v // config keys come lowercased
+	}
+	return headers
+}
+
+// ApplyRequestHeaders sets the headers for req (see RequestHeaders). Extra
+// headers are applied last, so they can override the built-in ones.
+func ApplyRequestHeaders(req *http.Request) {
+	for k, v := range RequestHeaders(req.URL) {
+		req.Header.Set(k, v)
+	}
+}
+
+// extraHeadersAllowed reports whether the extra headers may be sent to target:
+// its host (or host:port) is one of headerHosts, and it is https unless
+// plaintext was allowed
+func extraHeadersAllowed(target *url.URL) bool {
+	if len(extraHeaders) == 0 || target == nil {
+		return false
+	}
+	if target.Scheme != "https" && !headersInsecure {
+		return false
+	}
+
+	hosts := headerHosts
+	if len(hosts) == 0 {
+		backend, _ := url.Parse(constants.CATOPS_API_URL)
+		hosts = []string{backend.Hostname()}
+	}
+	for _, host := range hosts {
+		if strings.EqualFold(host, target.Hostname()) || strings.EqualFold(host, target.Host) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetHTTPTimeout sets the timeout used by NewHTTPClient (non-positive keeps the default)
+func SetHTTPTimeout(timeout time.Duration) {
+	if timeout > 0 {
+		httpTimeout = timeout
+	}
+}
+
+// HTTPTimeout returns the timeout for backend API requests
+func HTTPTimeout() time.Duration {
+	return httpTimeout
+}
+
+// NewHTTPClient creates an HTTP client for backend API requests
+func NewHTTPClient() *http.Client {
+	return &http.Client{Timeout: httpTimeout}
 }
 
 // CreateCLIRequest creates HTTP request with proper CLI headers
@@ -142,3 +345,57 @@ func CreateCLIRequest(method, url string, body io.Reader, version string) (*http
 	AddCLIHeaders(req, version)
 	return req, nil
 }
+
+// PostJSON posts a JSON body with CLI headers. Bodies of gzipMinSize or more are
+// gzipped (Content-Encoding: gzip). If the answer is 400, 415 or 501, as from
+// backends and proxies that don't take gzipped bodies, the request is resent
+// uncompressed; when that succeeds, compression stays off for the endpoint.
+func PostJSON(client *http.Client, url string, body []byte, version string) (*http.Response, error) {
+	retried := false
+	if _, plain := gzipUnsupported.Load(url); len(body) >= gzipMinSize && !plain {
+		if compressed, err := gzipBytes(body); err == nil {
+			req, err := CreateCLIRequest("POST", url, bytes.NewReader(compressed), version)
+			if err != nil {
+				return nil, err
+			}
+			req.Header.Set("Content-Encoding", "gzip")
+
+			resp, err := client.Do(req)
+			if err != nil || !gzipRejected(resp.StatusCode) {
+				return resp, err
+			}
+			resp.Body.Close()
+			retried = true
+		}
+	}
+
+	req, err := CreateCLIRequest("POST", url, bytes.NewReader(body), version)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	// Only a plain body that gets through shows the gzip one was the problem
+	if retried && err == nil && resp.StatusCode < 300 {
+		gzipUnsupported.Store(url, true)
+	}
+	return resp, err
+}
+
+// gzipRejected reports whether status may be a backend or proxy refusing a
+// gzipped body: 415, or 400 and 501 from ones that don't parse it
+func gzipRejected(status int) bool {
+	return status == http.StatusUnsupportedMediaType || status == http.StatusBadRequest || status == http.StatusNotImplemented
+}
+
+// gzipBytes compresses data with gzip
+func gzipBytes(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}